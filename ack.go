@@ -0,0 +1,201 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// AckHandler is an optional interface for workers that acknowledge tasks
+// instead of returning from Handle, giving at least once processing within
+// the pool. Every task the worker takes comes as a Delivery, which stays
+// pending until it is acked. Nacked deliveries, and those still pending when
+// the worker is removed, are delivered again. The context given to Receive is
+// cancelled once the delivery is settled.
+type AckHandler[Task any, Result any] interface {
+	Receive(ctx context.Context, d *Delivery[Task, Result])
+}
+
+// Delivery is a task handed to an AckHandler. Acking or nacking it may happen
+// from any goroutine, but the worker doesn't get another task until it does.
+type Delivery[Task any, Result any] struct {
+	Task Task
+
+	mutex   *sync.Mutex
+	done    chan struct{}
+	settled bool
+	result  Result
+	nacked  bool
+	// expires is when the lease on the delivery runs out, if it has one
+	expires time.Time
+	clock   Clock
+}
+
+func newDelivery[Task any, Result any](task Task, visibility time.Duration, clock Clock) *Delivery[Task, Result] {
+	d := &Delivery[Task, Result]{
+		Task:  task,
+		mutex: &sync.Mutex{},
+		done:  make(chan struct{}),
+		clock: clock,
+	}
+	if visibility > 0 {
+		d.expires = clock.Now().Add(visibility)
+	}
+	return d
+}
+
+// Ack completes the task with result. It returns false if the delivery was
+// already acked, nacked or given up on.
+func (d *Delivery[Task, Result]) Ack(result Result) bool {
+	return d.settle(func() {
+		d.result = result
+	})
+}
+
+// Nack gives the task back to the pool to be delivered again. It returns
+// false if the delivery was already acked, nacked or given up on.
+func (d *Delivery[Task, Result]) Nack() bool {
+	return d.settle(func() {
+		d.nacked = true
+	})
+}
+
+// Extend renews the lease on the delivery for d from now, when the pool has a
+// visibility timeout. It returns false if the delivery was already acked,
+// nacked or given up on.
+func (d *Delivery[Task, Result]) Extend(timeout time.Duration) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.settled {
+		return false
+	}
+	if !d.expires.IsZero() {
+		d.expires = d.clock.Now().Add(timeout)
+	}
+	return true
+}
+
+func (d *Delivery[Task, Result]) settle(fn func()) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.settled {
+		return false
+	}
+	fn()
+	d.settled = true
+	close(d.done)
+	return true
+}
+
+// leaseLeft is how long until the lease runs out, if the delivery has one.
+func (d *Delivery[Task, Result]) leaseLeft() (time.Duration, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.expires.IsZero() {
+		return 0, false
+	}
+	return d.expires.Sub(d.clock.Now()), true
+}
+
+// WithVisibilityTimeout leases every Delivery to its worker for d. A delivery
+// that is neither acked, nacked nor extended in time is given up on and
+// delivered again, the context given to Receive being cancelled.
+func WithVisibilityTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.visibilityTimeout = d
+	}
+}
+
+// ackHandler makes an AckHandler a TaskHandler, waiting for each delivery to
+// be settled. Receive runs on its own goroutine so that a worker hung on a
+// delivery doesn't keep it from being given up on.
+type ackHandler[Task any, Result any] struct {
+	AckHandler[Task, Result]
+	visibility time.Duration
+	clock      Clock
+	// launch starts receive, see GorkPool.launch
+	launch func(kind string, fn func())
+	// recovered is told about panics in Receive, which are left alone unless
+	// recovers
+	recovers  func() bool
+	recovered func(task any, v any)
+}
+
+func (h ackHandler[Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d := newDelivery[Task, Result](task, h.visibility, h.clock)
+	h.launch("receive", func() {
+		h.receive(ctx, d)
+	})
+
+	var (
+		timer Timer
+		lease <-chan time.Time
+	)
+	if left, ok := d.leaseLeft(); ok {
+		timer = h.clock.NewTimer(left)
+		defer timer.Stop()
+		lease = timer.C()
+	}
+
+	var zero Result
+	for {
+		select {
+		case <-d.done:
+			if d.nacked {
+				return zero, NewErrNacked()
+			}
+			return d.result, nil
+		case <-ctx.Done():
+			if d.settle(func() {}) {
+				return zero, ctx.Err()
+			}
+		case <-lease:
+			if left, _ := d.leaseLeft(); left > 0 {
+				// The lease was extended
+				timer.Reset(left)
+				continue
+			}
+			if d.settle(func() {}) {
+				return zero, NewErrLeaseExpired()
+			}
+		}
+	}
+}
+
+// receive hands d to the worker. A panic counts as the worker dying with d,
+// which is then delivered again.
+func (h ackHandler[Task, Result]) receive(ctx context.Context, d *Delivery[Task, Result]) {
+	if h.recovers() {
+		defer func() {
+			if v := recover(); v != nil {
+				h.recovered(d.Task, v)
+				d.Nack()
+			}
+		}()
+	}
+	h.Receive(ctx, d)
+}
+
+// redeliver tells whether the task failed with err must be queued again
+// without going through the retry policy.
+func redeliver(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		nacked  ErrNacked
+		expired ErrLeaseExpired
+	)
+	return errors.As(err, &nacked) || errors.As(err, &expired) || connLost(err)
+}
+
+func connLost(err error) bool {
+	if err == nil {
+		return false
+	}
+	var lost ErrConnLost
+	return errors.As(err, &lost)
+}
@@ -0,0 +1,218 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+type testAcker struct {
+	id int
+	fn func(ctx context.Context, d *gorkpool.Delivery[int, int])
+}
+
+func (w *testAcker) ID() int {
+	return w.id
+}
+
+func (w *testAcker) Process() {}
+
+func (w *testAcker) SignalRemoval() {}
+
+func (w *testAcker) Receive(ctx context.Context, d *gorkpool.Delivery[int, int]) {
+	w.fn(ctx, d)
+}
+
+func setupAckPool(fn func(id int, ctx context.Context, d *gorkpool.Delivery[int, int])) (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testAcker{id: id, fn: func(ctx context.Context, d *gorkpool.Delivery[int, int]) {
+			fn(id, ctx, d)
+		}}, nil
+	}, gorkpool.WithOutputBufferSize(10)), cancel
+}
+
+func TestAckHandler(t *testing.T) {
+	// Setup
+	deliveries := 0
+	pool, cancel := setupAckPool(func(id int, ctx context.Context, d *gorkpool.Delivery[int, int]) {
+		deliveries++
+		if deliveries == 1 {
+			d.Nack()
+			return
+		}
+		go d.Ack(-d.Task)
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result to be %d, got %d", -1, got)
+	}
+	if deliveries != 2 {
+		t.Errorf("expected nacked task to be delivered again, got %d deliveries", deliveries)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestAckHandlerRemovedWorker(t *testing.T) {
+	// Setup
+	received := make(chan *gorkpool.Delivery[int, int], 1)
+	pool, cancel := setupAckPool(func(id int, ctx context.Context, d *gorkpool.Delivery[int, int]) {
+		if id == 0 {
+			received <- d
+			return
+		}
+		d.Ack(-d.Task)
+	})
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	d := <-received
+	// Action
+	pool.RemoveWorkerById(0)
+	pool.AddWorker(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected unacked task to be delivered again, got %d", got)
+	}
+	if d.Ack(0) {
+		t.Error("expected late ack to be ignored")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithVisibilityTimeout(t *testing.T) {
+	// Setup
+	var deliveries int32
+	abandoned := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testAcker{id: id, fn: func(ctx context.Context, d *gorkpool.Delivery[int, int]) {
+			if atomic.AddInt32(&deliveries, 1) == 1 {
+				// Hang until the lease runs out
+				<-ctx.Done()
+				close(abandoned)
+				return
+			}
+			d.Ack(-d.Task)
+		}}, nil
+	}, gorkpool.WithVisibilityTimeout(10*time.Millisecond), gorkpool.WithOutputBufferSize(1))
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result to be %d, got %d", -1, got)
+	}
+	<-abandoned
+	if got := atomic.LoadInt32(&deliveries); got != 2 {
+		t.Errorf("expected task to be delivered again, got %d deliveries", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestDeliveryExtend(t *testing.T) {
+	// Setup
+	var deliveries int32
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testAcker{id: id, fn: func(ctx context.Context, d *gorkpool.Delivery[int, int]) {
+			atomic.AddInt32(&deliveries, 1)
+			for i := 0; i < 5; i++ {
+				time.Sleep(10 * time.Millisecond)
+				d.Extend(30 * time.Millisecond)
+			}
+			d.Ack(-d.Task)
+		}}, nil
+	}, gorkpool.WithVisibilityTimeout(30*time.Millisecond), gorkpool.WithOutputBufferSize(1))
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result to be %d, got %d", -1, got)
+	}
+	if got := atomic.LoadInt32(&deliveries); got != 1 {
+		t.Errorf("expected extended delivery to be kept, got %d deliveries", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestAckHandlerContextAfterAck(t *testing.T) {
+	// Setup
+	var wg sync.WaitGroup
+	mismatches := make(chan string, 10)
+	pool, cancel := setupAckPool(func(id int, ctx context.Context, d *gorkpool.Delivery[int, int]) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := d.Task
+			d.Ack(task)
+			// The context outlives the delivery, and the task with it
+			time.Sleep(time.Millisecond)
+			envelope, _ := gorkpool.EnvelopeFrom(ctx)
+			if got := envelope.Metadata["task"]; got != strconv.Itoa(task) {
+				mismatches <- got
+			}
+		}()
+	})
+	pool.AddWorker(0)
+	// Action
+	for i := 0; i < 10; i++ {
+		pool.AddTask(i, gorkpool.WithMetadata("task", strconv.Itoa(i)))
+		<-pool.OutputCh()
+	}
+	wg.Wait()
+	close(mismatches)
+	// Assert
+	for got := range mismatches {
+		t.Errorf("expected the context of a delivery to keep its task after the ack, got task %q", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestAckHandlerReceiveTracked(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	pool, cancel := setupAckPool(func(id int, ctx context.Context, d *gorkpool.Delivery[int, int]) {
+		d.Ack(-d.Task)
+		// Still running once the delivery is settled
+		<-release
+	})
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-pool.OutputCh()
+	cancel()
+	pool.Wait()
+	// Action
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	err := pool.VerifyClean(ctx)
+	// Assert
+	var leak gorkpool.ErrGoroutineLeak
+	if !errors.As(err, &leak) || !reflect.DeepEqual(leak.Goroutines(), map[string]int{"receive": 1}) {
+		t.Errorf("expected the Receive still running to be tracked, got %v", err)
+	}
+	// Cleanup
+	close(release)
+	gorkpooltest.VerifyClean(t, pool, time.Second)
+}
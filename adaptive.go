@@ -0,0 +1,97 @@
+package gorkpool
+
+import "time"
+
+// QueueResize is a change of the queue size made by WithAdaptiveQueue, along
+// with the rates it was based on, in tasks per second over the last interval.
+type QueueResize struct {
+	From          int
+	To            int
+	ArrivalRate   float64
+	DepartureRate float64
+}
+
+type adaptiveQueue struct {
+	min      int
+	max      int
+	interval time.Duration
+	onResize func(QueueResize)
+}
+
+// WithAdaptiveQueue lets the queue size follow the load, between min and max
+// tasks, in place of WithQueueSize. Every interval, the queue doubles if
+// producers found it full and halves once it stays mostly empty with tasks
+// being taken as fast as they come. onResize, if not nil, is told about every
+// change, on the goroutine of the pool adjusting the size.
+func WithAdaptiveQueue(min int, max int, interval time.Duration, onResize func(QueueResize)) Option {
+	return func(c *config) {
+		if min < 1 {
+			min = 1
+		}
+		if max < min {
+			max = min
+		}
+		c.adaptiveQueue = &adaptiveQueue{min: min, max: max, interval: interval, onResize: onResize}
+	}
+}
+
+// clamp brings size within the bounds of the adaptive queue.
+func (a *adaptiveQueue) clamp(size int) int {
+	if size < a.min {
+		return a.min
+	}
+	if size > a.max {
+		return a.max
+	}
+	return size
+}
+
+// next is the size the queue should have given how it was used over the last
+// interval: used slots out of size, arrived and departed tasks and whether
+// producers found it full.
+func (a *adaptiveQueue) next(used int, size int, arrived uint64, departed uint64, full bool) int {
+	switch {
+	case full || (4*used >= 3*size && arrived > departed):
+		return a.clamp(2 * size)
+	case 4*used <= size && arrived <= departed:
+		return a.clamp(size / 2)
+	default:
+		return size
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) adaptQueue() {
+	a := p.cfg.adaptiveQueue
+	interval := a.interval
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := p.cfg.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := p.cfg.clock.Now()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case now := <-ticker.C():
+			used, size, arrived, departed, full := p.queue.slots.sample()
+			elapsed := now.Sub(last).Seconds()
+			last = now
+			to := a.next(used, size, arrived, departed, full)
+			if to == size {
+				continue
+			}
+			p.queue.slots.resize(to)
+			p.queue.changed()
+			resize := QueueResize{From: size, To: to}
+			if elapsed > 0 {
+				resize.ArrivalRate = float64(arrived) / elapsed
+				resize.DepartureRate = float64(departed) / elapsed
+			}
+			if a.onResize != nil {
+				a.onResize(resize)
+			}
+		}
+	}
+}
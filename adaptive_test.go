@@ -0,0 +1,50 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithAdaptiveQueue(t *testing.T) {
+	// Setup
+	c := gorkpool.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	resizes := make(chan gorkpool.QueueResize, 4)
+	release := make(chan struct{})
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		<-release
+		return x, nil
+	}, gorkpool.WithClock(c), gorkpool.WithQueueSize(2), gorkpool.WithBackpressure(gorkpool.BackpressureError), gorkpool.WithAdaptiveQueue(2, 8, time.Second, func(r gorkpool.QueueResize) {
+		resizes <- r
+	}))
+	waitForWaiters(t, c, 1)
+	var futures []*gorkpool.Future[int]
+	for {
+		f, err := pool.Submit(len(futures))
+		if err != nil {
+			break
+		}
+		futures = append(futures, f)
+	}
+	// Action
+	c.Advance(time.Second)
+	grown := <-resizes
+	close(release)
+	for _, f := range futures {
+		f.Wait(context.Background())
+	}
+	waitFor(t, func() bool { return pool.QueueLen() == 0 })
+	c.Advance(time.Second)
+	shrunk := <-resizes
+	// Assert
+	if grown.From != 2 || grown.To != 4 {
+		t.Errorf("expected the full queue to grow from 2 to 4, got %+v", grown)
+	}
+	if shrunk.From != 4 || shrunk.To != 2 {
+		t.Errorf("expected the empty queue to shrink from 4 to 2, got %+v", shrunk)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
@@ -0,0 +1,91 @@
+package gorkpool
+
+// BackpressurePolicy decides what submitting a task does when the queue is
+// full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits for room in the queue.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropNewest drops the task being submitted.
+	BackpressureDropNewest
+	// BackpressureDropOldest drops the task that has been queued the longest
+	// to make room.
+	BackpressureDropOldest
+	// BackpressureError fails the submission with ErrQueueFull.
+	BackpressureError
+)
+
+// WithBackpressure sets what submitting a task does when the queue is full.
+// Dropped tasks fail with ErrQueueFull, reaching their futures, ErrorCh and
+// the dead letter handler. Retries always wait for room.
+func WithBackpressure(policy BackpressurePolicy) Option {
+	return func(c *config) {
+		c.backpressure = policy
+	}
+}
+
+// offer queues env according to the backpressure policy, waiting for room
+// until done or cancel is closed when blocking. It returns false if env was
+// neither queued nor dropped.
+func (p *GorkPool[Id, Task, Result]) offer(env *envelope[Task, Result], done <-chan struct{}, cancel <-chan struct{}) (bool, error) {
+	if !p.queue.reserve(env) {
+		return false, NewErrQuotaExceeded(env.tenant)
+	}
+	// Once queued, env may be handled and recycled before this returns
+	if env.reserved {
+		defer p.queue.unreserve(env)
+	}
+
+	if p.cfg.backpressure == BackpressureBlock {
+		return p.pushUntil(env, done, cancel), nil
+	}
+
+	select {
+	case <-done:
+		return false, nil
+	default:
+	}
+	if p.tryPush(env) == nil {
+		return true, nil
+	}
+
+	switch p.cfg.backpressure {
+	case BackpressureDropOldest:
+		for {
+			oldest, ok := p.queue.evictOldest()
+			if !ok {
+				// Nothing queued to make room with
+				break
+			}
+			if !p.isCanceled(oldest) {
+				p.drop(oldest, NewErrQueueFull())
+			}
+			if p.tryPush(env) == nil {
+				return true, nil
+			}
+		}
+		fallthrough
+	case BackpressureDropNewest:
+		p.drop(env, NewErrQueueFull())
+		return true, nil
+	default:
+		return false, NewErrQueueFull()
+	}
+}
+
+// tryPush queues env if there is room right away, with ErrQueueFull if not.
+func (p *GorkPool[Id, Task, Result]) tryPush(env *envelope[Task, Result]) error {
+	if err := p.validate(env); err != nil {
+		return err
+	}
+
+	p.track(env)
+	p.numberResult(env)
+	if !p.queue.tryPush(env) {
+		p.finish(env)
+		p.skipResult(env)
+		return NewErrQueueFull()
+	}
+	return nil
+}
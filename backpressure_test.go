@@ -0,0 +1,83 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func setupFullPool(policy gorkpool.BackpressurePolicy) (*gorkpool.GorkPool[int, int, int], *gorkpool.Future[int], chan struct{}) {
+	busy, release := make(chan struct{}, 1), make(chan struct{})
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			select {
+			case busy <- struct{}{}:
+			default:
+			}
+			<-release
+			return x, nil
+		}}, nil
+	}, gorkpool.WithQueueSize(2), gorkpool.WithBackpressure(policy))
+	pool.AddWorker(0)
+	// One task being handled, one held by the dispatcher and one queued
+	pool.Submit(1)
+	<-busy
+	pool.Submit(2)
+	time.Sleep(5 * time.Millisecond)
+	queued, _ := pool.Submit(3)
+	return pool, queued, release
+}
+
+func TestBackpressureDropNewest(t *testing.T) {
+	// Setup
+	pool, _, release := setupFullPool(gorkpool.BackpressureDropNewest)
+	// Action
+	f, err := pool.Submit(4)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected submission to succeed, got %v", err)
+	}
+	var full gorkpool.ErrQueueFull
+	if _, err := f.Wait(context.Background()); !errors.As(err, &full) {
+		t.Errorf("expected newest task to be dropped with ErrQueueFull, got %v", err)
+	}
+	// Cleanup
+	close(release)
+	pool.Shutdown(context.Background())
+}
+
+func TestBackpressureDropOldest(t *testing.T) {
+	// Setup
+	pool, queued, release := setupFullPool(gorkpool.BackpressureDropOldest)
+	// Action
+	f, err := pool.Submit(4)
+	// Assert
+	var full gorkpool.ErrQueueFull
+	if _, err := queued.Wait(context.Background()); !errors.As(err, &full) {
+		t.Errorf("expected oldest queued task to be dropped with ErrQueueFull, got %v", err)
+	}
+	close(release)
+	if got, err2 := f.Wait(context.Background()); err != nil || err2 != nil || got != 4 {
+		t.Errorf("expected newest task to be handled, got %d, %v, %v", got, err, err2)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestBackpressureError(t *testing.T) {
+	// Setup
+	pool, _, release := setupFullPool(gorkpool.BackpressureError)
+	// Action
+	_, err := pool.Submit(4)
+	// Assert
+	var full gorkpool.ErrQueueFull
+	if !errors.As(err, &full) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	// Cleanup
+	close(release)
+	pool.Shutdown(context.Background())
+}
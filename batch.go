@@ -0,0 +1,49 @@
+package gorkpool
+
+import "context"
+
+// SubmitAll queues every task like AddTask, stopping at the first one that
+// can't be queued because ctx is done or the pool is closed.
+func (p *GorkPool[Id, Task, Result]) SubmitAll(ctx context.Context, tasks []Task, opts ...TaskOption) error {
+	for _, task := range tasks {
+		if err := p.pushCtx(ctx, p.newEnvelope(task, opts)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubmitAllWait submits every task and gathers their results in input order.
+// It returns the first error in input order, once every task is done with.
+func (p *GorkPool[Id, Task, Result]) SubmitAllWait(ctx context.Context, tasks []Task, opts ...TaskOption) ([]Result, error) {
+	envs := make([]*envelope[Task, Result], 0, len(tasks))
+	cancelAll := func() {
+		for _, env := range envs {
+			p.cancelEnvelope(env)
+		}
+	}
+
+	for _, task := range tasks {
+		env, err := p.submit(ctx, task, opts)
+		if err != nil {
+			cancelAll()
+			return nil, err
+		}
+		envs = append(envs, env)
+	}
+
+	var firstErr error
+	results := make([]Result, len(tasks))
+	for i, env := range envs {
+		result, err := env.future.Wait(ctx)
+		if ctx.Err() != nil {
+			cancelAll()
+			return nil, ctx.Err()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		results[i] = result
+	}
+	return results, firstErr
+}
@@ -0,0 +1,83 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSubmitAll(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	// Action
+	err := pool.SubmitAll(context.Background(), []int{1, 2, 3})
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	sum := 0
+	for i := 0; i < 3; i++ {
+		sum += <-pool.OutputCh()
+	}
+	if sum != -6 {
+		t.Errorf("expected results to sum %d, got %d", -6, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitAllCancelled(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	ctx, cancelSubmit := context.WithCancel(context.Background())
+	cancelSubmit()
+	// Action
+	err := pool.SubmitAll(ctx, []int{1, 2, 3})
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitAllWait(t *testing.T) {
+	// Setup
+	failure := errors.New("odd")
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if x == 3 {
+			return 0, failure
+		}
+		return -x, nil
+	})
+	for i := 0; i < 4; i++ {
+		pool.AddWorker(i)
+	}
+	tasks := make([]int, 20)
+	for i := range tasks {
+		tasks[i] = i + 10
+	}
+	// Action
+	results, err := pool.SubmitAllWait(context.Background(), tasks)
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	for i, r := range results {
+		if r != -tasks[i] {
+			t.Errorf("expected result %d to be %d, got %d", i, -tasks[i], r)
+		}
+	}
+	// Action
+	_, err = pool.SubmitAllWait(context.Background(), []int{1, 2, 3, 4})
+	// Assert
+	if !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,256 @@
+package gorkpool
+
+import (
+	"context"
+	"runtime/trace"
+	"time"
+)
+
+// BatchHandler is an optional interface for workers that handle several tasks
+// at once, like bulk database writes. HandleBatch returns a result per task,
+// in order, or an error failing them all.
+//
+// Without WithBatching, or for tasks the pool has to handle one at a time,
+// HandleBatch gets a single task.
+type BatchHandler[Task any, Result any] interface {
+	HandleBatch(ctx context.Context, tasks []Task) ([]Result, error)
+}
+
+// WithBatching makes BatchHandler workers take tasks in batches of up to size,
+// waiting at most window after the first task of a batch for it to fill up.
+//
+// Batches run in the worker's context, with the earliest deadline of their
+// tasks. Middleware doesn't apply to them and CancelTask doesn't interrupt
+// them, though it still cancels the task's Future.
+func WithBatching(size int, window time.Duration) Option {
+	return func(c *config) {
+		c.batchSize = size
+		c.batchWindow = window
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) batching(ws *workerState[Id, Task, Result]) bool {
+	return ws.batch != nil && p.cfg.batchSize > 1
+}
+
+// batchTaskHandler handles the tasks of BatchHandler workers one at a time.
+type batchTaskHandler[Task any, Result any] struct {
+	BatchHandler[Task, Result]
+}
+
+func (h batchTaskHandler[Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	var zero Result
+	results, err := h.HandleBatch(ctx, []Task{task})
+	if err != nil {
+		return zero, err
+	}
+	if len(results) != 1 {
+		return zero, NewErrBatchResults(1, len(results))
+	}
+	return results[0], nil
+}
+
+// serveBatch is serve for BatchHandler workers when batching.
+func (p *GorkPool[Id, Task, Result]) serveBatch(ws *workerState[Id, Task, Result]) {
+	defer ws.cancel()
+
+	taskCh, inputCh := p.taskCh, p.inputCh
+	if ws.queue != nil {
+		taskCh = ws.queue
+	}
+	for taskCh != nil || inputCh != nil {
+		if !p.coolDown(ws) || !p.workerUnpaused(ws) {
+			return
+		}
+
+		var (
+			batch  []*envelope[Task, Result]
+			timer  Timer
+			window <-chan time.Time
+			closed bool
+		)
+	gather:
+		for len(batch) < p.cfg.batchSize && !closed && (taskCh != nil || inputCh != nil) {
+			select {
+			case <-ws.ctx.Done():
+				closed = true
+			case <-window:
+				break gather
+			case <-ws.pausing:
+				break gather
+			case env := <-ws.direct:
+				p.handle(ws, env)
+			case env, ok := <-taskCh:
+				if !ok {
+					if p.cfg.externalChannels {
+						closed = true
+						break
+					}
+					taskCh = nil
+					continue
+				}
+				if ws.queue != nil {
+					p.taken(ws, env)
+				}
+				batch = append(batch, env)
+			case task, ok := <-inputCh:
+				if !ok {
+					inputCh = nil
+					continue
+				}
+				batch = append(batch, p.alloc(task))
+			}
+			if len(batch) == 1 && timer == nil {
+				timer = ws.clock.NewTimer(p.cfg.batchWindow)
+				window = timer.C()
+			}
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if ws.ctx.Err() != nil {
+			for _, env := range batch {
+				p.reroute(env)
+			}
+			return
+		}
+		if len(batch) > 0 {
+			p.handleBatch(ws, batch)
+		}
+		if closed {
+			return
+		}
+	}
+}
+
+// handleBatch is handleOne for a batch of tasks.
+func (p *GorkPool[Id, Task, Result]) handleBatch(ws *workerState[Id, Task, Result], batch []*envelope[Task, Result]) {
+	var (
+		claimed []*envelope[Task, Result]
+		dropped []*envelope[Task, Result]
+		errs    []error
+		next    []*envelope[Task, Result]
+	)
+	for _, env := range batch {
+		p.orphaned(env)
+	}
+	p.mutex.Lock()
+	for _, env := range batch {
+		ok, n, err := p.claim(ws, env)
+		switch {
+		case ok:
+			claimed = append(claimed, env)
+		case err != nil:
+			dropped, errs = append(dropped, env), append(errs, err)
+		}
+		if n != nil {
+			next = append(next, n)
+		}
+	}
+	startHooks, endHooks := p.startHooks, p.endHooks
+	p.mutex.Unlock()
+
+	for i, env := range dropped {
+		p.drop(env, errs[i])
+	}
+	if len(claimed) > 0 {
+		next = append(next, p.runBatch(ws, claimed, startHooks, endHooks)...)
+	}
+	for _, env := range next {
+		p.handle(ws, env)
+	}
+}
+
+// runBatch hands the claimed envelopes of a batch to ws, returning the tasks
+// of their keys taking their place.
+func (p *GorkPool[Id, Task, Result]) runBatch(ws *workerState[Id, Task, Result], batch []*envelope[Task, Result], startHooks, endHooks []TaskHook[Id, Task, Result]) []*envelope[Task, Result] {
+	ctx := ws.ctx
+	var deadline time.Time
+	for _, env := range batch {
+		if !env.deadline.IsZero() && (deadline.IsZero() || env.deadline.Before(deadline)) {
+			deadline = env.deadline
+		}
+	}
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	tasks := make([]Task, len(batch))
+	var events []TaskEvent[Id, Task, Result]
+	if len(startHooks) > 0 || len(endHooks) > 0 || p.recent != nil {
+		events = make([]TaskEvent[Id, Task, Result], len(batch))
+	}
+	for i, env := range batch {
+		tasks[i] = env.task
+		if events != nil {
+			events[i] = newTaskEvent(ws, env, p.cfg.clock.Now())
+			runHooks(startHooks, events[i])
+		}
+	}
+	started := p.cfg.clock.Now()
+	ws.labelTask(tasks[0])
+	var region *trace.Region
+	if p.tracing() {
+		region = p.traceRegion(ctx, "batch", ws.worker.ID(), tasks[0])
+	}
+	results, err := p.callBatch(ctx, ws.worker.ID(), ws.batch, tasks)
+	if region != nil {
+		region.End()
+	}
+	ws.unlabelTask()
+	now := p.cfg.clock.Now()
+	if err == nil && len(results) != len(tasks) {
+		err = NewErrBatchResults(len(tasks), len(results))
+	}
+	if err != nil {
+		results = make([]Result, len(tasks))
+	}
+
+	var next []*envelope[Task, Result]
+	interrupted := make([]bool, len(batch))
+	tripped := false
+	p.mutex.Lock()
+	for i, env := range batch {
+		n, wasInterrupted, trip := p.complete(ws, env, err, now.Sub(started), now)
+		if n != nil {
+			next = append(next, n)
+		}
+		interrupted[i] = wasInterrupted
+		tripped = tripped || trip
+	}
+	p.mutex.Unlock()
+
+	if len(endHooks) > 0 || p.recent != nil {
+		for i := range events {
+			events[i].Result, events[i].Err, events[i].Duration = results[i], err, now.Sub(started)
+			runHooks(endHooks, events[i])
+			p.remember(events[i], now)
+		}
+	}
+	if tripped {
+		p.tripped(ws)
+	}
+	if broken(err) {
+		p.replace(ws.worker.ID(), ws, NewErrWorker(ws.worker.ID(), err))
+	}
+	for i, env := range batch {
+		p.conclude(env, results[i], err, interrupted[i])
+	}
+	return next
+}
+
+// callBatch is call for batches.
+func (p *GorkPool[Id, Task, Result]) callBatch(ctx context.Context, id Id, b BatchHandler[Task, Result], tasks []Task) (results []Result, err error) {
+	if p.recovers() {
+		defer func() {
+			if v := recover(); v != nil {
+				p.recovered(id, tasks, v)
+				err = NewErrPanic(v)
+			}
+		}()
+	}
+	return b.HandleBatch(ctx, tasks)
+}
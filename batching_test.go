@@ -0,0 +1,129 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type batchWorker struct {
+	id int
+	fn func(tasks []int) ([]int, error)
+
+	mutex sync.Mutex
+	sizes []int
+}
+
+func (w *batchWorker) ID() int        { return w.id }
+func (w *batchWorker) Process()       {}
+func (w *batchWorker) SignalRemoval() {}
+func (w *batchWorker) batches() []int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]int(nil), w.sizes...)
+}
+
+func (w *batchWorker) HandleBatch(ctx context.Context, tasks []int) ([]int, error) {
+	w.mutex.Lock()
+	w.sizes = append(w.sizes, len(tasks))
+	w.mutex.Unlock()
+	return w.fn(tasks)
+}
+
+func doubleAll(tasks []int) ([]int, error) {
+	results := make([]int, len(tasks))
+	for i, task := range tasks {
+		results[i] = 2 * task
+	}
+	return results, nil
+}
+
+func batchPool(w *batchWorker, opts ...gorkpool.Option) (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return w, nil
+	}, opts...)
+	pool.AddWorker(0)
+	return pool, cancel
+}
+
+func TestWithBatching(t *testing.T) {
+	// Setup
+	clock := gorkpool.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	w := &batchWorker{fn: doubleAll}
+	pool, cancel := batchPool(w, gorkpool.WithBatching(4, time.Second), gorkpool.WithClock(clock),
+		gorkpool.WithOutputBufferSize(10))
+	// Action
+	for i := 1; i <= 10; i++ {
+		pool.AddTask(i)
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += <-pool.OutputCh()
+	}
+	for pool.QueueLen() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	waitForWaiters(t, clock, 1)
+	clock.Advance(time.Second)
+	for i := 0; i < 2; i++ {
+		sum += <-pool.OutputCh()
+	}
+	// Assert
+	if got := w.batches(); !reflect.DeepEqual(got, []int{4, 4, 2}) {
+		t.Errorf("expected batches of [4 4 2], got %v", got)
+	}
+	if sum != 110 {
+		t.Errorf("expected results to add up to %d, got %d", 110, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestBatchErrors(t *testing.T) {
+	// Setup
+	failing := errors.New("bulk insert failed")
+	w := &batchWorker{fn: func(tasks []int) ([]int, error) {
+		return nil, failing
+	}}
+	pool, cancel := batchPool(w, gorkpool.WithBatching(2, time.Hour))
+	// Action
+	first, _ := pool.Submit(1)
+	second, _ := pool.Submit(2)
+	_, firstErr := first.Wait(context.Background())
+	_, secondErr := second.Wait(context.Background())
+	// Assert
+	if !errors.Is(firstErr, failing) || !errors.Is(secondErr, failing) {
+		t.Errorf("expected the batch error for both tasks, got %v and %v", firstErr, secondErr)
+	}
+	if got := w.batches(); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("expected a single batch of 2, got %v", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestBatchResultsMismatch(t *testing.T) {
+	// Setup
+	w := &batchWorker{fn: func(tasks []int) ([]int, error) {
+		return nil, nil
+	}}
+	pool, cancel := batchPool(w)
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 1)
+	// Assert
+	var mismatch gorkpool.ErrBatchResults
+	if !errors.As(err, &mismatch) || mismatch.Tasks() != 1 || mismatch.Results() != 0 {
+		t.Errorf("expected ErrBatchResults for 1 task and 0 results, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
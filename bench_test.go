@@ -0,0 +1,90 @@
+package gorkpool_test
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func BenchmarkAddTask(b *testing.B) {
+	benchmarkAddTask(b)
+}
+
+// Without a trace being recorded it should allocate nothing either, see
+// TestWithTraceRegionsAllocs.
+func BenchmarkAddTaskTraceRegions(b *testing.B) {
+	benchmarkAddTask(b, gorkpool.WithTraceRegions())
+}
+
+func benchmarkAddTask(b *testing.B, opts ...gorkpool.Option) {
+	ctx, cancel := context.WithCancel(context.Background())
+	opts = append(opts, gorkpool.WithInputBufferSize(1024), gorkpool.WithOutputBufferSize(1024))
+	pool := gorkpool.NewFuncPool(ctx, runtime.GOMAXPROCS(0), func(x int) int { return x }, opts...)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < b.N; i++ {
+			<-pool.OutputCh()
+		}
+	}()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.AddTask(i)
+	}
+	<-done
+	b.StopTimer()
+	cancel()
+	pool.Wait()
+}
+
+func BenchmarkSubmitWait(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, runtime.GOMAXPROCS(0), func(x int) int { return x },
+		gorkpool.WithInputBufferSize(1024))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			pool.SubmitWait(context.Background(), 1)
+		}
+	})
+	b.StopTimer()
+	cancel()
+	pool.Wait()
+}
+
+func BenchmarkAddRemoveWorker(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 0, func(x int) int { return x })
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.AddWorker(i)
+		pool.RemoveWorkerById(i)
+	}
+	b.StopTimer()
+	cancel()
+	pool.Wait()
+}
+
+func BenchmarkAddRemoveWorkerParallel(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 0, func(x int) int { return x })
+	var ids atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			id := int(ids.Add(1))
+			pool.AddWorker(id)
+			pool.RemoveWorkerById(id)
+		}
+	})
+	b.StopTimer()
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,83 @@
+package gorkpool
+
+import "time"
+
+// CircuitBreaker stops tasks from going to a TaskHandler worker that keeps
+// failing. The zero value disables it.
+type CircuitBreaker struct {
+	// Threshold is how many consecutive failures, panics included, trip the
+	// breaker of a worker.
+	Threshold int
+	// Cooldown is how long a tripped worker gets no tasks. A failure of the
+	// first task it handles after that trips it again.
+	Cooldown time.Duration
+	// Replace removes tripped workers, adding a new one with the same id in
+	// their place, instead of letting them cool down.
+	Replace bool
+}
+
+// WithCircuitBreaker sets the circuit breaker of the workers of the pool.
+// Trips are reported as an ErrWorker wrapping an ErrCircuitOpen and counted
+// in WorkerStats.
+func WithCircuitBreaker(b CircuitBreaker) Option {
+	return func(c *config) {
+		c.breaker = b
+	}
+}
+
+// trip records the outcome of a task handled by ws, telling whether it trips
+// the breaker. The caller must hold p.mutex.
+func (ws *workerState[Id, Task, Result]) trip(err error, now time.Time, b CircuitBreaker) bool {
+	if b.Threshold <= 0 {
+		return false
+	}
+	if err == nil {
+		ws.failures = 0
+		return false
+	}
+	ws.failures++
+	if ws.failures < b.Threshold {
+		return false
+	}
+	ws.trips++
+	// Half open, the next failure trips it again
+	ws.failures = b.Threshold - 1
+	ws.openUntil = now.Add(b.Cooldown)
+	return true
+}
+
+// open tells whether the breaker of ws keeps it from getting tasks. The
+// caller must hold p.mutex.
+func (ws *workerState[Id, Task, Result]) open(now time.Time) bool {
+	return now.Before(ws.openUntil)
+}
+
+func (p *GorkPool[Id, Task, Result]) tripped(ws *workerState[Id, Task, Result]) {
+	id := ws.worker.ID()
+	err := NewErrWorker(id, NewErrCircuitOpen(p.cfg.breaker.Threshold))
+	if p.cfg.breaker.Replace {
+		p.replace(id, ws, err)
+		return
+	}
+	p.reportErr(err)
+}
+
+// coolDown waits for the breaker of ws to let it take tasks again, telling
+// whether it did before ws was stopped.
+func (p *GorkPool[Id, Task, Result]) coolDown(ws *workerState[Id, Task, Result]) bool {
+	p.mutex.Lock()
+	wait := ws.openUntil.Sub(p.cfg.clock.Now())
+	p.mutex.Unlock()
+	if wait <= 0 {
+		return true
+	}
+
+	timer := p.cfg.clock.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ws.ctx.Done():
+		return false
+	case <-timer.C():
+		return true
+	}
+}
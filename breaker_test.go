@@ -0,0 +1,88 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func setupBreakerPool(b gorkpool.CircuitBreaker, created *atomic.Int64) (*gorkpool.GorkPool[int, int, int], chan error, context.CancelFunc) {
+	failure := errors.New("failure")
+	errs := make(chan error, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		created.Add(1)
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			if x < 0 {
+				return 0, failure
+			}
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithCircuitBreaker(b), gorkpool.WithErrorHandler(func(err error) {
+		var open gorkpool.ErrCircuitOpen
+		if errors.As(err, &open) {
+			errs <- err
+		}
+	}))
+	return pool, errs, cancel
+}
+
+func TestCircuitBreakerCooldown(t *testing.T) {
+	// Setup
+	var created atomic.Int64
+	pool, errs, cancel := setupBreakerPool(gorkpool.CircuitBreaker{Threshold: 2, Cooldown: 50 * time.Millisecond}, &created)
+	pool.AddWorker(0)
+	// Action
+	for _, x := range []int{-1, 1, -2, -3} {
+		f, _ := pool.Submit(x)
+		f.Wait(context.Background())
+	}
+	start := time.Now()
+	f, _ := pool.Submit(4)
+	got, err := f.Wait(context.Background())
+	// Assert
+	if err != nil || got != -4 {
+		t.Errorf("expected result -4, got %d (%v)", got, err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected tripped worker to cool down, took %v", elapsed)
+	}
+	if stats, _ := pool.WorkerStats(0); stats.Trips != 1 {
+		t.Errorf("expected 1 trip, got %d", stats.Trips)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected 1 trip to be reported, got %d", len(errs))
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestCircuitBreakerReplace(t *testing.T) {
+	// Setup
+	var created atomic.Int64
+	pool, errs, cancel := setupBreakerPool(gorkpool.CircuitBreaker{Threshold: 1, Replace: true}, &created)
+	pool.AddWorker(0)
+	// Action
+	f, _ := pool.Submit(-1)
+	f.Wait(context.Background())
+	err := <-errs
+	// Assert
+	var workerErr gorkpool.ErrWorker
+	if !errors.As(err, &workerErr) {
+		t.Errorf("expected trip to be reported as ErrWorker, got %v", err)
+	}
+	waitFor(t, func() bool { return created.Load() == 2 && pool.Contains(0) })
+	if got, err := pool.Submit(1); err != nil {
+		t.Errorf("expected replacement to take tasks, got %v", err)
+	} else if res, _ := got.Wait(context.Background()); res != -1 {
+		t.Errorf("expected result -1, got %d", res)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,53 @@
+package gorkpool
+
+import "errors"
+
+// Broadcast hands task to every TaskHandler worker currently in the pool and
+// gathers their results, ordered from the oldest worker to the newest, for
+// things like cache invalidation or config pushes to stateful workers. The
+// tasks skip the queue and aren't retried. Failures are reported in the
+// returned error as ErrWorker, with zero results in their place, including
+// workers removed before taking the task, which fail with ErrWorkerNotFound.
+func (p *GorkPool[Id, Task, Result]) Broadcast(task Task) ([]Result, error) {
+	p.mutex.RLock()
+	if !p.running() {
+		p.mutex.RUnlock()
+		return nil, NewErrPoolClosed()
+	}
+	var workers []*workerState[Id, Task, Result]
+	for _, ws := range p.byAge() {
+		if ws.direct != nil {
+			workers = append(workers, ws)
+		}
+	}
+	p.mutex.RUnlock()
+
+	envs := make([]*envelope[Task, Result], len(workers))
+	for i, ws := range workers {
+		env := p.newEnvelope(task, nil)
+		env.future, env.direct = newFuture[Result](), true
+		envs[i] = env
+		ws := ws
+		p.launch("broadcast", func() {
+			if err := p.handTo(ws, env); err != nil {
+				var zero Result
+				env.future.resolve(zero, err)
+			}
+		})
+	}
+
+	var errs []error
+	results := make([]Result, len(workers))
+	for i, env := range envs {
+		result, err := env.future.Wait(p.ctx)
+		if err != nil {
+			errs = append(errs, NewErrWorker(workers[i].worker.ID(), err))
+			continue
+		}
+		results[i] = result
+	}
+	if p.ctx.Err() != nil {
+		return results, NewErrPoolClosed()
+	}
+	return results, errors.Join(errs...)
+}
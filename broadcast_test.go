@@ -0,0 +1,72 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+func TestBroadcast(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	workers := map[int]*gorkpooltest.Recorder[int, int, int]{}
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		rec := gorkpooltest.NewRecorder[int](id, func(ctx context.Context, task int) (int, error) {
+			return 10*id + task, nil
+		})
+		workers[id] = rec
+		return rec, nil
+	})
+	for _, id := range []int{2, 0, 1} {
+		pool.AddWorker(id)
+	}
+	// Action
+	results, err := pool.Broadcast(5)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := []int{25, 5, 15}; !reflect.DeepEqual(results, want) {
+		t.Errorf("expected results %v from the oldest worker to the newest, got %v", want, results)
+	}
+	for _, rec := range workers {
+		gorkpooltest.AssertProcessed(t, rec, 5)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestBroadcastErrors(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	failing := errors.New("stale cache")
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return gorkpooltest.NewRecorder[int](id, func(ctx context.Context, task int) (int, error) {
+			if id == 1 {
+				return 0, failing
+			}
+			return task, nil
+		}), nil
+	})
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 3})
+	// Action
+	results, err := pool.Broadcast(7)
+	// Assert
+	var workerErr gorkpool.ErrWorker
+	if !errors.As(err, &workerErr) || workerErr.ID() != 1 || !errors.Is(err, failing) {
+		t.Fatalf("expected an ErrWorker for worker 1, got %v", err)
+	}
+	if want := []int{7, 0}; !reflect.DeepEqual(results, want) {
+		t.Errorf("expected results %v, got %v", want, results)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,22 @@
+package gorkpool
+
+import "time"
+
+// WithTaskBudget gives every task d from its submission to its completion,
+// retries included, like a WithTimeout of d unless the task has an earlier
+// deadline. Tasks still queued once their budget is spent are skipped, and
+// TaskHandler workers get what is left of it as the deadline of their
+// context, so downstream calls can respect the overall SLA. The TaskEnvelope
+// of the task tells how much of it was spent before it was handled.
+func WithTaskBudget(d time.Duration) Option {
+	return func(c *config) {
+		c.taskBudget = d
+	}
+}
+
+// budget caps the deadline of env to the task budget of the pool.
+func (p *GorkPool[Id, Task, Result]) budget(env *envelope[Task, Result]) {
+	if deadline := env.submitted.Add(p.cfg.taskBudget); env.deadline.IsZero() || deadline.Before(env.deadline) {
+		env.deadline = deadline
+	}
+}
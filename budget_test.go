@@ -0,0 +1,57 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithTaskBudget(t *testing.T) {
+	// Setup
+	deadlines := make(chan time.Time, 1)
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		deadline, _ := ctx.Deadline()
+		deadlines <- deadline
+		return x, nil
+	}, gorkpool.WithTaskBudget(time.Minute))
+	submitted := time.Now()
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 1)
+	handled := time.Now()
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deadline := <-deadlines; deadline.Before(submitted) || deadline.After(handled.Add(time.Minute)) {
+		t.Errorf("expected a deadline within a minute of submission, got %v", deadline.Sub(submitted))
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithTaskBudgetSpentQueued(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		if x == 0 {
+			<-release
+		}
+		return x, nil
+	}, gorkpool.WithTaskBudget(10*time.Millisecond))
+	first, _ := pool.Submit(0, gorkpool.WithTimeout(time.Minute))
+	second, _ := pool.Submit(1)
+	// Action
+	time.Sleep(30 * time.Millisecond)
+	close(release)
+	_, err := second.Wait(context.Background())
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the task to run out of budget while queued, got %v", err)
+	}
+	// Cleanup
+	first.Wait(context.Background())
+	pool.Shutdown(context.Background())
+}
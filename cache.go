@@ -0,0 +1,145 @@
+package gorkpool
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ResultCache keeps the results of tasks by their cache key, see
+// WithResultCache. It must be safe for concurrent use.
+type ResultCache[Result any] interface {
+	Get(key string) (Result, bool)
+	// Set keeps result for ttl, or until evicted if ttl is zero
+	Set(key string, result Result, ttl time.Duration)
+}
+
+// WithResultCache makes Submit and SubmitWait answer the tasks submitted
+// WithCacheKey from cache, without queueing them, if a task with the same key
+// succeeded less than ttl before. Failures aren't cached, and tasks submitted
+// with AddTask and the like never hit the cache. cache must be a ResultCache
+// of the result type of the pool, it is ignored otherwise.
+func WithResultCache[Result any](cache ResultCache[Result], ttl time.Duration) Option {
+	return func(c *config) {
+		c.resultCache, c.cacheTTL = cache, ttl
+	}
+}
+
+// WithCacheKey identifies the result of the task for WithResultCache.
+func WithCacheKey(key string) TaskOption {
+	return func(c *taskConfig) {
+		c.cacheKey = key
+	}
+}
+
+// useCache sets up the cache given to WithResultCache.
+func (p *GorkPool[Id, Task, Result]) useCache() {
+	cache, ok := p.cfg.resultCache.(ResultCache[Result])
+	if !ok {
+		p.logf("gorkpool: ignoring result cache of type %T", p.cfg.resultCache)
+		return
+	}
+	if c, ok := cache.(clocked); ok {
+		c.useClock(p.cfg.clock)
+	}
+	p.cache = cache
+}
+
+// clocked caches tell time with the clock of the pool they are given to.
+type clocked interface {
+	useClock(clock Clock)
+}
+
+// cached resolves the future of env with the cached result of its key, if
+// any, telling whether it did.
+func (p *GorkPool[Id, Task, Result]) cached(env *envelope[Task, Result]) bool {
+	if p.cache == nil || env.cacheKey == "" {
+		return false
+	}
+	result, ok := p.cache.Get(env.cacheKey)
+	if ok {
+		env.future.resolve(result, nil)
+	}
+	return ok
+}
+
+// LRUCache is an in-memory ResultCache holding up to size results, evicting
+// the least recently used ones first. Given to WithResultCache, it tells their
+// age with the Clock of the pool.
+type LRUCache[Result any] struct {
+	mutex *sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+	clock Clock
+}
+
+type lruEntry[Result any] struct {
+	key     string
+	result  Result
+	expires time.Time
+}
+
+func NewLRUCache[Result any](size int) *LRUCache[Result] {
+	return &LRUCache[Result]{
+		mutex: &sync.Mutex{},
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+		clock: realClock{},
+	}
+}
+
+func (c *LRUCache[Result]) useClock(clock Clock) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.clock = clock
+}
+
+func (c *LRUCache[Result]) Get(key string) (Result, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var zero Result
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[Result])
+	if !entry.expires.IsZero() && !c.clock.Now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.result, true
+}
+
+func (c *LRUCache[Result]) Set(key string, result Result, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.clock.Now().Add(ttl)
+	}
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry[Result])
+		entry.result, entry.expires = result, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[Result]{key: key, result: result, expires: expires})
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[Result]).key)
+	}
+}
+
+// Len returns how many results the cache holds, expired ones included until
+// they are looked up or evicted.
+func (c *LRUCache[Result]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,94 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithResultCache(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		calls.Add(1)
+		if x < 0 {
+			return 0, errors.New("negative")
+		}
+		return 2 * x, nil
+	}, gorkpool.WithResultCache[int](gorkpool.NewLRUCache[int](10), time.Minute))
+	// Action
+	first, _ := pool.SubmitWait(context.Background(), 21, gorkpool.WithCacheKey("a"))
+	second, _ := pool.SubmitWait(context.Background(), 21, gorkpool.WithCacheKey("a"))
+	pool.SubmitWait(context.Background(), -1, gorkpool.WithCacheKey("b"))
+	_, err := pool.SubmitWait(context.Background(), -1, gorkpool.WithCacheKey("b"))
+	// Assert
+	if first != 42 || second != 42 {
+		t.Errorf("expected both submissions to get %d, got %d and %d", 42, first, second)
+	}
+	if err == nil {
+		t.Errorf("expected failures not to be cached")
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("expected %d tasks handled, got %d", 3, n)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestLRUCache(t *testing.T) {
+	// Setup
+	cache := gorkpool.NewLRUCache[int](2)
+	// Action
+	cache.Set("a", 1, 0)
+	cache.Set("b", 2, 0)
+	cache.Get("a")
+	cache.Set("c", 3, 0)
+	cache.Set("d", 4, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	// Assert
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected least recently used %q to be evicted", "b")
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected %q to be evicted once %q came in", "a", "d")
+	}
+	if v, ok := cache.Get("c"); !ok || v != 3 {
+		t.Errorf("expected %q to be cached as %d, got %d, %v", "c", 3, v, ok)
+	}
+	if _, ok := cache.Get("d"); ok {
+		t.Errorf("expected %q to expire", "d")
+	}
+	if n := cache.Len(); n != 1 {
+		t.Errorf("expected %d result left, got %d", 1, n)
+	}
+}
+
+func TestWithResultCacheClock(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	clock := gorkpool.NewFakeClock(time.Now())
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		calls.Add(1)
+		return 2 * x, nil
+	}, gorkpool.WithResultCache[int](gorkpool.NewLRUCache[int](10), time.Minute), gorkpool.WithClock(clock))
+	// Action
+	pool.SubmitWait(context.Background(), 21, gorkpool.WithCacheKey("a"))
+	clock.Advance(30 * time.Second)
+	pool.SubmitWait(context.Background(), 21, gorkpool.WithCacheKey("a"))
+	fresh := calls.Load()
+	clock.Advance(time.Minute)
+	pool.SubmitWait(context.Background(), 21, gorkpool.WithCacheKey("a"))
+	// Assert
+	if fresh != 1 {
+		t.Errorf("expected the result to be cached within its ttl, got %d tasks handled", fresh)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected the result to expire once the clock passed its ttl, got %d tasks handled", n)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
@@ -0,0 +1,71 @@
+package gorkpool
+
+import "context"
+
+// CancelTask cancels the task submitted with WithTaskID(id). Queued tasks are
+// removed from the queue, while TaskHandler workers processing it get its
+// context cancelled. It returns false if no such task is pending or running.
+func (p *GorkPool[Id, Task, Result]) CancelTask(id string) bool {
+	p.mutex.Lock()
+	env, ok := p.tasks[id]
+	p.mutex.Unlock()
+	if !ok {
+		return false
+	}
+	return p.cancelEnvelope(env)
+}
+
+func (p *GorkPool[Id, Task, Result]) cancelEnvelope(env *envelope[Task, Result]) bool {
+	p.mutex.Lock()
+	if env.canceled {
+		p.mutex.Unlock()
+		return false
+	}
+	if env.id != "" && p.tasks[env.id] == env {
+		delete(p.tasks, env.id)
+	}
+	env.canceled = true
+	cancel := env.cancel
+	p.mutex.Unlock()
+
+	p.queue.remove(env)
+	if cancel != nil {
+		cancel()
+	}
+	if env.future != nil {
+		var zero Result
+		env.future.resolve(zero, context.Canceled)
+	}
+	p.skipResult(env)
+	return true
+}
+
+func (p *GorkPool[Id, Task, Result]) isCanceled(env *envelope[Task, Result]) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return env.canceled
+}
+
+// track makes env reachable by CancelTask.
+func (p *GorkPool[Id, Task, Result]) track(env *envelope[Task, Result]) {
+	if env.id == "" {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.tasks[env.id] = env
+}
+
+// finish forgets about env once it can no longer be cancelled.
+func (p *GorkPool[Id, Task, Result]) finish(env *envelope[Task, Result]) {
+	if env.id == "" {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.tasks[env.id] == env {
+		delete(p.tasks, env.id)
+	}
+}
@@ -0,0 +1,52 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestCancelTask(t *testing.T) {
+	// Setup
+	started := make(chan int, 10)
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		started <- x
+		if x == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return -x, nil
+	})
+	deadLetters := make(chan gorkpool.DeadLetter[int], 10)
+	pool.SetDeadLetterHandler(func(dl gorkpool.DeadLetter[int]) {
+		deadLetters <- dl
+	})
+	pool.AddWorker(0)
+	pool.AddTask(1, gorkpool.WithTaskID("in-flight"))
+	<-started
+	pool.AddTask(2, gorkpool.WithTaskID("queued"))
+	// Action
+	queuedCancelled := pool.CancelTask("queued")
+	inFlightCancelled := pool.CancelTask("in-flight")
+	pool.AddTask(3)
+	// Assert
+	if !queuedCancelled || !inFlightCancelled {
+		t.Errorf("expected both tasks to be cancelled, got queued=%v in-flight=%v", queuedCancelled, inFlightCancelled)
+	}
+	if pool.CancelTask("queued") {
+		t.Error("expected cancelling an already cancelled task to return false")
+	}
+	if got := <-pool.OutputCh(); got != -3 {
+		t.Errorf("expected result to be %d, got %d", -3, got)
+	}
+	if x := <-started; x != 3 {
+		t.Errorf("expected cancelled task not to run, got task %d", x)
+	}
+	if len(deadLetters) != 0 {
+		t.Errorf("expected cancelled tasks not to be dead lettered, got %d", len(deadLetters))
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
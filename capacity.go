@@ -0,0 +1,24 @@
+package gorkpool
+
+// Weighted is an optional interface for workers that can take more work than
+// others, like remote nodes with more cores. When dispatching to per worker
+// queues, a worker with a capacity of n gets n times the share of tasks of a
+// worker with the default capacity of 1: DispatchLeastBusy weighs its load
+// against its capacity, DispatchRoundRobin gives it n turns in a row, and
+// TaskHandler workers get a queue n times as big. Capacity is read once, when
+// the worker is added.
+type Weighted interface {
+	Capacity() int
+}
+
+func capacityOf(w any) int {
+	if c, ok := w.(Weighted); ok && c.Capacity() > 1 {
+		return c.Capacity()
+	}
+	return 1
+}
+
+// lighter tells whether ws has less load than other for its capacity.
+func (ws *workerState[Id, Task, Result]) lighter(other *workerState[Id, Task, Result]) bool {
+	return ws.load()*other.capacity < other.load()*ws.capacity
+}
@@ -0,0 +1,95 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type weightedHandler struct {
+	testHandler
+	capacity int
+}
+
+func (w *weightedHandler) Capacity() int {
+	return w.capacity
+}
+
+// heldWorker is a channel worker that leaves its tasks waiting in its input
+// channel until released.
+type heldWorker struct {
+	id       int
+	capacity int
+	input    chan int
+	release  chan struct{}
+}
+
+func (w *heldWorker) ID() int {
+	return w.id
+}
+
+func (w *heldWorker) Capacity() int {
+	return w.capacity
+}
+
+func (w *heldWorker) Process() {
+	<-w.release
+	for range w.input {
+	}
+}
+
+func (w *heldWorker) SignalRemoval() {}
+
+func TestCapacityLeastBusy(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	inputs := map[int]chan int{}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		inputs[id] = ic
+		return &heldWorker{id: id, capacity: 3 - 2*id, input: ic, release: release}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithWorkerQueueSize(8))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	for i := 0; i < 8; i++ {
+		pool.AddTask(i)
+	}
+	for deadline := time.Now().Add(time.Second); len(inputs[0])+len(inputs[1]) < 8 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	// Assert
+	if len(inputs[0]) != 6 || len(inputs[1]) != 2 {
+		t.Errorf("expected tasks to be shared 6 to 2 by capacity, got %d to %d", len(inputs[0]), len(inputs[1]))
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
+
+func TestCapacityRoundRobin(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &weightedHandler{testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return id, nil
+		}}, 2 - id}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchRoundRobin))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	want := []int{0, 0, 1, 0, 0, 1}
+	for i, id := range want {
+		// Action
+		got, err := pool.SubmitWait(context.Background(), i)
+		// Assert
+		if err != nil || got != id {
+			t.Errorf("expected task %d to go to worker %d, got %d, %v", i, id, got, err)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
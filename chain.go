@@ -0,0 +1,39 @@
+package gorkpool
+
+import "context"
+
+// Chain feeds every result of from to to, converted by adapt, so pools can be
+// connected into a pipeline. Once from is done and its results are forwarded,
+// to is shut down too, letting the shutdown flow down the pipeline.
+func Chain[Id1 comparable, Task1 any, Result1 any, Id2 comparable, Task2 any, Result2 any](
+	from *GorkPool[Id1, Task1, Result1],
+	to *GorkPool[Id2, Task2, Result2],
+	adapt func(Result1) Task2,
+) {
+	outputCh, done := from.OutputCh(), from.Done()
+	from.launch("chain", func() {
+		defer to.Shutdown(context.Background())
+		for {
+			select {
+			case result, ok := <-outputCh:
+				if !ok {
+					return
+				}
+				to.AddTask(adapt(result))
+			case <-done:
+				// The output channel may be external and never closed
+				for {
+					select {
+					case result, ok := <-outputCh:
+						if !ok {
+							return
+						}
+						to.AddTask(adapt(result))
+					default:
+						return
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestChain(t *testing.T) {
+	// Setup
+	first := gorkpool.NewFuncPool(context.Background(), 2, func(x int) int { return x * 2 })
+	second := gorkpool.NewFuncPool(context.Background(), 2, func(s string) string { return s + "!" }, gorkpool.WithOutputBufferSize(3))
+	gorkpool.Chain(first, second, strconv.Itoa)
+	// Action
+	for i := 1; i <= 3; i++ {
+		first.AddTask(i)
+	}
+	first.Shutdown(context.Background())
+	second.Wait()
+	// Assert
+	got := make([]string, 0, 3)
+	for s := range second.OutputCh() {
+		got = append(got, s)
+	}
+	sort.Strings(got)
+	want := []string{"2!", "4!", "6!"}
+	if len(got) != len(want) {
+		t.Fatalf("expected results %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected results %v, got %v", want, got)
+			break
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+)
+
+// ProcessChunks splits items into chunks of chunkSize, the last one taking the
+// remainder, and submits each chunk to p as a task, so chunks spread across
+// its workers. The results of the chunks are put back together in the order
+// of items. A chunkSize below 1 makes a single chunk of items.
+//
+// Results of failed chunks are left zero, and each failure is reported in the
+// returned error as an ErrChunkFailed. If ctx is done first, the chunks left
+// are cancelled and only ctx's error is returned.
+func ProcessChunks[Id comparable, Task any, Result any](ctx context.Context, p *GorkPool[Id, []Task, []Result], items []Task, chunkSize int) ([]Result, error) {
+	if chunkSize < 1 {
+		chunkSize = len(items)
+	}
+	var envs []*envelope[[]Task, []Result]
+	cancelAll := func() {
+		for _, env := range envs {
+			p.cancelEnvelope(env)
+		}
+	}
+
+	for start := 0; start < len(items); start += chunkSize {
+		end := start + chunkSize
+		if end > len(items) {
+			end = len(items)
+		}
+		// Capped so workers appending to their chunk don't write over the next
+		env, err := p.submit(ctx, items[start:end:end], nil)
+		if err != nil {
+			cancelAll()
+			return nil, err
+		}
+		envs = append(envs, env)
+	}
+
+	var errs []error
+	results := make([]Result, len(items))
+	for i, env := range envs {
+		chunk, err := env.future.Wait(ctx)
+		if ctx.Err() != nil {
+			cancelAll()
+			return nil, ctx.Err()
+		}
+		start := i * chunkSize
+		if err == nil && len(chunk) != len(env.task) {
+			err = NewErrBatchResults(len(env.task), len(chunk))
+		}
+		if err != nil {
+			errs = append(errs, NewErrChunkFailed(start, start+len(env.task), err))
+			continue
+		}
+		copy(results[start:], chunk)
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,59 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestProcessChunks(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 3, func(ctx context.Context, chunk []int) ([]int, error) {
+		return doubleAll(chunk)
+	})
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	// Action
+	results, err := gorkpool.ProcessChunks(ctx, pool, items, 3)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := []int{2, 4, 6, 8, 10, 12, 14}; !reflect.DeepEqual(results, want) {
+		t.Errorf("expected results %v, got %v", want, results)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestProcessChunksErrors(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	failing := errors.New("odd chunk")
+	pool := gorkpool.NewHandlerFuncPool(ctx, 2, func(ctx context.Context, chunk []int) ([]int, error) {
+		if chunk[0] == 3 {
+			return nil, failing
+		}
+		return doubleAll(chunk)
+	})
+	// Action
+	results, err := gorkpool.ProcessChunks(ctx, pool, []int{1, 2, 3, 4, 5}, 2)
+	// Assert
+	var chunkErr gorkpool.ErrChunkFailed
+	if !errors.As(err, &chunkErr) || !errors.Is(err, failing) {
+		t.Fatalf("expected ErrChunkFailed wrapping the chunk error, got %v", err)
+	}
+	if chunkErr.Start() != 2 || chunkErr.End() != 4 {
+		t.Errorf("expected chunk [2:4] to fail, got [%d:%d]", chunkErr.Start(), chunkErr.End())
+	}
+	if want := []int{2, 4, 0, 0, 10}; !reflect.DeepEqual(results, want) {
+		t.Errorf("expected results %v, got %v", want, results)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
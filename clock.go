@@ -0,0 +1,219 @@
+package gorkpool
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of the pool's time based features: TTLs, stale
+// tasks, retry backoff, scheduled submissions, idle reaping, health and stall
+// checks, circuit breakers, visibility timeouts, latency scaling and the
+// LRUCache given to WithResultCache. Task
+// deadlines and rate limits follow the real time through their contexts and
+// limiter.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f on its own goroutine once d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+type Timer interface {
+	// C is nil for timers made by AfterFunc.
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// WithClock makes the pool tell time with c instead of the real clock, mostly
+// to test it with a FakeClock.
+func WithClock(c Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = c
+	}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// FakeClock is a Clock that only moves forward when told to, firing the
+// timers and tickers due on the way.
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []*fakeTimer
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d, firing what comes due in order. Like
+// real timers, those made by AfterFunc run on their own goroutine.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	target := c.now.Add(d)
+	for len(c.waiters) > 0 && !c.waiters[0].at.After(target) {
+		w := c.waiters[0]
+		c.now = w.at
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			c.sort()
+		} else {
+			c.remove(w)
+		}
+		w.fire(c.now)
+	}
+	c.now = target
+}
+
+// Waiters tells how many timers and tickers are pending, so tests can wait
+// for the pool to set one before advancing.
+func (c *FakeClock) Waiters() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.waiters)
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.add(d, 0, f)
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return c.add(d, 0, nil)
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("gorkpool: non-positive interval for FakeClock.NewTicker")
+	}
+	return fakeTicker{c.add(d, d, nil)}
+}
+
+func (c *FakeClock) add(d time.Duration, period time.Duration, f func()) *fakeTimer {
+	w := &fakeTimer{clock: c, period: period, fn: f}
+	if f == nil {
+		w.c = make(chan time.Time, 1)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	w.at = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.sort()
+	return w
+}
+
+// sort orders the waiters by when they are due, keeping those due together
+// in the order they were set. The caller must hold c.mutex.
+func (c *FakeClock) sort() {
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].at.Before(c.waiters[j].at)
+	})
+}
+
+// remove takes w off the waiters, telling whether it was on them. The caller
+// must hold c.mutex.
+func (c *FakeClock) remove(w *fakeTimer) bool {
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	at     time.Time
+	period time.Duration
+	fn     func()
+	c      chan time.Time
+}
+
+func (t *fakeTimer) fire(now time.Time) {
+	if t.fn != nil {
+		go t.fn()
+		return
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pending := c.remove(t)
+	t.at = c.now.Add(d)
+	c.waiters = append(c.waiters, t)
+	c.sort()
+	return pending
+}
+
+type fakeTicker struct {
+	*fakeTimer
+}
+
+func (t fakeTicker) Stop() {
+	t.fakeTimer.Stop()
+}
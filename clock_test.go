@@ -0,0 +1,102 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func waitForWaiters(t *testing.T, c *gorkpool.FakeClock, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for c.Waiters() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d timers to be set, got %d", n, c.Waiters())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	// Setup
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := gorkpool.NewFakeClock(start)
+	timer := c.NewTimer(time.Minute)
+	ticker := c.NewTicker(time.Second)
+	fired := make(chan struct{})
+	c.AfterFunc(time.Hour, func() { close(fired) })
+	// Action
+	c.Advance(30 * time.Second)
+	// Assert
+	if got := c.Now(); !got.Equal(start.Add(30 * time.Second)) {
+		t.Errorf("expected the clock to be at %v, got %v", start.Add(30*time.Second), got)
+	}
+	if got := <-ticker.C(); !got.Equal(start.Add(time.Second)) {
+		t.Errorf("expected the first tick at %v, got %v", start.Add(time.Second), got)
+	}
+	select {
+	case <-timer.C():
+		t.Error("expected the timer not to fire before its time")
+	default:
+	}
+	c.Advance(30 * time.Second)
+	if got := <-timer.C(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected the timer to fire at %v, got %v", start.Add(time.Minute), got)
+	}
+	ticker.Stop()
+	c.Advance(time.Hour)
+	<-fired
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("expected no timers left, got %d", n)
+	}
+}
+
+func TestWithClockRetryBackoff(t *testing.T) {
+	// Setup
+	c := gorkpool.NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int64
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, errors.New("first")
+		}
+		return -x, nil
+	}, gorkpool.WithClock(c))
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour})
+	f, _ := pool.Submit(1)
+	// Action
+	waitForWaiters(t, c, 1)
+	c.Advance(time.Hour)
+	// Assert
+	result, err := f.Wait(context.Background())
+	if err != nil || result != -1 {
+		t.Errorf("expected the retry to give %d after the backoff, got %d and %v", -1, result, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithClockTTL(t *testing.T) {
+	// Setup
+	c := gorkpool.NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 0, func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	}, gorkpool.WithClock(c))
+	f, _ := pool.Submit(1, gorkpool.WithTTL(time.Minute))
+	// Action
+	c.Advance(2 * time.Minute)
+	pool.AddWorker(0)
+	// Assert
+	if _, err := f.Wait(context.Background()); err == nil {
+		t.Error("expected the task to expire on the fake clock")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,61 @@
+package gorkpool
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/time/rate"
+)
+
+// Clone creates a new pool under ctx with the factory, options and settings
+// of p, and workers with the same ids, but channels of its own, of the same
+// capacity. The channels being its own, WithExternalChannels isn't carried
+// over, and neither is WithExpvar, so the clone doesn't take the place of p in
+// expvar, nor WithTaskStore, so the clone doesn't replay the tasks of p or
+// save over them.
+func (p *GorkPool[Id, Task, Result]) Clone(ctx context.Context) (*GorkPool[Id, Task, Result], error) {
+	p.mutex.Lock()
+	cfg := p.cfg
+	cfg.expvarName = ""
+	cfg.externalChannels = false
+	cfg.taskStore = nil
+	if cfg.limiter != nil {
+		// Sharing the limiter would share the rate too
+		cfg.limiter = rate.NewLimiter(cfg.limiter.Limit(), cfg.limiter.Burst())
+	}
+	ids := make([]Id, 0, p.workers.len())
+	seqs := make(map[Id]uint64, p.workers.len())
+	p.workers.each(func(id Id, ws *workerState[Id, Task, Result]) bool {
+		ids = append(ids, id)
+		seqs[id] = ws.seq
+		return true
+	})
+	clone := newPool(ctx, make(chan Task, cap(p.inputCh)), make(chan Result, cap(p.outputCh)), p.createWorkerFn, cfg)
+	clone.retry = p.retry
+	clone.deadLetter = p.deadLetter
+	clone.panicReporter.Store(p.panicReporter.Load())
+	clone.dispatcher = p.dispatcher
+	clone.idGenerator = p.idGenerator
+	clone.nextID = p.nextID
+	clone.interceptors = p.interceptors
+	clone.startHooks, clone.endHooks = p.startHooks, p.endHooks
+	clone.middleware = p.middleware
+	latency := p.latency
+	p.mutex.Unlock()
+
+	if latency != nil {
+		clone.ScaleForLatency(latency.objective, latency.interval, latency.nextID)
+	}
+
+	// Keep the order the workers were added in
+	sort.Slice(ids, func(i, j int) bool {
+		return seqs[ids[i]] < seqs[ids[j]]
+	})
+	for _, id := range ids {
+		if err := clone.AddWorker(id); err != nil {
+			clone.cancel(nil)
+			return nil, err
+		}
+	}
+	return clone, nil
+}
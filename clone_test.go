@@ -0,0 +1,65 @@
+package gorkpool_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestClone(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 3, func(x int) int { return -x }, gorkpool.WithOutputBufferSize(4))
+	pool.AddResultInterceptors(gorkpool.MapResults(func(x int) int { return x * 2 }))
+	// Action
+	clone, err := pool.Clone(ctx)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected pool to be cloned, got %v", err)
+	}
+	if clone.Length() != 3 || !clone.Contains(2) {
+		t.Errorf("expected clone to have workers 0 to 2, got %d workers", clone.Length())
+	}
+	if clone.OutputCh() == pool.OutputCh() || cap(clone.OutputCh()) != 4 {
+		t.Error("expected clone to have an output channel of its own with the same capacity")
+	}
+	clone.AddTask(1)
+	if got := <-clone.OutputCh(); got != -2 {
+		t.Errorf("expected clone to keep the interceptors, got %d", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+	clone.Wait()
+}
+
+func TestCloneTaskStore(t *testing.T) {
+	// Setup
+	store := gorkpool.NewFileStore[int](filepath.Join(t.TempDir(), "tasks.json"))
+	store.Save([]int{1, 2, 3})
+	ctx := context.Background()
+	pool := gorkpool.NewFuncPool(ctx, 1, func(x int) int { return -x }, gorkpool.WithTaskStore[int](store), gorkpool.WithOutputBufferSize(10))
+	for i := 0; i < 3; i++ {
+		<-pool.OutputCh()
+	}
+	// Action
+	clone, err := pool.Clone(ctx)
+	if err != nil {
+		t.Fatalf("expected pool to be cloned, got %v", err)
+	}
+	clone.AddTask(4)
+	result := <-clone.OutputCh()
+	cloneErr := clone.Shutdown(ctx)
+	left, loadErr := store.Load()
+	// Assert
+	if result != -4 || len(clone.OutputCh()) != 0 {
+		t.Errorf("expected the clone not to replay the store of the pool, got %d and %d more results", result, len(clone.OutputCh()))
+	}
+	if cloneErr != nil || loadErr != nil || len(left) != 3 {
+		t.Errorf("expected the clone not to save over the store of the pool, got %v (%v, %v)", left, cloneErr, loadErr)
+	}
+	// Cleanup
+	pool.Shutdown(ctx)
+}
@@ -0,0 +1,132 @@
+// Package cloudqueue feeds a GorkPool from a cloud message queue like Amazon
+// SQS or Google Pub/Sub, deleting each message only once its task is done and
+// keeping it invisible to other consumers meanwhile.
+//
+// It works on the small Queue interface below rather than depending on the
+// cloud SDKs. For SQS, Receive is ReceiveMessage, Delete is DeleteMessage and
+// Extend is ChangeMessageVisibility, with the receipt handle as the message's
+// Handle. For Pub/Sub, they are Pull, Acknowledge and ModifyAckDeadline, with
+// the ack ID.
+package cloudqueue
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type Message struct {
+	ID     string
+	Handle string
+	Body   []byte
+}
+
+type Queue interface {
+	// Receive pulls the next messages, waiting for some within ctx.
+	Receive(ctx context.Context) ([]Message, error)
+	Delete(ctx context.Context, msg Message) error
+	// Extend keeps msg from being redelivered for d from now. Zero makes it
+	// available again right away.
+	Extend(ctx context.Context, msg Message, d time.Duration) error
+}
+
+// Consume submits the JSON task of each message received from q to pool. It
+// blocks until ctx is done or q fails, then waits for the tasks in flight.
+//
+// Messages are kept invisible for visibility at a time, extended halfway
+// through for as long as their task is pending. They are deleted once it
+// succeeds, and made visible again right away when it fails or the pool
+// closes, leaving retries and dead lettering to the queue. Messages that don't
+// decode are left to come back after visibility.
+func Consume[Id comparable, Task any, Result any](ctx context.Context, pool *gorkpool.GorkPool[Id, Task, Result], q Queue, visibility time.Duration, opts ...gorkpool.TaskOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+	failed := func(err error) {
+		if err == nil {
+			return
+		}
+		errMutex.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		errMutex.Unlock()
+		cancel()
+	}
+
+	for ctx.Err() == nil {
+		msgs, err := q.Receive(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				failed(err)
+			}
+			break
+		}
+
+		for _, msg := range msgs {
+			var task Task
+			if err := json.Unmarshal(msg.Body, &task); err != nil {
+				continue
+			}
+
+			submitted := make(chan *gorkpool.Future[Result], 1)
+			wg.Add(1)
+			go func(msg Message) {
+				defer wg.Done()
+				failed(keep(q, msg, visibility, submitted))
+			}(msg)
+
+			// Submit blocks while the pool is full, with msg kept invisible
+			f, err := pool.Submit(task, opts...)
+			if err != nil {
+				close(submitted)
+				failed(err)
+				break
+			}
+			submitted <- f
+		}
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// keep keeps msg invisible until the task it is submitted as, given by
+// submitted, is done, then deletes or releases it. Closing submitted releases
+// it right away. It outlives Consume's context to settle the tasks it waits
+// for.
+func keep[Result any](q Queue, msg Message, visibility time.Duration, submitted <-chan *gorkpool.Future[Result]) error {
+	ticker := time.NewTicker(visibility / 2)
+	defer ticker.Stop()
+
+	var (
+		f    *gorkpool.Future[Result]
+		done <-chan struct{}
+	)
+	for {
+		select {
+		case next, ok := <-submitted:
+			if !ok {
+				return q.Extend(context.Background(), msg, 0)
+			}
+			f, done, submitted = next, next.Done(), nil
+		case <-done:
+			if _, err := f.Wait(context.Background()); err != nil {
+				return q.Extend(context.Background(), msg, 0)
+			}
+			return q.Delete(context.Background(), msg)
+		case <-ticker.C:
+			if err := q.Extend(context.Background(), msg, visibility); err != nil {
+				return err
+			}
+		}
+	}
+}
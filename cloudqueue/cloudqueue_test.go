@@ -0,0 +1,102 @@
+package cloudqueue_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/cloudqueue"
+)
+
+type testQueue struct {
+	batches  chan []cloudqueue.Message
+	mutex    sync.Mutex
+	deleted  map[string]bool
+	released map[string]bool
+	extended map[string]int
+	settled  chan string
+}
+
+func newTestQueue(bodies ...string) *testQueue {
+	q := &testQueue{
+		batches:  make(chan []cloudqueue.Message, 1),
+		deleted:  make(map[string]bool),
+		released: make(map[string]bool),
+		extended: make(map[string]int),
+		settled:  make(chan string, len(bodies)),
+	}
+	var batch []cloudqueue.Message
+	for i, body := range bodies {
+		batch = append(batch, cloudqueue.Message{ID: strconv.Itoa(i), Body: []byte(body)})
+	}
+	q.batches <- batch
+	return q
+}
+
+func (q *testQueue) Receive(ctx context.Context) ([]cloudqueue.Message, error) {
+	select {
+	case batch := <-q.batches:
+		return batch, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (q *testQueue) Delete(ctx context.Context, msg cloudqueue.Message) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.deleted[msg.ID] = true
+	q.settled <- msg.ID
+	return nil
+}
+
+func (q *testQueue) Extend(ctx context.Context, msg cloudqueue.Message, d time.Duration) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if d == 0 {
+		q.released[msg.ID] = true
+		q.settled <- msg.ID
+		return nil
+	}
+	q.extended[msg.ID]++
+	return nil
+}
+
+func TestConsume(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 2, func(ctx context.Context, x int) (int, error) {
+		if x < 0 {
+			return 0, errors.New("negative")
+		}
+		time.Sleep(30 * time.Millisecond)
+		return x, nil
+	})
+	q := newTestQueue("1", "-1")
+	consumed := make(chan error)
+	// Action
+	go func() {
+		consumed <- cloudqueue.Consume(ctx, pool, q, 20*time.Millisecond)
+	}()
+	<-q.settled
+	<-q.settled
+	// Assert
+	q.mutex.Lock()
+	if !q.deleted["0"] || q.extended["0"] == 0 {
+		t.Errorf("expected the slow task's message to be extended then deleted, got deleted %v and %d extensions", q.deleted["0"], q.extended["0"])
+	}
+	if !q.released["1"] || q.deleted["1"] {
+		t.Error("expected the failed task's message to be released")
+	}
+	q.mutex.Unlock()
+	// Cleanup
+	cancel()
+	if err := <-consumed; err != nil {
+		t.Errorf("expected Consume to return nil once cancelled, got %v", err)
+	}
+	pool.Wait()
+}
@@ -0,0 +1,54 @@
+package gorkpool
+
+import "context"
+
+// CollectAll reads OutputCh until the pool stops. If ctx is done first it
+// returns what it got so far along with the context's error.
+func (p *GorkPool[Id, Task, Result]) CollectAll(ctx context.Context) ([]Result, error) {
+	return p.collect(ctx, -1)
+}
+
+// CollectN reads n results from OutputCh. If ctx is done first it returns what
+// it got so far along with the context's error, and if the pool stops first,
+// along with ErrPoolClosed.
+func (p *GorkPool[Id, Task, Result]) CollectN(ctx context.Context, n int) ([]Result, error) {
+	return p.collect(ctx, n)
+}
+
+// collect reads n results, or every result when n is negative.
+func (p *GorkPool[Id, Task, Result]) collect(ctx context.Context, n int) ([]Result, error) {
+	outputCh, done := p.OutputCh(), p.Done()
+	results := make([]Result, 0)
+	for n < 0 || len(results) < n {
+		select {
+		case result, ok := <-outputCh:
+			if !ok {
+				return results, collectEnd(n)
+			}
+			results = append(results, result)
+			continue
+		case <-ctx.Done():
+			return results, ctx.Err()
+		case <-done:
+		}
+
+		// The output channel may be external and never closed
+		select {
+		case result, ok := <-outputCh:
+			if ok {
+				results = append(results, result)
+				continue
+			}
+		default:
+		}
+		return results, collectEnd(n)
+	}
+	return results, nil
+}
+
+func collectEnd(n int) error {
+	if n < 0 {
+		return nil
+	}
+	return NewErrPoolClosed()
+}
@@ -0,0 +1,54 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestCollectAll(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	waitFor(t, func() bool { return len(pool.OutputCh()) == 3 })
+	cancel()
+	// Action
+	results, err := pool.CollectAll(context.Background())
+	// Assert
+	if err != nil || len(results) != 3 {
+		t.Errorf("expected 3 results, got %v, %v", results, err)
+	}
+}
+
+func TestCollectN(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	// Action
+	results, err := pool.CollectN(context.Background(), 2)
+	// Assert
+	if err != nil || len(results) != 2 {
+		t.Errorf("expected 2 results, got %v, %v", results, err)
+	}
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	results, err = pool.CollectN(ctx, 2)
+	if !errors.Is(err, context.DeadlineExceeded) || len(results) != 1 {
+		t.Errorf("expected 1 result and a deadline error, got %v, %v", results, err)
+	}
+	cancel()
+	_, err = pool.CollectN(context.Background(), 1)
+	var closed gorkpool.ErrPoolClosed
+	if !errors.As(err, &closed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
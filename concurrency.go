@@ -0,0 +1,90 @@
+package gorkpool
+
+// WithKeyConcurrency caps at n the tasks submitted WithConcurrencyKey that
+// TaskHandler workers handle at once for each key. Tasks over the limit wait
+// in a queue of their key, and each worker finishing a task of the key goes
+// on with the next one waiting.
+func WithKeyConcurrency(n int) Option {
+	return func(c *config) {
+		c.keyConcurrency = n
+	}
+}
+
+// WithConcurrencyKey counts the task against the limit of key set by
+// WithKeyConcurrency. Like tasks with a future, it only goes to TaskHandler
+// workers. Without a limit the key is ignored.
+func WithConcurrencyKey(key string) TaskOption {
+	return func(c *taskConfig) {
+		c.concurrencyKey = key
+	}
+}
+
+// admitKey tells whether env can be handled now, putting it in the queue of
+// its key otherwise. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) admitKey(env *envelope[Task, Result]) bool {
+	if env.limitKey == "" || env.admitted {
+		return true
+	}
+	if p.keyInFlight[env.limitKey] >= p.cfg.keyConcurrency {
+		p.held[env.limitKey] = append(p.held[env.limitKey], env)
+		return false
+	}
+	p.keyInFlight[env.limitKey]++
+	env.admitted = true
+	return true
+}
+
+// releaseKey frees the place of env in its key, handing it to the next task
+// of the key waiting. That task is returned for ws to handle, unless ws is
+// stopping, in which case it is queued again. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) releaseKey(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) *envelope[Task, Result] {
+	if !env.admitted {
+		return nil
+	}
+	env.admitted = false
+
+	key := env.limitKey
+	held := p.held[key]
+	for len(held) > 0 && held[0].canceled {
+		held = held[1:]
+	}
+	if len(held) == 0 {
+		delete(p.held, key)
+		if p.keyInFlight[key]--; p.keyInFlight[key] == 0 {
+			delete(p.keyInFlight, key)
+		}
+		return nil
+	}
+
+	next := held[0]
+	if len(held) == 1 {
+		delete(p.held, key)
+	} else {
+		p.held[key] = held[1:]
+	}
+	if ws.ctx.Err() == nil {
+		next.admitted = true
+		return next
+	}
+
+	p.keyInFlight[key]--
+	p.launch("requeue", func() {
+		if !p.queue.push(next, p.ctx.Done(), nil) {
+			p.abandon(next)
+		}
+	})
+	return nil
+}
+
+// unholdAll takes every task waiting for its key out, leaving them to the
+// caller.
+func (p *GorkPool[Id, Task, Result]) unholdAll() []*envelope[Task, Result] {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var held []*envelope[Task, Result]
+	for key, envs := range p.held {
+		held = append(held, envs...)
+		delete(p.held, key)
+	}
+	return held
+}
@@ -0,0 +1,48 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithKeyConcurrency(t *testing.T) {
+	// Setup
+	var (
+		mutex         sync.Mutex
+		running, peak int
+	)
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 4, func(ctx context.Context, x int) (int, error) {
+		mutex.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mutex.Unlock()
+		<-release
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		return x, nil
+	}, gorkpool.WithOutputBufferSize(6), gorkpool.WithKeyConcurrency(2))
+	// Action
+	for i := 0; i < 6; i++ {
+		pool.AddTask(i, gorkpool.WithConcurrencyKey("hot"))
+	}
+	waitFor(t, func() bool { return pool.InFlight() == 2 && pool.QueueLen() == 4 })
+	close(release)
+	for i := 0; i < 6; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	if peak != 2 {
+		t.Errorf("expected at most %d tasks of the key at once, got %d", 2, peak)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
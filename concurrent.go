@@ -0,0 +1,35 @@
+package gorkpool
+
+import "sync"
+
+// Concurrent is an optional interface for TaskHandler workers that handle
+// several tasks at once, like N in-flight calls over one connection. The pool
+// calls Handle from up to Concurrency goroutines at a time for the worker,
+// which must then be safe for concurrent use, and counts the tasks in hand in
+// its busy slots, see WorkerDump. Concurrency is read once, when the worker
+// is added, and BatchHandler workers ignore it.
+type Concurrent interface {
+	Concurrency() int
+}
+
+func concurrencyOf(w any) int {
+	if c, ok := w.(Concurrent); ok && c.Concurrency() > 1 {
+		return c.Concurrency()
+	}
+	return 1
+}
+
+// serveAll runs as many processing loops for ws as tasks it handles at once.
+// They all stop along with ws.
+func (p *GorkPool[Id, Task, Result]) serveAll(ws *workerState[Id, Task, Result]) {
+	var wg sync.WaitGroup
+	for i := 1; i < concurrencyOf(ws.worker); i++ {
+		wg.Add(1)
+		p.launch("worker", func() {
+			defer wg.Done()
+			p.serve(ws)
+		})
+	}
+	p.serve(ws)
+	wg.Wait()
+}
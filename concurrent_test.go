@@ -0,0 +1,54 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type concurrentHandler struct {
+	testHandler
+	concurrency int
+}
+
+func (w *concurrentHandler) Concurrency() int {
+	return w.concurrency
+}
+
+func TestConcurrentWorker(t *testing.T) {
+	// Setup
+	started, release := make(chan int, 3), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &concurrentHandler{testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			started <- x
+			<-release
+			return -x, nil
+		}}, 3}, nil
+	}, gorkpool.WithOutputBufferSize(4))
+	pool.AddWorker(0)
+	// Action
+	for i := 1; i <= 4; i++ {
+		pool.AddTask(i)
+	}
+	for i := 0; i < 3; i++ {
+		<-started
+	}
+	dump := pool.DumpState()
+	close(release)
+	sum := 0
+	for i := 0; i < 4; i++ {
+		sum += <-pool.OutputCh()
+	}
+	// Assert
+	if busy := dump.Workers[0].Busy; busy != 3 {
+		t.Errorf("expected the worker to handle %d tasks at once, got %d", 3, busy)
+	}
+	if sum != -10 {
+		t.Errorf("expected results to sum to %d, got %d", -10, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
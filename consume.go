@@ -0,0 +1,20 @@
+//go:build go1.23
+
+package gorkpool
+
+import (
+	"context"
+	"iter"
+)
+
+// Consume submits the tasks of seq as it yields them, only pulling the next
+// one once the previous one is queued. It stops at the first task that can't
+// be queued because ctx is done or the pool is closed, returning why.
+func (p *GorkPool[Id, Task, Result]) Consume(ctx context.Context, seq iter.Seq[Task], opts ...TaskOption) error {
+	for task := range seq {
+		if err := p.AddTaskCtx(ctx, task, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+//go:build go1.23
+
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestConsume(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	seq := func(yield func(int) bool) {
+		for i := 1; i <= 4; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	// Action
+	err := pool.Consume(context.Background(), seq)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	results, _ := pool.CollectN(context.Background(), 4)
+	sum := 0
+	for _, x := range results {
+		sum += x
+	}
+	if sum != -10 {
+		t.Errorf("expected results to sum to %d, got %d", -10, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestConsumeClosedPool(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	cancel()
+	pool.Wait()
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; i < 10; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	// Action
+	err := pool.Consume(context.Background(), seq)
+	// Assert
+	var closed gorkpool.ErrPoolClosed
+	if !errors.As(err, &closed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+	if pulled != 1 {
+		t.Errorf("expected iteration to stop after the first task, pulled %d", pulled)
+	}
+}
@@ -0,0 +1,36 @@
+package gorkpool
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+)
+
+var correlationSeq uint64
+
+// Correlated pairs a task or a result with the id of the submission it
+// belongs to, so results on OutputCh can be matched to their tasks.
+type Correlated[T any] struct {
+	ID    string
+	Value T
+}
+
+// NewCorrelatedFuncPool is NewFuncPool for correlated tasks, with every result
+// carrying the id of its task.
+func NewCorrelatedFuncPool[Task any, Result any](ctx context.Context, n int, fn func(Task) Result, opts ...Option) *GorkPool[int, Correlated[Task], Correlated[Result]] {
+	return NewFuncPool(ctx, n, func(task Correlated[Task]) Correlated[Result] {
+		return Correlated[Result]{ID: task.ID, Value: fn(task.Value)}
+	}, opts...)
+}
+
+// SubmitCorrelated queues task under a newly generated id, which it returns.
+// The id is also the task id, so the task can be cancelled with CancelTask.
+func SubmitCorrelated[Id comparable, Task any, Result any](p *GorkPool[Id, Correlated[Task], Result], task Task, opts ...TaskOption) (string, error) {
+	id := strconv.FormatUint(atomic.AddUint64(&correlationSeq, 1), 10)
+	// Not into the backing array of the caller, which may share it
+	opts = append(opts[:len(opts):len(opts)], WithTaskID(id))
+	if err := p.AddTask(Correlated[Task]{ID: id, Value: task}, opts...); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,54 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSubmitCorrelated(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewCorrelatedFuncPool(ctx, 3, func(x int) int { return -x }, gorkpool.WithOutputBufferSize(5))
+	submitted := make(map[string]int)
+	// Action
+	for i := 1; i <= 5; i++ {
+		id, err := gorkpool.SubmitCorrelated(pool, i)
+		if err != nil {
+			t.Fatalf("expected task to be submitted, got %v", err)
+		}
+		submitted[id] = i
+	}
+	// Assert
+	for i := 0; i < 5; i++ {
+		res := <-pool.OutputCh()
+		if task, ok := submitted[res.ID]; !ok || res.Value != -task {
+			t.Errorf("expected result %q to match its task, got %d for task %d", res.ID, res.Value, task)
+		}
+		delete(submitted, res.ID)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitCorrelatedOptions(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewCorrelatedFuncPool(ctx, 1, func(x int) int { return -x }, gorkpool.WithOutputBufferSize(1))
+	opts := make([]gorkpool.TaskOption, 1, 2)
+	opts[0] = gorkpool.WithPriority(1)
+	// Action
+	_, err := gorkpool.SubmitCorrelated(pool, 1, opts...)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected task to be submitted, got %v", err)
+	}
+	if opts[:2][1] != nil {
+		t.Error("expected the options of the caller to be left alone")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
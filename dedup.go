@@ -0,0 +1,66 @@
+package gorkpool
+
+import "time"
+
+// WithDedupWindow makes Submit and SubmitWait coalesce the tasks submitted
+// WithDedupKey with the key of a task submitted less than d before. They
+// don't run again, sharing the Future of that first task instead.
+func WithDedupWindow(d time.Duration) Option {
+	return func(c *config) {
+		c.dedupWindow = d
+	}
+}
+
+// WithDedupKey identifies the task for WithDedupWindow. Tasks submitted with
+// AddTask and the like are never coalesced.
+func WithDedupKey(key string) TaskOption {
+	return func(c *taskConfig) {
+		c.dedupKey = key
+	}
+}
+
+// coalesce returns the envelope submitted with the key of env within the
+// window, if any. Otherwise env becomes the one the next tasks with its key
+// are coalesced into.
+func (p *GorkPool[Id, Task, Result]) coalesce(env *envelope[Task, Result]) (*envelope[Task, Result], bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if first, ok := p.dedup[env.dedupKey]; ok {
+		first.waiters++
+		return first, true
+	}
+
+	env.waiters = 1
+	dedup := p.dedup
+	dedup[env.dedupKey] = env
+	p.cfg.clock.AfterFunc(p.cfg.dedupWindow, func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		if dedup[env.dedupKey] == env {
+			delete(dedup, env.dedupKey)
+		}
+	})
+	return env, false
+}
+
+// forgetDedup stops coalescing tasks into env, which failed to submit.
+func (p *GorkPool[Id, Task, Result]) forgetDedup(env *envelope[Task, Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.dedup[env.dedupKey] == env {
+		delete(p.dedup, env.dedupKey)
+	}
+}
+
+// leave tells whether every submission sharing env gave up on it, in which
+// case the task can be cancelled.
+func (p *GorkPool[Id, Task, Result]) leave(env *envelope[Task, Result]) bool {
+	if env.dedupKey == "" {
+		return true
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	env.waiters--
+	return env.waiters == 0
+}
@@ -0,0 +1,63 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithDedupWindow(t *testing.T) {
+	// Setup
+	var runs int32
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 2, func(ctx context.Context, x int) (int, error) {
+		atomic.AddInt32(&runs, 1)
+		<-release
+		return -x, nil
+	}, gorkpool.WithDedupWindow(time.Minute))
+	futures := make([]*gorkpool.Future[int], 0, 3)
+	// Action
+	for i := 1; i <= 3; i++ {
+		f, err := pool.Submit(i, gorkpool.WithDedupKey("refresh"))
+		if err != nil {
+			t.Fatalf("expected task to be submitted, got %v", err)
+		}
+		futures = append(futures, f)
+	}
+	close(release)
+	// Assert
+	for _, f := range futures {
+		if got, err := f.Wait(context.Background()); err != nil || got != -1 {
+			t.Errorf("expected every future to get the result of the first task, got %d, %v", got, err)
+		}
+	}
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("expected coalesced tasks to run once, ran %d times", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithDedupWindowExpires(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	}, gorkpool.WithDedupWindow(10*time.Millisecond))
+	first, _ := pool.SubmitWait(context.Background(), 1, gorkpool.WithDedupKey("refresh"))
+	// Action
+	time.Sleep(20 * time.Millisecond)
+	second, _ := pool.SubmitWait(context.Background(), 2, gorkpool.WithDedupKey("refresh"))
+	// Assert
+	if first != -1 || second != -2 {
+		t.Errorf("expected tasks outside the window to run, got %d and %d", first, second)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,34 @@
+package gorkpool
+
+import (
+	"context"
+	"math"
+	"runtime"
+)
+
+// WithCPUMultiplier makes NewDefaultPool start m workers per
+// runtime.GOMAXPROCS, like 2 for workloads that wait on I/O part of the time.
+// It defaults to 1.
+func WithCPUMultiplier(m float64) Option {
+	return func(c *config) {
+		c.cpuMultiplier = m
+	}
+}
+
+// NewDefaultPool is NewGorkPoolWithOptions started with one worker per
+// runtime.GOMAXPROCS, a sensible size for CPU bound work. Workers get ids from
+// 0 onwards.
+func NewDefaultPool[Task any, Result any](ctx context.Context, createWorkerFn WorkerFactoryFn[int, Task, Result], opts ...Option) *GorkPool[int, Task, Result] {
+	pool := NewGorkPoolWithOptions(ctx, createWorkerFn, opts...)
+
+	multiplier := pool.cfg.cpuMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	n := int(math.Max(1, math.Round(multiplier*float64(runtime.GOMAXPROCS(0)))))
+	for i := 0; i < n; i++ {
+		pool.AddWorker(i)
+	}
+
+	return pool
+}
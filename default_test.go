@@ -0,0 +1,29 @@
+package gorkpool_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestNewDefaultPool(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	factory := func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return newTestWorker(id, ic, oc), nil
+	}
+	// Action
+	pool := gorkpool.NewDefaultPool(ctx, factory, gorkpool.WithCPUMultiplier(2))
+	// Assert
+	if got, want := pool.Length(), 2*runtime.GOMAXPROCS(0); got != want {
+		t.Errorf("expected %d workers, got %d", want, got)
+	}
+	if !pool.Contains(0) {
+		t.Error("expected worker ids to start at 0")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,54 @@
+package gorkpool
+
+// SubmitTo hands task to the worker with id, for workers owning sessions or
+// other resources of their own. It blocks until the worker takes the task,
+// returning ErrWorkerNotFound if it isn't in the pool, leaves first, or can't
+// be handed tasks: only TaskHandler workers and, with per worker queues,
+// channel workers can. The result goes to the output channel and the task
+// isn't retried, since no other worker may take it.
+func (p *GorkPool[Id, Task, Result]) SubmitTo(id Id, task Task, opts ...TaskOption) error {
+	p.mutex.RLock()
+	ws, ok := p.workers.get(id)
+	running := p.running()
+	if running && ok && ws.direct == nil && ws.inputCh != nil {
+		// Made while running, the send is waited for before ws.inputCh is
+		// closed on shutdown, which cancels p.ctx first
+		p.sends.Add(1)
+		defer p.sends.Done()
+	}
+	p.mutex.RUnlock()
+	switch {
+	case !running:
+		return NewErrPoolClosed()
+	case !ok:
+		return NewErrWorkerNotFound(id)
+	case ws.direct != nil:
+		env := p.newEnvelope(task, opts)
+		env.direct = true
+		return p.handTo(ws, env)
+	case ws.inputCh != nil:
+		select {
+		case ws.inputCh <- task:
+			return nil
+		case <-ws.done:
+			return NewErrWorkerNotFound(id)
+		case <-p.ctx.Done():
+			return NewErrPoolClosed()
+		}
+	default:
+		return NewErrWorkerNotFound(id)
+	}
+}
+
+// handTo gives env straight to ws, failing if ws leaves or the pool stops
+// first.
+func (p *GorkPool[Id, Task, Result]) handTo(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) error {
+	select {
+	case ws.direct <- env:
+		return nil
+	case <-ws.ctx.Done():
+		return NewErrWorkerNotFound(ws.worker.ID())
+	case <-p.ctx.Done():
+		return NewErrPoolClosed()
+	}
+}
@@ -0,0 +1,103 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+func double(ctx context.Context, x int) (int, error) {
+	return 2 * x, nil
+}
+
+func TestSubmitTo(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	workers := map[int]*gorkpooltest.Recorder[int, int, int]{}
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		workers[id] = gorkpooltest.NewRecorder[int](id, double)
+		return workers[id], nil
+	}, gorkpool.WithOutputBufferSize(3))
+	for id := 0; id < 3; id++ {
+		pool.AddWorker(id)
+	}
+	// Action
+	for _, task := range []int{1, 2, 3} {
+		if err := pool.SubmitTo(1, task); err != nil {
+			t.Fatalf("expected task %d to be handed to worker 1, got %v", task, err)
+		}
+	}
+	sum := 0
+	for i := 0; i < 3; i++ {
+		sum += <-pool.OutputCh()
+	}
+	// Assert
+	gorkpooltest.AssertProcessed(t, workers[1], 1, 2, 3)
+	gorkpooltest.AssertProcessedCount(t, workers[0], 0)
+	gorkpooltest.AssertProcessedCount(t, workers[2], 0)
+	if sum != 12 {
+		t.Errorf("expected results to add up to %d, got %d", 12, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitToMissingWorker(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, double)
+	// Action
+	err := pool.SubmitTo(7, 1)
+	// Assert
+	var notFound gorkpool.ErrWorkerNotFound
+	if !errors.As(err, &notFound) || notFound.ID() != 7 {
+		t.Errorf("expected ErrWorkerNotFound for worker 7, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitToShutdown(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		// Setup
+		pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+			return newTestWorker(id, inputCh, outputCh), nil
+		}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy))
+		pool.AddWorker(0)
+		go func() {
+			for range pool.OutputCh() {
+			}
+		}()
+		started, errs := make(chan struct{}, 4), make(chan error, 4)
+		for j := 0; j < cap(errs); j++ {
+			go func() {
+				for {
+					if err := pool.SubmitTo(0, 1); err != nil {
+						errs <- err
+						return
+					}
+					select {
+					case started <- struct{}{}:
+					default:
+					}
+				}
+			}()
+		}
+		for j := 0; j < cap(started); j++ {
+			<-started
+		}
+		// Action
+		pool.Shutdown(context.Background())
+		// Assert
+		for j := 0; j < cap(errs); j++ {
+			if err := <-errs; !errors.Is(err, gorkpool.CodePoolClosed) {
+				t.Fatalf("expected SubmitTo to fail with ErrPoolClosed once shut down, got %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package gorkpool
+
+import "time"
+
+// DispatchMode decides how queued tasks reach the workers.
+type DispatchMode int
+
+const (
+	// DispatchShared has every worker compete on the same channels.
+	DispatchShared DispatchMode = iota
+	// DispatchLeastBusy gives every worker a queue of its own, routing each
+	// task to the worker with the least work waiting. Channel workers get
+	// their queue as the input channel passed to the factory.
+	DispatchLeastBusy
+	// DispatchRoundRobin gives every worker a queue of its own like
+	// DispatchLeastBusy, handing tasks to the workers in turn, in the order
+	// they were added. A task waits for its worker to have room rather than
+	// going to the next one.
+	DispatchRoundRobin
+)
+
+// routeRetry is how often a full set of channel worker queues is checked
+// again, since the pool can't see them taking tasks.
+const routeRetry = time.Millisecond
+
+func WithDispatchMode(mode DispatchMode) Option {
+	return func(c *config) {
+		c.dispatchMode = mode
+	}
+}
+
+// WithWorkerQueueSize sets how many tasks each worker queue holds when
+// dispatching to per worker queues. It defaults to 1.
+func WithWorkerQueueSize(n int) Option {
+	return func(c *config) {
+		c.workerQueueSize = n
+	}
+}
+
+// WithWorkStealing lets idle TaskHandler workers take tasks waiting in the
+// queues of other workers when dispatching to per worker queues.
+func WithWorkStealing() Option {
+	return func(c *config) {
+		c.workStealing = true
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) perWorkerQueues() bool {
+	return p.cfg.dispatchMode != DispatchShared
+}
+
+func (p *GorkPool[Id, Task, Result]) workerQueueSize() int {
+	if p.cfg.workerQueueSize < 1 {
+		return 1
+	}
+	return p.cfg.workerQueueSize
+}
+
+// takes tells whether ws can ever take env, since channel workers can't
+// report back and tagged tasks need workers with those tags.
+func (ws *workerState[Id, Task, Result]) takes(env *envelope[Task, Result]) bool {
+	return (ws.queue != nil || !env.tracked()) && ws.hasTags(env.tags)
+}
+
+// accepts tells whether env fits in the queue of ws right now.
+func (ws *workerState[Id, Task, Result]) accepts(env *envelope[Task, Result]) bool {
+	if ws.paused() || ws.open(ws.clock.Now()) {
+		return false
+	}
+	if ws.queue != nil {
+		return len(ws.queue) < cap(ws.queue)
+	}
+	return !env.tracked() && len(ws.inputCh) < cap(ws.inputCh)
+}
+
+func (ws *workerState[Id, Task, Result]) load() int {
+	return ws.weight + len(ws.inputCh)
+}
+
+// route is deliver for per worker queues.
+func (p *GorkPool[Id, Task, Result]) route(env *envelope[Task, Result], done <-chan struct{}, draining bool) bool {
+	spawned := false
+	for {
+		p.mutex.Lock()
+		if env.keyed && p.holdKey(env) {
+			p.mutex.Unlock()
+			return true
+		}
+		workers := p.workers.len()
+		target := p.pickTarget(env)
+		if target != nil {
+			p.send(target, env)
+		} else if workers > 0 && !p.anyTakes(env) {
+			// Don't hold up the tasks behind it waiting for a worker that
+			// may never come
+			p.parked = append(p.parked, env)
+			p.mutex.Unlock()
+			p.queue.release()
+			return true
+		}
+		p.mutex.Unlock()
+
+		if target != nil {
+			return true
+		}
+		if draining && workers == 0 {
+			return false
+		}
+		if !draining && !spawned {
+			if spawned = p.spawn(); spawned {
+				continue
+			}
+		}
+
+		timer := p.cfg.clock.NewTimer(routeRetry)
+		select {
+		case <-p.wake:
+		case <-timer.C():
+		case <-done:
+			timer.Stop()
+			return false
+		}
+		timer.Stop()
+	}
+}
+
+// pickTarget chooses the worker whose queue gets env, if any has room. The
+// caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) pickTarget(env *envelope[Task, Result]) *workerState[Id, Task, Result] {
+	if env.keyed {
+		return p.keyTarget(env)
+	}
+	if p.dispatcher != nil {
+		return p.dispatchTarget(env)
+	}
+	if p.cfg.dispatchMode == DispatchRoundRobin {
+		return p.nextInTurn(env)
+	}
+
+	var target *workerState[Id, Task, Result]
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws.takes(env) && ws.accepts(env) && (target == nil || ws.lighter(target)) {
+			target = ws
+		}
+		return true
+	})
+	return target
+}
+
+// anyTakes tells whether any worker can take env. The caller must hold
+// p.mutex.
+func (p *GorkPool[Id, Task, Result]) anyTakes(env *envelope[Task, Result]) bool {
+	takes := false
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		takes = ws.takes(env)
+		return !takes
+	})
+	return takes
+}
+
+// nextInTurn is pickTarget for round robin, returning nil while the worker
+// whose turn it is has no room. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) nextInTurn(env *envelope[Task, Result]) *workerState[Id, Task, Result] {
+	var current, next, first *workerState[Id, Task, Result]
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		// Paused workers don't hold up their turn
+		if !ws.takes(env) || ws.paused() {
+			return true
+		}
+		if ws.seq == p.turn && p.turns < ws.capacity {
+			current = ws
+		}
+		if ws.seq > p.turn && (next == nil || ws.seq < next.seq) {
+			next = ws
+		}
+		if first == nil || ws.seq < first.seq {
+			first = ws
+		}
+		return true
+	})
+	if current != nil {
+		next = current
+	} else if next == nil {
+		next = first
+	}
+
+	if next == nil || !next.accepts(env) {
+		return nil
+	}
+	if next.seq != p.turn {
+		p.turn, p.turns = next.seq, 0
+	}
+	p.turns++
+	return next
+}
+
+// send puts env in the queue of ws, which must have room for it. TaskHandler
+// workers free its slot in the pool queue once they take it, while channel
+// workers are out of reach from now on. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) send(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) {
+	if ws.queue != nil {
+		ws.queue <- env
+		ws.weight += env.cost()
+		if env.pinned() {
+			ws.pinned++
+		}
+		if env.keyed {
+			p.own(ws, env)
+		}
+		if p.cfg.workStealing {
+			select {
+			case p.stealable <- struct{}{}:
+			default:
+			}
+		}
+		return
+	}
+	ws.inputCh <- env.task
+	p.finish(env)
+	p.queue.release()
+}
+
+// taken frees the pool queue slot of a task a TaskHandler worker took from its
+// queue.
+func (p *GorkPool[Id, Task, Result]) taken(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) {
+	p.mutex.Lock()
+	ws.weight -= env.cost()
+	if env.pinned() {
+		ws.pinned--
+	}
+	p.mutex.Unlock()
+	p.queue.release()
+	p.notifyWorkersChanged()
+}
+
+// steal takes a task waiting in the queue of another worker, if any. Queues
+// holding keyed or tagged tasks are left alone, since those can't go to just
+// any worker.
+func (p *GorkPool[Id, Task, Result]) steal(thief *workerState[Id, Task, Result]) (*envelope[Task, Result], bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var stolen *envelope[Task, Result]
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws == thief || ws.pinned > 0 || len(ws.queue) == 0 {
+			return true
+		}
+		select {
+		case env, ok := <-ws.queue:
+			if ok {
+				ws.weight -= env.cost()
+				stolen = env
+			}
+		default:
+		}
+		return stolen == nil
+	})
+	if stolen == nil {
+		return nil, false
+	}
+	p.queue.release()
+	p.notifyWorkersChanged()
+	return stolen, true
+}
+
+// reclaim puts the tasks left in the queue of a removed worker back in the
+// pool queue.
+func (p *GorkPool[Id, Task, Result]) reclaim(id Id, ws *workerState[Id, Task, Result]) {
+	p.mutex.Lock()
+	registered := p.workers.holds(id, ws)
+	p.mutex.Unlock()
+	if registered {
+		// The pool is stopping, the queue is closed and taken care of
+		return
+	}
+
+	for {
+		select {
+		case env, ok := <-ws.queue:
+			if !ok {
+				return
+			}
+			if env.owned {
+				p.mutex.Lock()
+				p.returned(env)
+				p.mutex.Unlock()
+				continue
+			}
+			p.queue.unpop(env)
+			continue
+		case task, ok := <-ws.inputCh:
+			if !ok {
+				return
+			}
+			env := p.alloc(task)
+			if !p.queue.push(env, p.ctx.Done(), nil) {
+				p.abandon(env)
+			}
+			continue
+		default:
+		}
+		return
+	}
+}
+
+// closeWorkerQueues lets the workers know there won't be any more tasks in
+// their queues.
+func (p *GorkPool[Id, Task, Result]) closeWorkerQueues() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws.queue != nil {
+			close(ws.queue)
+		}
+		if ws.inputCh != nil {
+			close(ws.inputCh)
+		}
+		return true
+	})
+}
+
+// discardWorkerQueues empties the worker queues into leftovers.
+func (p *GorkPool[Id, Task, Result]) discardWorkerQueues(leftovers []Task) []Task {
+	p.mutex.Lock()
+	targets := p.workers.snapshot()
+	p.mutex.Unlock()
+
+	for _, ws := range targets {
+		for {
+			select {
+			case env := <-ws.queue:
+				p.queue.release()
+				p.abandon(env)
+				leftovers = append(leftovers, env.task)
+				continue
+			case task := <-ws.inputCh:
+				leftovers = append(leftovers, task)
+				continue
+			default:
+			}
+			break
+		}
+	}
+	return leftovers
+}
@@ -0,0 +1,125 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestDispatchLeastBusy(t *testing.T) {
+	// Setup
+	channels := make(map[chan int]bool)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		channels[ic] = true
+		return newTestWorker(id, ic, oc), nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithOutputBufferSize(10))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	for i := 1; i <= 10; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	if len(channels) != 2 {
+		t.Errorf("expected every worker to get its own input channel, got %d channels", len(channels))
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		sum += <-pool.OutputCh()
+	}
+	if sum != -55 {
+		t.Errorf("expected results to sum to %d, got %d", -55, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestDispatchLeastBusyHandlers(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	futures := make([]*gorkpool.Future[int], 0, 10)
+	// Action
+	for i := 1; i <= 10; i++ {
+		f, _ := pool.Submit(i)
+		futures = append(futures, f)
+	}
+	pool.RemoveWorkerById(0)
+	// Assert
+	for i, f := range futures {
+		if got, err := f.Wait(context.Background()); err != nil || got != -(i+1) {
+			t.Errorf("expected result %d, got %d, %v", -(i + 1), got, err)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestDispatchRoundRobin(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return id, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchRoundRobin))
+	for i := 0; i < 3; i++ {
+		pool.AddWorker(i)
+	}
+	for i := 0; i < 6; i++ {
+		// Action
+		got, err := pool.SubmitWait(context.Background(), i)
+		// Assert
+		if err != nil || got != i%3 {
+			t.Errorf("expected task %d to go to worker %d, got %d, %v", i, i%3, got, err)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWorkStealing(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			if x == 0 {
+				<-release
+			}
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchRoundRobin), gorkpool.WithWorkerQueueSize(4), gorkpool.WithWorkStealing())
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	futures := make([]*gorkpool.Future[int], 0, 6)
+	// Action
+	for i := 0; i < 6; i++ {
+		f, _ := pool.Submit(i)
+		futures = append(futures, f)
+	}
+	// Assert
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), time.Second)
+	defer cancelWait()
+	for i, f := range futures[1:] {
+		if got, err := f.Wait(waitCtx); err != nil || got != -(i+1) {
+			t.Errorf("expected task %d to be stolen from the busy worker, got %d, %v", i+1, got, err)
+		}
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
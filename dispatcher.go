@@ -0,0 +1,87 @@
+package gorkpool
+
+import "sort"
+
+// Dispatcher chooses the worker each task goes to when dispatching to per
+// worker queues, in place of the dispatch mode. Keyed tasks still go to the
+// worker owning their key.
+type Dispatcher[Id comparable, Task any] interface {
+	// Dispatch picks one of candidates for task, or none to have the task
+	// wait until the workers change or take some of their tasks.
+	// Candidates are the workers that can take task and have room for it,
+	// in the order they were added, and must not be kept around.
+	Dispatch(task Task, candidates []Candidate[Id]) (Id, bool)
+}
+
+// Candidate is a worker a Dispatcher can pick.
+type Candidate[Id comparable] struct {
+	ID Id
+	// Queued is how many tasks wait in the queue of the worker
+	Queued int
+	// Busy is how many tasks the worker is handling, always 0 for channel
+	// workers
+	Busy int
+	// Load weighs the tasks queued and handled by the worker, see WithWeight
+	Load int
+	// Capacity is the share of the tasks the worker takes, see Weighted
+	Capacity int
+}
+
+// DispatcherFunc lets a plain function be used as a Dispatcher.
+type DispatcherFunc[Id comparable, Task any] func(task Task, candidates []Candidate[Id]) (Id, bool)
+
+func (fn DispatcherFunc[Id, Task]) Dispatch(task Task, candidates []Candidate[Id]) (Id, bool) {
+	return fn(task, candidates)
+}
+
+// SetDispatcher makes d choose where tasks go. It needs per worker queues,
+// failing with ErrNoWorkerQueues otherwise.
+func (p *GorkPool[Id, Task, Result]) SetDispatcher(d Dispatcher[Id, Task]) error {
+	if !p.perWorkerQueues() {
+		return NewErrNoWorkerQueues()
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.dispatcher = d
+	return nil
+}
+
+// dispatchTarget is pickTarget for a custom Dispatcher. The caller must hold
+// p.mutex.
+func (p *GorkPool[Id, Task, Result]) dispatchTarget(env *envelope[Task, Result]) *workerState[Id, Task, Result] {
+	var matched []*workerState[Id, Task, Result]
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws.takes(env) && ws.accepts(env) {
+			matched = append(matched, ws)
+		}
+		return true
+	})
+	if len(matched) == 0 {
+		return nil
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].seq < matched[j].seq
+	})
+	candidates := make([]Candidate[Id], len(matched))
+	for i, ws := range matched {
+		candidates[i] = Candidate[Id]{
+			ID:       ws.worker.ID(),
+			Queued:   len(ws.queue) + len(ws.inputCh),
+			Busy:     ws.busy,
+			Load:     ws.load(),
+			Capacity: ws.capacity,
+		}
+	}
+
+	id, ok := p.dispatcher.Dispatch(env.task, candidates)
+	if !ok {
+		return nil
+	}
+	ws, ok := p.workers.get(id)
+	if !ok || !ws.takes(env) || !ws.accepts(env) {
+		p.logf("gorkpool: dispatcher picked worker %v, which can't take the task", id)
+		return nil
+	}
+	return ws
+}
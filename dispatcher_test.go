@@ -0,0 +1,62 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSetDispatcher(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return x*10 + id, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithOutputBufferSize(10))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	err := pool.SetDispatcher(gorkpool.DispatcherFunc[int, int](func(task int, candidates []gorkpool.Candidate[int]) (int, bool) {
+		for _, c := range candidates {
+			if c.ID == task%2 {
+				return c.ID, true
+			}
+		}
+		return 0, false
+	}))
+	for i := 0; i < 10; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	if err != nil {
+		t.Fatalf("expected dispatcher to be set, got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		got := <-pool.OutputCh()
+		if task, id := got/10, got%10; id != task%2 {
+			t.Errorf("expected task %d to go to worker %d, went to %d", task, task%2, id)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSetDispatcherShared(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	err := pool.SetDispatcher(gorkpool.DispatcherFunc[int, int](func(task int, candidates []gorkpool.Candidate[int]) (int, bool) {
+		return 0, false
+	}))
+	// Assert
+	if !errors.As(err, new(gorkpool.ErrNoWorkerQueues)) {
+		t.Errorf("expected ErrNoWorkerQueues, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
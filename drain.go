@@ -0,0 +1,95 @@
+package gorkpool
+
+import "time"
+
+// WithDrainTimeout bounds how long shutting down waits for the pool to drain.
+// Once d has passed, the tasks still queued are abandoned with ErrPoolClosed
+// and listed in Report.Abandoned, and every worker is signalled to stop, the
+// results of the tasks they were handling being discarded as with Kill.
+// Workers still running d after that, removed ones included, are given up on
+// and listed in Report.TimedOut, so a stuck worker can't keep the pool from
+// stopping. The output channel is left open if a channel worker is among them,
+// since it may still send to it.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.drainTimeout = d
+	}
+}
+
+// forceDrain returns a channel closed once the drain timeout passes, after
+// cutting the drain short like Kill, nil without WithDrainTimeout. It gives up
+// once drained is closed.
+func (p *GorkPool[Id, Task, Result]) forceDrain(drained <-chan struct{}) <-chan struct{} {
+	if p.cfg.drainTimeout <= 0 {
+		return nil
+	}
+	forced := make(chan struct{})
+	timer := p.cfg.clock.NewTimer(p.cfg.drainTimeout)
+	p.launch("drain timeout", func() {
+		defer timer.Stop()
+		select {
+		case <-drained:
+			return
+		case <-timer.C():
+		}
+		p.logf("gorkpool: drain timed out after %v, stopping the workers", p.cfg.drainTimeout)
+		p.killOnce.Do(func() {
+			close(p.kill)
+		})
+		p.abortOnce.Do(func() {
+			close(p.abort)
+		})
+		close(forced)
+	})
+	return forced
+}
+
+// awaitWorkers waits for every worker to exit, or, once forced is closed, for
+// the drain timeout at most, signalling them first unless killed already did.
+// stuck tells whether a channel worker was given up on, which may still send
+// to the output channel.
+func (p *GorkPool[Id, Task, Result]) awaitWorkers(forced <-chan struct{}, killed bool) (stuck bool) {
+	if forced == nil {
+		p.wg.Wait()
+		return false
+	}
+	exited := make(chan struct{})
+	p.launch("drain", func() {
+		p.wg.Wait()
+		close(exited)
+	})
+	select {
+	case <-exited:
+		return false
+	case <-forced:
+	}
+	if !killed {
+		// Channel workers on external channels were signalled already
+		p.stopWorkers(!p.cfg.externalChannels, true)
+	}
+
+	timer := p.cfg.clock.NewTimer(p.cfg.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-exited:
+		return false
+	case <-timer.C():
+	}
+	running := p.runningWorkers()
+	p.logf("gorkpool: giving up on workers %v", running)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.report.TimedOut = running
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws.handler == nil && !ws.exited() {
+			stuck = true
+		}
+		return true
+	})
+	p.workers.eachLeaving(func(ws *workerState[Id, Task, Result]) {
+		if ws.handler == nil && !ws.exited() {
+			stuck = true
+		}
+	})
+	return stuck
+}
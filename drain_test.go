@@ -0,0 +1,116 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithDrainTimeout(t *testing.T) {
+	// Setup
+	started, release := make(chan struct{}), make(chan struct{})
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		if x == 1 {
+			// Stuck, ignoring ctx
+			close(started)
+			<-release
+		}
+		return x, nil
+	}, gorkpool.WithQueueSize(10), gorkpool.WithDrainTimeout(20*time.Millisecond))
+	pool.Submit(1)
+	<-started
+	queued, _ := pool.Submit(2)
+	pool.Submit(3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Action
+	report, err := pool.ShutdownReport(ctx)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected the shutdown to give up on the stuck worker, got %v", err)
+	}
+	if want := []any{2, 3}; !reflect.DeepEqual(report.Abandoned, want) {
+		t.Errorf("expected tasks %v to be abandoned, got %v", want, report.Abandoned)
+	}
+	if want := []any{0}; !reflect.DeepEqual(report.TimedOut, want) {
+		t.Errorf("expected worker %v to time out, got %v", want, report.TimedOut)
+	}
+	if _, err := queued.Wait(context.Background()); !errors.Is(err, gorkpool.CodePoolClosed) {
+		t.Errorf("expected the abandoned task to fail with ErrPoolClosed, got %v", err)
+	}
+	// Cleanup
+	close(release)
+}
+
+func TestWithDrainTimeoutSignalsWorkers(t *testing.T) {
+	// Setup
+	started := make(chan struct{})
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, gorkpool.WithDrainTimeout(20*time.Millisecond))
+	pool.Submit(1)
+	<-started
+	// Action
+	report, err := pool.ShutdownReport(context.Background())
+	// Assert
+	if err != nil || len(report.TimedOut) != 0 {
+		t.Errorf("expected the signalled worker to exit, got %v and timed out workers %v", err, report.TimedOut)
+	}
+	if _, ok := <-pool.OutputCh(); ok {
+		t.Error("expected the output channel to be closed")
+	}
+}
+
+// stuckWorker is a channel worker ignoring SignalRemoval while handling a task.
+type stuckWorker struct {
+	id      int
+	input   chan int
+	output  chan int
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *stuckWorker) ID() int {
+	return w.id
+}
+
+func (w *stuckWorker) Process() {
+	x := <-w.input
+	close(w.started)
+	<-w.release
+	w.output <- x
+}
+
+func (w *stuckWorker) SignalRemoval() {}
+
+func TestWithDrainTimeoutRemovedWorker(t *testing.T) {
+	// Setup
+	started, release := make(chan struct{}), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &stuckWorker{id: id, input: ic, output: oc, started: started, release: release}, nil
+	}, gorkpool.WithOutputBufferSize(1), gorkpool.WithDrainTimeout(20*time.Millisecond))
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-started
+	pool.RemoveWorkerById(0)
+	// Action
+	cancel()
+	pool.Wait()
+	// Assert
+	report, _ := pool.FinalReport()
+	if want := []any{0}; !reflect.DeepEqual(report.TimedOut, want) {
+		t.Errorf("expected removed worker %v to time out, got %v", want, report.TimedOut)
+	}
+	// It would panic sending to a closed output channel
+	close(release)
+	if got := <-pool.OutputCh(); got != 1 {
+		t.Errorf("expected the removed worker to still send its result, got %d", got)
+	}
+}
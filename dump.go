@@ -0,0 +1,105 @@
+package gorkpool
+
+import (
+	"sort"
+	"time"
+)
+
+// QueueDump summarizes the tasks waiting in the queue of the pool, leaving
+// out those already handed to worker queues.
+type QueueDump struct {
+	Len  int `json:"len"`
+	Size int `json:"size"`
+	// Oldest is when the task waiting the longest was queued, zero if none is
+	Oldest     time.Time      `json:"oldest"`
+	ByPriority map[int]int    `json:"by_priority,omitempty"`
+	ByTenant   map[string]int `json:"by_tenant,omitempty"`
+}
+
+// ConfigDump is the configuration of the pool, as set by its options.
+type ConfigDump struct {
+	InputBufferSize   int                `json:"input_buffer_size"`
+	OutputBufferSize  int                `json:"output_buffer_size"`
+	MinWorkers        int                `json:"min_workers"`
+	MaxWorkers        int                `json:"max_workers"`
+	QueueSize         int                `json:"queue_size"`
+	DispatchMode      DispatchMode       `json:"dispatch_mode"`
+	RemovalPolicy     RemovalPolicy      `json:"removal_policy"`
+	Backpressure      BackpressurePolicy `json:"backpressure"`
+	WorkerQueueSize   int                `json:"worker_queue_size"`
+	WorkStealing      bool               `json:"work_stealing"`
+	OrderedResults    bool               `json:"ordered_results"`
+	KeyConcurrency    int                `json:"key_concurrency"`
+	DedupWindow       time.Duration      `json:"dedup_window"`
+	VisibilityTimeout time.Duration      `json:"visibility_timeout"`
+	IdleTimeout       time.Duration      `json:"idle_timeout"`
+	BatchSize         int                `json:"batch_size"`
+	BatchWindow       time.Duration      `json:"batch_window"`
+	HedgeDelay        time.Duration      `json:"hedge_delay"`
+	TaskBudget        time.Duration      `json:"task_budget"`
+	ReadyWorkers      int                `json:"ready_workers"`
+	Inline            bool               `json:"inline"`
+}
+
+func (c *config) dump() ConfigDump {
+	return ConfigDump{
+		InputBufferSize:   c.inputBufferSize,
+		OutputBufferSize:  c.outputBufferSize,
+		MinWorkers:        c.minWorkers,
+		MaxWorkers:        c.maxWorkers,
+		QueueSize:         c.queueSize,
+		DispatchMode:      c.dispatchMode,
+		RemovalPolicy:     c.removalPolicy,
+		Backpressure:      c.backpressure,
+		WorkerQueueSize:   c.workerQueueSize,
+		WorkStealing:      c.workStealing,
+		OrderedResults:    c.orderedResults,
+		KeyConcurrency:    c.keyConcurrency,
+		DedupWindow:       c.dedupWindow,
+		VisibilityTimeout: c.visibilityTimeout,
+		IdleTimeout:       c.idleTimeout,
+		BatchSize:         c.batchSize,
+		BatchWindow:       c.batchWindow,
+		HedgeDelay:        c.hedgeDelay,
+		TaskBudget:        c.taskBudget,
+		ReadyWorkers:      c.requiredReady(),
+		Inline:            c.inline,
+	}
+}
+
+func (q *taskQueue[Task, Result]) dump() QueueDump {
+	_, size := q.slots.load()
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	dump := QueueDump{Len: len(q.items), Size: size}
+	for _, env := range q.items {
+		if dump.Oldest.IsZero() || env.queuedAt.Before(dump.Oldest) {
+			dump.Oldest = env.queuedAt
+		}
+		if dump.ByPriority == nil {
+			dump.ByPriority = make(map[int]int)
+		}
+		dump.ByPriority[env.priority]++
+		if env.tenant != "" {
+			if dump.ByTenant == nil {
+				dump.ByTenant = make(map[string]int)
+			}
+			dump.ByTenant[env.tenant]++
+		}
+	}
+	return dump
+}
+
+// tagList returns the tags of ws, sorted.
+func (ws *workerState[Id, Task, Result]) tagList() []string {
+	if len(ws.tags) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(ws.tags))
+	for tag := range ws.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
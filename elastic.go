@@ -0,0 +1,51 @@
+package gorkpool
+
+// SpawnOnDemand makes the pool add a worker, with the id nextID returns, when
+// a task can't be handed to any of its workers right away, up to the maximum
+// set by WithMaxWorkers if any. Together with WithIdleTimeout the pool grows
+// and shrinks with its load, and it can start with no workers at all.
+func (p *GorkPool[Id, Task, Result]) SpawnOnDemand(nextID func() Id) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.nextID = nextID
+}
+
+func (p *GorkPool[Id, Task, Result]) spawnsOnDemand() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.nextID != nil
+}
+
+// spawn adds a worker on demand, returning false if the pool can't have one
+// more.
+func (p *GorkPool[Id, Task, Result]) spawn() bool {
+	p.mutex.Lock()
+	nextID := p.nextID
+	full := p.cfg.maxWorkers > 0 && p.workers.len() >= p.cfg.maxWorkers
+	running := p.running()
+	p.mutex.Unlock()
+	if nextID == nil || full || !running {
+		return false
+	}
+
+	id := nextID()
+	if err := p.AddWorker(id); err != nil {
+		p.reportErr(NewErrWorker(id, err))
+		return false
+	}
+	return true
+}
+
+// handOver is deliver without waiting for a worker to be ready.
+func (p *GorkPool[Id, Task, Result]) handOver(env *envelope[Task, Result], inputCh chan Task) bool {
+	select {
+	case inputCh <- env.task:
+		p.finish(env)
+	case p.taskCh <- env:
+	default:
+		return false
+	}
+
+	p.queue.release()
+	return true
+}
@@ -0,0 +1,70 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSpawnOnDemand(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		<-release
+		return -x, nil
+	})
+	next := 0
+	pool.SpawnOnDemand(func() int {
+		next++
+		return next
+	})
+	// Action
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	waitFor(t, func() bool { return pool.Length() == 3 && pool.InFlight() == 3 })
+	close(release)
+	sum := 0
+	for i := 0; i < 3; i++ {
+		sum += <-pool.OutputCh()
+	}
+	if sum != -6 {
+		t.Errorf("expected results to sum to %d, got %d", -6, sum)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSpawnOnDemandMaxWorkers(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 0, func(ctx context.Context, x int) (int, error) {
+		<-release
+		return -x, nil
+	}, gorkpool.WithMaxWorkers(2), gorkpool.WithQueueSize(5), gorkpool.WithOutputBufferSize(5))
+	next := 0
+	pool.SpawnOnDemand(func() int {
+		next++
+		return next
+	})
+	// Action
+	for i := 1; i <= 5; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	waitFor(t, func() bool { return pool.InFlight() == 2 })
+	if got := pool.Length(); got != 2 {
+		t.Errorf("expected the pool to grow up to %d workers, got %d", 2, got)
+	}
+	close(release)
+	for i := 0; i < 5; i++ {
+		<-pool.OutputCh()
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,166 @@
+package gorkpool
+
+// ErrorCode identifies what went wrong, for callers to branch on. Every error
+// of the package has one, returned by its Code method, and codes double as
+// sentinels: errors.Is(err, CodeQueueFull) tells whether err is, or wraps, an
+// ErrQueueFull. The error types themselves are there for errors.As.
+type ErrorCode string
+
+const (
+	CodeIdConflict        ErrorCode = "id_conflict"
+	CodeInvalidCronExpr   ErrorCode = "invalid_cron_expr"
+	CodePermanent         ErrorCode = "permanent"
+	CodePoolClosed        ErrorCode = "pool_closed"
+	CodeSubmitCanceled    ErrorCode = "submit_canceled"
+	CodeInvalidTransition ErrorCode = "invalid_transition"
+	CodeShutdownTimeout   ErrorCode = "shutdown_timeout"
+	CodeMaxWorkers        ErrorCode = "max_workers"
+	CodePanic             ErrorCode = "panic"
+	CodeWorker            ErrorCode = "worker"
+	CodeRemovalTimeout    ErrorCode = "removal_timeout"
+	CodeNoWorkerQueues    ErrorCode = "no_worker_queues"
+	CodeTaskFailed        ErrorCode = "task_failed"
+	CodeQueueFull         ErrorCode = "queue_full"
+	CodeQuotaExceeded     ErrorCode = "quota_exceeded"
+	CodeTaskStale         ErrorCode = "task_stale"
+	CodeNacked            ErrorCode = "nacked"
+	CodeLeaseExpired      ErrorCode = "lease_expired"
+	CodeUnhealthy         ErrorCode = "unhealthy"
+	CodeCircuitOpen       ErrorCode = "circuit_open"
+	CodeWorkerNotFound    ErrorCode = "worker_not_found"
+	CodeMinWorkers        ErrorCode = "min_workers"
+	CodeFactoryFailed     ErrorCode = "factory_failed"
+	CodeNoIdGenerator     ErrorCode = "no_id_generator"
+	CodePoolExists        ErrorCode = "pool_exists"
+	CodePoolShutdown      ErrorCode = "pool_shutdown"
+	CodeTaskStore         ErrorCode = "task_store"
+	CodeConnLost          ErrorCode = "conn_lost"
+	CodeProcessExited     ErrorCode = "process_exited"
+	CodeBatchResults      ErrorCode = "batch_results"
+	CodeChunkFailed       ErrorCode = "chunk_failed"
+	CodeGoroutineLeak     ErrorCode = "goroutine_leak"
+	CodePreempted         ErrorCode = "preempted"
+	CodeNotReady          ErrorCode = "not_ready"
+)
+
+func (c ErrorCode) Error() string {
+	return "gorkpool: " + string(c)
+}
+
+// Phase is the part of a pool's life an error comes from.
+type Phase string
+
+const (
+	// PhaseConfig errors come from setting up pools, schedules and the like.
+	PhaseConfig Phase = "config"
+	// PhaseSubmit errors keep tasks from being queued.
+	PhaseSubmit Phase = "submit"
+	// PhaseWorkers errors come from adding and removing workers.
+	PhaseWorkers Phase = "workers"
+	// PhaseHandle errors come from handling tasks.
+	PhaseHandle Phase = "handle"
+	// PhaseShutdown errors come from pools stopping.
+	PhaseShutdown Phase = "shutdown"
+)
+
+var codePhases = map[ErrorCode]Phase{
+	CodeIdConflict:        PhaseWorkers,
+	CodeInvalidCronExpr:   PhaseConfig,
+	CodePermanent:         PhaseHandle,
+	CodePoolClosed:        PhaseSubmit,
+	CodeSubmitCanceled:    PhaseSubmit,
+	CodeInvalidTransition: PhaseShutdown,
+	CodeShutdownTimeout:   PhaseShutdown,
+	CodeMaxWorkers:        PhaseWorkers,
+	CodePanic:             PhaseHandle,
+	CodeWorker:            PhaseHandle,
+	CodeRemovalTimeout:    PhaseWorkers,
+	CodeNoWorkerQueues:    PhaseConfig,
+	CodeTaskFailed:        PhaseHandle,
+	CodeQueueFull:         PhaseSubmit,
+	CodeQuotaExceeded:     PhaseSubmit,
+	CodeTaskStale:         PhaseHandle,
+	CodeNacked:            PhaseHandle,
+	CodeLeaseExpired:      PhaseHandle,
+	CodeUnhealthy:         PhaseHandle,
+	CodeCircuitOpen:       PhaseHandle,
+	CodeWorkerNotFound:    PhaseWorkers,
+	CodeMinWorkers:        PhaseWorkers,
+	CodeFactoryFailed:     PhaseWorkers,
+	CodeNoIdGenerator:     PhaseConfig,
+	CodePoolExists:        PhaseConfig,
+	CodePoolShutdown:      PhaseShutdown,
+	CodeTaskStore:         PhaseShutdown,
+	CodeConnLost:          PhaseHandle,
+	CodeProcessExited:     PhaseHandle,
+	CodeBatchResults:      PhaseHandle,
+	CodeChunkFailed:       PhaseHandle,
+	CodeGoroutineLeak:     PhaseShutdown,
+	CodePreempted:         PhaseHandle,
+	CodeNotReady:          PhaseWorkers,
+}
+
+// Phase returns the phase errors with code come from.
+func (c ErrorCode) Phase() Phase {
+	return codePhases[c]
+}
+
+// ErrorInfo is what Inspect makes of an error.
+type ErrorInfo struct {
+	// Code is the code of the innermost error of the package err wraps, the
+	// closest to the cause
+	Code  ErrorCode
+	Phase Phase
+	// WorkerID and TaskID are the first worker and task id found along the
+	// way, nil and empty if none
+	WorkerID any
+	TaskID   string
+}
+
+type coded interface {
+	Code() ErrorCode
+}
+
+// Inspect walks err and the errors it wraps, telling what they have to say
+// about the failure. It returns false if none of them come from the package.
+func Inspect(err error) (ErrorInfo, bool) {
+	var info ErrorInfo
+	inspect(err, &info)
+	info.Phase = info.Code.Phase()
+	return info, info.Code != ""
+}
+
+func inspect(err error, info *ErrorInfo) {
+	if err == nil {
+		return
+	}
+	if c, ok := err.(coded); ok {
+		info.Code = c.Code()
+	}
+	if info.WorkerID == nil {
+		switch e := err.(type) {
+		case ErrIdConflict:
+			info.WorkerID = e.id
+		case ErrWorker:
+			info.WorkerID = e.id
+		case ErrRemovalTimeout:
+			info.WorkerID = e.id
+		case ErrWorkerNotFound:
+			info.WorkerID = e.id
+		case ErrFactoryFailed:
+			info.WorkerID = e.id
+		}
+	}
+	if e, ok := err.(ErrTaskFailed); ok && info.TaskID == "" {
+		info.TaskID = e.taskID
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		inspect(e.Unwrap(), info)
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			inspect(err, info)
+		}
+	}
+}
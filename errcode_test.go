@@ -0,0 +1,111 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestErrorCodes(t *testing.T) {
+	// Setup
+	errs := []interface {
+		error
+		Code() gorkpool.ErrorCode
+	}{
+		gorkpool.NewErrIdConflict(1),
+		gorkpool.NewErrInvalidCronExpr("* *", "too few fields"),
+		gorkpool.NewErrPermanent(errors.New("boom")),
+		gorkpool.NewErrPoolClosed(),
+		gorkpool.NewErrSubmitCanceled(context.Canceled),
+		gorkpool.NewErrInvalidTransition(gorkpool.StateStopped, gorkpool.StateDraining),
+		gorkpool.NewErrShutdownTimeout([]any{1}, context.DeadlineExceeded),
+		gorkpool.NewErrMaxWorkers(1),
+		gorkpool.NewErrPanic("boom"),
+		gorkpool.NewErrWorker(1, errors.New("boom")),
+		gorkpool.NewErrRemovalTimeout(1, context.DeadlineExceeded),
+		gorkpool.NewErrNoWorkerQueues(),
+		gorkpool.NewErrTaskFailed(1, errors.New("boom")),
+		gorkpool.NewErrQueueFull(),
+		gorkpool.NewErrQuotaExceeded("acme"),
+		gorkpool.NewErrTaskStale(),
+		gorkpool.NewErrNacked(),
+		gorkpool.NewErrLeaseExpired(),
+		gorkpool.NewErrUnhealthy(),
+		gorkpool.NewErrCircuitOpen(3),
+		gorkpool.NewErrWorkerNotFound(1),
+		gorkpool.NewErrMinWorkers(1),
+		gorkpool.NewErrFactoryFailed(1, errors.New("boom")),
+		gorkpool.NewErrNoIdGenerator(),
+		gorkpool.NewErrPoolExists("jobs"),
+		gorkpool.NewErrPoolShutdown("jobs", context.DeadlineExceeded),
+		gorkpool.NewErrTaskStore(errors.New("boom")),
+		gorkpool.NewErrConnLost(errors.New("boom")),
+		gorkpool.NewErrProcessExited(nil),
+		gorkpool.NewErrBatchResults(2, 1),
+		gorkpool.NewErrChunkFailed(0, 2, errors.New("boom")),
+	}
+	seen := map[gorkpool.ErrorCode]bool{}
+	for _, err := range errs {
+		// Action
+		code := err.Code()
+		wrapped := fmt.Errorf("wrapped: %w", err)
+		// Assert
+		if code == "" || seen[code] {
+			t.Errorf("expected %T to have a code of its own, got %q", err, code)
+		}
+		seen[code] = true
+		if code.Phase() == "" {
+			t.Errorf("expected code %q to have a phase", code)
+		}
+		if !errors.Is(wrapped, code) {
+			t.Errorf("expected %T to match its code %q with errors.Is", err, code)
+		}
+		if errors.Is(wrapped, gorkpool.CodePoolClosed) != (code == gorkpool.CodePoolClosed) {
+			t.Errorf("expected %T to match %q only if it is one", err, gorkpool.CodePoolClosed)
+		}
+		// Errors match their code, not other values of their type
+		zero := reflect.Zero(reflect.TypeOf(err)).Interface().(error)
+		equal := reflect.TypeOf(err).Comparable() && error(err) == zero
+		if errors.Is(wrapped, zero) != equal {
+			t.Errorf("expected %T to match a zero %T with errors.Is only if equal", err, zero)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	// Setup
+	err := fmt.Errorf("handling: %w", gorkpool.NewErrWorker(7, gorkpool.NewErrCircuitOpen(3)))
+	// Action
+	info, ok := gorkpool.Inspect(err)
+	// Assert
+	if !ok {
+		t.Fatalf("expected %v to be inspected", err)
+	}
+	if info.Code != gorkpool.CodeCircuitOpen || info.Phase != gorkpool.PhaseHandle || info.WorkerID != 7 {
+		t.Errorf("expected circuit_open while handling on worker 7, got %+v", info)
+	}
+	if _, ok := gorkpool.Inspect(errors.New("boom")); ok {
+		t.Errorf("expected foreign errors not to be inspected")
+	}
+}
+
+func TestInspectTaskID(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		return 0, errors.New("boom")
+	}, gorkpool.WithErrorChannel(1))
+	// Action
+	pool.AddTask(1, gorkpool.WithTaskID("job-1"))
+	err := <-pool.ErrorCh()
+	// Assert
+	info, _ := gorkpool.Inspect(err)
+	if info.Code != gorkpool.CodeTaskFailed || info.TaskID != "job-1" {
+		t.Errorf("expected task_failed for task job-1, got %+v", info)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
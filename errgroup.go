@@ -0,0 +1,44 @@
+package gorkpool
+
+// maxErrors caps how many errors Errors keeps, the first ones.
+const maxErrors = 1024
+
+// WithCancelOnError cancels the pool, like errgroup does its context, with the
+// first error it collects as the cause, so it ends up failed.
+func WithCancelOnError() Option {
+	return func(c *config) {
+		c.cancelOnError = true
+	}
+}
+
+// FirstError returns the first worker error or task failed for good since the
+// pool started, nil if there is none.
+func (p *GorkPool[Id, Task, Result]) FirstError() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return p.errs[0]
+}
+
+// Errors returns the worker errors, as ErrWorker, and the tasks failed for
+// good, as ErrTaskFailed, since the pool started, up to the first 1024.
+func (p *GorkPool[Id, Task, Result]) Errors() []error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]error(nil), p.errs...)
+}
+
+func (p *GorkPool[Id, Task, Result]) recordErr(err error) {
+	p.mutex.Lock()
+	first := len(p.errs) == 0
+	if len(p.errs) < maxErrors {
+		p.errs = append(p.errs, err)
+	}
+	p.mutex.Unlock()
+
+	if first && p.cfg.cancelOnError {
+		p.cancel(err)
+	}
+}
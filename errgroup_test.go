@@ -0,0 +1,62 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestErrors(t *testing.T) {
+	// Setup
+	failure := errors.New("failure")
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if x < 0 {
+			return 0, failure
+		}
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	for _, x := range []int{1, -1, 2, -2} {
+		f, _ := pool.Submit(x)
+		f.Wait(context.Background())
+	}
+	errs := pool.Errors()
+	// Assert
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	var taskErr gorkpool.ErrTaskFailed
+	if first := pool.FirstError(); !errors.As(first, &taskErr) || !errors.Is(first, failure) || first != errs[0] {
+		t.Errorf("expected first error to be the failure of the first task, got %v", first)
+	}
+	if pool.State() != gorkpool.StateRunning {
+		t.Errorf("expected pool to keep running, got %v", pool.State())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithCancelOnError(t *testing.T) {
+	// Setup
+	failure := errors.New("failure")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		return 0, failure
+	}, gorkpool.WithCancelOnError())
+	// Action
+	f, _ := pool.Submit(1)
+	f.Wait(context.Background())
+	pool.Wait()
+	// Assert
+	if pool.State() != gorkpool.StateFailed || !errors.Is(pool.Err(), failure) {
+		t.Errorf("expected pool to fail with the task failure, got %v (%v)", pool.State(), pool.Err())
+	}
+	if pool.FirstError() == nil {
+		t.Error("expected first error to be kept")
+	}
+}
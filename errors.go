@@ -1,6 +1,10 @@
 package gorkpool
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 type ErrIdConflict struct {
 	id any
@@ -15,3 +19,916 @@ func NewErrIdConflict(id any) ErrIdConflict {
 func (err ErrIdConflict) Error() string {
 	return fmt.Sprintf("worker id conflict: there's already a worker with id %v", err.id)
 }
+
+func (err ErrIdConflict) Code() ErrorCode {
+	return CodeIdConflict
+}
+
+func (err ErrIdConflict) Is(target error) bool {
+	return target == CodeIdConflict
+}
+
+func (err ErrIdConflict) ID() any {
+	return err.id
+}
+
+type ErrInvalidCronExpr struct {
+	expr   string
+	reason string
+}
+
+func NewErrInvalidCronExpr(expr string, reason string) ErrInvalidCronExpr {
+	return ErrInvalidCronExpr{
+		expr:   expr,
+		reason: reason,
+	}
+}
+
+func (err ErrInvalidCronExpr) Error() string {
+	return fmt.Sprintf("invalid cron expression %q: %s", err.expr, err.reason)
+}
+
+func (err ErrInvalidCronExpr) Code() ErrorCode {
+	return CodeInvalidCronExpr
+}
+
+func (err ErrInvalidCronExpr) Is(target error) bool {
+	return target == CodeInvalidCronExpr
+}
+
+// ErrPermanent marks a task failure that must not be retried.
+type ErrPermanent struct {
+	err error
+}
+
+func NewErrPermanent(err error) ErrPermanent {
+	return ErrPermanent{
+		err: err,
+	}
+}
+
+func (err ErrPermanent) Error() string {
+	return fmt.Sprintf("permanent failure: %v", err.err)
+}
+
+func (err ErrPermanent) Code() ErrorCode {
+	return CodePermanent
+}
+
+func (err ErrPermanent) Is(target error) bool {
+	return target == CodePermanent
+}
+
+func (err ErrPermanent) Unwrap() error {
+	return err.err
+}
+
+type ErrPoolClosed struct{}
+
+func NewErrPoolClosed() ErrPoolClosed {
+	return ErrPoolClosed{}
+}
+
+func (err ErrPoolClosed) Error() string {
+	return "pool closed: it no longer accepts tasks"
+}
+
+func (err ErrPoolClosed) Code() ErrorCode {
+	return CodePoolClosed
+}
+
+func (err ErrPoolClosed) Is(target error) bool {
+	return target == CodePoolClosed
+}
+
+// ErrSubmitCanceled is returned when the caller's context is done before the
+// task could be queued. It unwraps to the context's error.
+type ErrSubmitCanceled struct {
+	err error
+}
+
+func NewErrSubmitCanceled(err error) ErrSubmitCanceled {
+	return ErrSubmitCanceled{
+		err: err,
+	}
+}
+
+func (err ErrSubmitCanceled) Error() string {
+	return fmt.Sprintf("task submission canceled: %v", err.err)
+}
+
+func (err ErrSubmitCanceled) Code() ErrorCode {
+	return CodeSubmitCanceled
+}
+
+func (err ErrSubmitCanceled) Is(target error) bool {
+	return target == CodeSubmitCanceled
+}
+
+func (err ErrSubmitCanceled) Unwrap() error {
+	return err.err
+}
+
+type ErrInvalidTransition struct {
+	from State
+	to   State
+}
+
+func NewErrInvalidTransition(from State, to State) ErrInvalidTransition {
+	return ErrInvalidTransition{
+		from: from,
+		to:   to,
+	}
+}
+
+func (err ErrInvalidTransition) Error() string {
+	return fmt.Sprintf("invalid pool state transition from %v to %v", err.from, err.to)
+}
+
+func (err ErrInvalidTransition) Code() ErrorCode {
+	return CodeInvalidTransition
+}
+
+func (err ErrInvalidTransition) Is(target error) bool {
+	return target == CodeInvalidTransition
+}
+
+type ErrShutdownTimeout struct {
+	workers []any
+	err     error
+}
+
+func NewErrShutdownTimeout(workers []any, err error) ErrShutdownTimeout {
+	return ErrShutdownTimeout{
+		workers: workers,
+		err:     err,
+	}
+}
+
+func (err ErrShutdownTimeout) Error() string {
+	return fmt.Sprintf("shutdown timed out with %d worker(s) still running %v: %v", len(err.workers), err.workers, err.err)
+}
+
+func (err ErrShutdownTimeout) Code() ErrorCode {
+	return CodeShutdownTimeout
+}
+
+func (err ErrShutdownTimeout) Is(target error) bool {
+	return target == CodeShutdownTimeout
+}
+
+func (err ErrShutdownTimeout) Unwrap() error {
+	return err.err
+}
+
+// Workers returns the ids of the workers that didn't stop in time.
+func (err ErrShutdownTimeout) Workers() []any {
+	return err.workers
+}
+
+type ErrMaxWorkers struct {
+	max int
+}
+
+func NewErrMaxWorkers(max int) ErrMaxWorkers {
+	return ErrMaxWorkers{
+		max: max,
+	}
+}
+
+func (err ErrMaxWorkers) Error() string {
+	return fmt.Sprintf("worker limit reached: the pool can't have more than %d workers", err.max)
+}
+
+func (err ErrMaxWorkers) Code() ErrorCode {
+	return CodeMaxWorkers
+}
+
+func (err ErrMaxWorkers) Is(target error) bool {
+	return target == CodeMaxWorkers
+}
+
+// ErrPanic is the error of a task whose handler panicked.
+type ErrPanic struct {
+	value any
+}
+
+func NewErrPanic(value any) ErrPanic {
+	return ErrPanic{
+		value: value,
+	}
+}
+
+func (err ErrPanic) Error() string {
+	return fmt.Sprintf("worker panicked: %v", err.value)
+}
+
+func (err ErrPanic) Code() ErrorCode {
+	return CodePanic
+}
+
+func (err ErrPanic) Is(target error) bool {
+	return target == CodePanic
+}
+
+func (err ErrPanic) Value() any {
+	return err.value
+}
+
+// ErrWorker is an error returned by a worker, along with its id.
+type ErrWorker struct {
+	id  any
+	err error
+}
+
+func NewErrWorker(id any, err error) ErrWorker {
+	return ErrWorker{
+		id:  id,
+		err: err,
+	}
+}
+
+func (err ErrWorker) Error() string {
+	return fmt.Sprintf("worker %v failed: %v", err.id, err.err)
+}
+
+func (err ErrWorker) Code() ErrorCode {
+	return CodeWorker
+}
+
+func (err ErrWorker) Is(target error) bool {
+	return target == CodeWorker
+}
+
+func (err ErrWorker) Unwrap() error {
+	return err.err
+}
+
+func (err ErrWorker) ID() any {
+	return err.id
+}
+
+type ErrRemovalTimeout struct {
+	id  any
+	err error
+}
+
+func NewErrRemovalTimeout(id any, err error) ErrRemovalTimeout {
+	return ErrRemovalTimeout{
+		id:  id,
+		err: err,
+	}
+}
+
+func (err ErrRemovalTimeout) Error() string {
+	return fmt.Sprintf("worker %v didn't stop in time: %v", err.id, err.err)
+}
+
+func (err ErrRemovalTimeout) Code() ErrorCode {
+	return CodeRemovalTimeout
+}
+
+func (err ErrRemovalTimeout) Is(target error) bool {
+	return target == CodeRemovalTimeout
+}
+
+func (err ErrRemovalTimeout) Unwrap() error {
+	return err.err
+}
+
+func (err ErrRemovalTimeout) ID() any {
+	return err.id
+}
+
+type ErrNoWorkerQueues struct{}
+
+func NewErrNoWorkerQueues() ErrNoWorkerQueues {
+	return ErrNoWorkerQueues{}
+}
+
+func (err ErrNoWorkerQueues) Error() string {
+	return "the pool doesn't dispatch to per worker queues"
+}
+
+func (err ErrNoWorkerQueues) Code() ErrorCode {
+	return CodeNoWorkerQueues
+}
+
+func (err ErrNoWorkerQueues) Is(target error) bool {
+	return target == CodeNoWorkerQueues
+}
+
+// ErrTaskFailed is the error of a task reported to ErrorCh, along with the
+// task and the id it was submitted with, if any.
+type ErrTaskFailed struct {
+	task   any
+	taskID string
+	err    error
+}
+
+func NewErrTaskFailed(task any, err error) ErrTaskFailed {
+	return ErrTaskFailed{
+		task: task,
+		err:  err,
+	}
+}
+
+func (err ErrTaskFailed) Error() string {
+	return fmt.Sprintf("task %v failed: %v", err.task, err.err)
+}
+
+func (err ErrTaskFailed) Code() ErrorCode {
+	return CodeTaskFailed
+}
+
+func (err ErrTaskFailed) Is(target error) bool {
+	return target == CodeTaskFailed
+}
+
+func (err ErrTaskFailed) Unwrap() error {
+	return err.err
+}
+
+func (err ErrTaskFailed) Task() any {
+	return err.task
+}
+
+func (err ErrTaskFailed) TaskID() string {
+	return err.taskID
+}
+
+type ErrQueueFull struct{}
+
+func NewErrQueueFull() ErrQueueFull {
+	return ErrQueueFull{}
+}
+
+func (err ErrQueueFull) Error() string {
+	return "task queue is full"
+}
+
+func (err ErrQueueFull) Code() ErrorCode {
+	return CodeQueueFull
+}
+
+func (err ErrQueueFull) Is(target error) bool {
+	return target == CodeQueueFull
+}
+
+type ErrQuotaExceeded struct {
+	tenant string
+}
+
+func NewErrQuotaExceeded(tenant string) ErrQuotaExceeded {
+	return ErrQuotaExceeded{
+		tenant: tenant,
+	}
+}
+
+func (err ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("tenant %q is over its queue quota", err.tenant)
+}
+
+func (err ErrQuotaExceeded) Code() ErrorCode {
+	return CodeQuotaExceeded
+}
+
+func (err ErrQuotaExceeded) Is(target error) bool {
+	return target == CodeQuotaExceeded
+}
+
+func (err ErrQuotaExceeded) Tenant() string {
+	return err.tenant
+}
+
+type ErrTaskStale struct{}
+
+func NewErrTaskStale() ErrTaskStale {
+	return ErrTaskStale{}
+}
+
+func (err ErrTaskStale) Error() string {
+	return "task went stale before being handled"
+}
+
+func (err ErrTaskStale) Code() ErrorCode {
+	return CodeTaskStale
+}
+
+func (err ErrTaskStale) Is(target error) bool {
+	return target == CodeTaskStale
+}
+
+type ErrNacked struct{}
+
+func NewErrNacked() ErrNacked {
+	return ErrNacked{}
+}
+
+func (err ErrNacked) Error() string {
+	return "delivery was nacked"
+}
+
+func (err ErrNacked) Code() ErrorCode {
+	return CodeNacked
+}
+
+func (err ErrNacked) Is(target error) bool {
+	return target == CodeNacked
+}
+
+type ErrLeaseExpired struct{}
+
+func NewErrLeaseExpired() ErrLeaseExpired {
+	return ErrLeaseExpired{}
+}
+
+func (err ErrLeaseExpired) Error() string {
+	return "delivery lease expired"
+}
+
+func (err ErrLeaseExpired) Code() ErrorCode {
+	return CodeLeaseExpired
+}
+
+func (err ErrLeaseExpired) Is(target error) bool {
+	return target == CodeLeaseExpired
+}
+
+type ErrUnhealthy struct{}
+
+func NewErrUnhealthy() ErrUnhealthy {
+	return ErrUnhealthy{}
+}
+
+func (err ErrUnhealthy) Error() string {
+	return "worker is unhealthy"
+}
+
+func (err ErrUnhealthy) Code() ErrorCode {
+	return CodeUnhealthy
+}
+
+func (err ErrUnhealthy) Is(target error) bool {
+	return target == CodeUnhealthy
+}
+
+type ErrCircuitOpen struct {
+	failures int
+}
+
+func NewErrCircuitOpen(failures int) ErrCircuitOpen {
+	return ErrCircuitOpen{
+		failures: failures,
+	}
+}
+
+func (err ErrCircuitOpen) Error() string {
+	return fmt.Sprintf("circuit breaker tripped after %d consecutive failures", err.failures)
+}
+
+func (err ErrCircuitOpen) Code() ErrorCode {
+	return CodeCircuitOpen
+}
+
+func (err ErrCircuitOpen) Is(target error) bool {
+	return target == CodeCircuitOpen
+}
+
+func (err ErrCircuitOpen) Failures() int {
+	return err.failures
+}
+
+// ErrWorkerNotFound is returned when removing a worker that isn't part of the
+// pool, or any worker from a pool without workers, in which case ID is nil.
+type ErrWorkerNotFound struct {
+	id any
+}
+
+func NewErrWorkerNotFound(id any) ErrWorkerNotFound {
+	return ErrWorkerNotFound{
+		id: id,
+	}
+}
+
+func (err ErrWorkerNotFound) Error() string {
+	if err.id == nil {
+		return "worker not found: the pool has no workers"
+	}
+	return fmt.Sprintf("worker not found: there's no worker with id %v", err.id)
+}
+
+func (err ErrWorkerNotFound) Code() ErrorCode {
+	return CodeWorkerNotFound
+}
+
+func (err ErrWorkerNotFound) ID() any {
+	return err.id
+}
+
+func (err ErrWorkerNotFound) Is(target error) bool {
+	return target == CodeWorkerNotFound
+}
+
+type ErrMinWorkers struct {
+	min int
+}
+
+func NewErrMinWorkers(min int) ErrMinWorkers {
+	return ErrMinWorkers{
+		min: min,
+	}
+}
+
+func (err ErrMinWorkers) Error() string {
+	return fmt.Sprintf("worker minimum reached: the pool can't have fewer than %d workers", err.min)
+}
+
+func (err ErrMinWorkers) Code() ErrorCode {
+	return CodeMinWorkers
+}
+
+func (err ErrMinWorkers) Is(target error) bool {
+	return target == CodeMinWorkers
+}
+
+func (err ErrMinWorkers) Min() int {
+	return err.min
+}
+
+// ErrFactoryFailed wraps the error of the factory failing to create a worker.
+type ErrFactoryFailed struct {
+	id  any
+	err error
+}
+
+func NewErrFactoryFailed(id any, err error) ErrFactoryFailed {
+	return ErrFactoryFailed{
+		id:  id,
+		err: err,
+	}
+}
+
+func (err ErrFactoryFailed) Error() string {
+	return fmt.Sprintf("creating worker %v: %v", err.id, err.err)
+}
+
+func (err ErrFactoryFailed) Code() ErrorCode {
+	return CodeFactoryFailed
+}
+
+func (err ErrFactoryFailed) ID() any {
+	return err.id
+}
+
+func (err ErrFactoryFailed) Unwrap() error {
+	return err.err
+}
+
+func (err ErrFactoryFailed) Is(target error) bool {
+	return target == CodeFactoryFailed
+}
+
+type ErrNoIdGenerator struct{}
+
+func NewErrNoIdGenerator() ErrNoIdGenerator {
+	return ErrNoIdGenerator{}
+}
+
+func (err ErrNoIdGenerator) Error() string {
+	return "no id generator: set one with SetIdGenerator"
+}
+
+func (err ErrNoIdGenerator) Code() ErrorCode {
+	return CodeNoIdGenerator
+}
+
+func (err ErrNoIdGenerator) Is(target error) bool {
+	return target == CodeNoIdGenerator
+}
+
+type ErrPoolExists struct {
+	name string
+}
+
+func NewErrPoolExists(name string) ErrPoolExists {
+	return ErrPoolExists{
+		name: name,
+	}
+}
+
+func (err ErrPoolExists) Error() string {
+	return fmt.Sprintf("pool name conflict: there's already a pool named %q", err.name)
+}
+
+func (err ErrPoolExists) Code() ErrorCode {
+	return CodePoolExists
+}
+
+func (err ErrPoolExists) Is(target error) bool {
+	return target == CodePoolExists
+}
+
+func (err ErrPoolExists) Name() string {
+	return err.name
+}
+
+// ErrPoolShutdown is the error of the pool named Name failing to shut down.
+type ErrPoolShutdown struct {
+	name string
+	err  error
+}
+
+func NewErrPoolShutdown(name string, err error) ErrPoolShutdown {
+	return ErrPoolShutdown{
+		name: name,
+		err:  err,
+	}
+}
+
+func (err ErrPoolShutdown) Error() string {
+	return fmt.Sprintf("shutting down pool %q: %v", err.name, err.err)
+}
+
+func (err ErrPoolShutdown) Code() ErrorCode {
+	return CodePoolShutdown
+}
+
+func (err ErrPoolShutdown) Is(target error) bool {
+	return target == CodePoolShutdown
+}
+
+func (err ErrPoolShutdown) Name() string {
+	return err.name
+}
+
+func (err ErrPoolShutdown) Unwrap() error {
+	return err.err
+}
+
+type ErrTaskStore struct {
+	err error
+}
+
+func NewErrTaskStore(err error) ErrTaskStore {
+	return ErrTaskStore{
+		err: err,
+	}
+}
+
+func (err ErrTaskStore) Error() string {
+	return fmt.Sprintf("task store: %v", err.err)
+}
+
+func (err ErrTaskStore) Code() ErrorCode {
+	return CodeTaskStore
+}
+
+func (err ErrTaskStore) Is(target error) bool {
+	return target == CodeTaskStore
+}
+
+func (err ErrTaskStore) Unwrap() error {
+	return err.err
+}
+
+// ErrConnLost is returned by TaskHandler workers that lost the connection to
+// whatever handles their tasks. The pool queues the task again, without
+// counting the attempt, and replaces the worker.
+type ErrConnLost struct {
+	err error
+}
+
+func NewErrConnLost(err error) ErrConnLost {
+	return ErrConnLost{
+		err: err,
+	}
+}
+
+func (err ErrConnLost) Error() string {
+	return fmt.Sprintf("connection lost: %v", err.err)
+}
+
+func (err ErrConnLost) Code() ErrorCode {
+	return CodeConnLost
+}
+
+func (err ErrConnLost) Is(target error) bool {
+	return target == CodeConnLost
+}
+
+func (err ErrConnLost) Unwrap() error {
+	return err.err
+}
+
+// ErrProcessExited is returned by a ProcessWorker whose child exited, or was
+// killed, while handling a task. Unwrap gives the exit error, if any.
+type ErrProcessExited struct {
+	err error
+}
+
+func NewErrProcessExited(err error) ErrProcessExited {
+	return ErrProcessExited{
+		err: err,
+	}
+}
+
+func (err ErrProcessExited) Error() string {
+	if err.err == nil {
+		return "worker process exited"
+	}
+	return fmt.Sprintf("worker process exited: %v", err.err)
+}
+
+func (err ErrProcessExited) Code() ErrorCode {
+	return CodeProcessExited
+}
+
+func (err ErrProcessExited) Is(target error) bool {
+	return target == CodeProcessExited
+}
+
+func (err ErrProcessExited) Unwrap() error {
+	return err.err
+}
+
+// ErrBatchResults fails the tasks of a batch whose BatchHandler didn't return
+// a result for each of them.
+type ErrBatchResults struct {
+	tasks   int
+	results int
+}
+
+func NewErrBatchResults(tasks int, results int) ErrBatchResults {
+	return ErrBatchResults{
+		tasks:   tasks,
+		results: results,
+	}
+}
+
+func (err ErrBatchResults) Error() string {
+	return fmt.Sprintf("batch of %d tasks returned %d results", err.tasks, err.results)
+}
+
+func (err ErrBatchResults) Code() ErrorCode {
+	return CodeBatchResults
+}
+
+func (err ErrBatchResults) Is(target error) bool {
+	return target == CodeBatchResults
+}
+
+func (err ErrBatchResults) Tasks() int {
+	return err.tasks
+}
+
+func (err ErrBatchResults) Results() int {
+	return err.results
+}
+
+// ErrChunkFailed is the error of the chunk of items from Start to End failed
+// by ProcessChunks.
+type ErrChunkFailed struct {
+	start int
+	end   int
+	err   error
+}
+
+func NewErrChunkFailed(start int, end int, err error) ErrChunkFailed {
+	return ErrChunkFailed{
+		start: start,
+		end:   end,
+		err:   err,
+	}
+}
+
+func (err ErrChunkFailed) Error() string {
+	return fmt.Sprintf("chunk [%d:%d] failed: %v", err.start, err.end, err.err)
+}
+
+func (err ErrChunkFailed) Code() ErrorCode {
+	return CodeChunkFailed
+}
+
+func (err ErrChunkFailed) Is(target error) bool {
+	return target == CodeChunkFailed
+}
+
+func (err ErrChunkFailed) Unwrap() error {
+	return err.err
+}
+
+func (err ErrChunkFailed) Start() int {
+	return err.start
+}
+
+func (err ErrChunkFailed) End() int {
+	return err.end
+}
+
+type ErrGoroutineLeak struct {
+	goroutines map[string]int
+}
+
+func NewErrGoroutineLeak(goroutines map[string]int) ErrGoroutineLeak {
+	return ErrGoroutineLeak{
+		goroutines: goroutines,
+	}
+}
+
+func (err ErrGoroutineLeak) Error() string {
+	names := make([]string, 0, len(err.goroutines))
+	for name, n := range err.goroutines {
+		names = append(names, fmt.Sprintf("%s (%d)", name, n))
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("goroutines still running: %s", strings.Join(names, ", "))
+}
+
+func (err ErrGoroutineLeak) Code() ErrorCode {
+	return CodeGoroutineLeak
+}
+
+func (err ErrGoroutineLeak) Is(target error) bool {
+	return target == CodeGoroutineLeak
+}
+
+// Goroutines tells how many goroutines of each kind were still running.
+func (err ErrGoroutineLeak) Goroutines() map[string]int {
+	return err.goroutines
+}
+
+type ErrPreempted struct {
+	reason PreemptReason
+	err    error
+}
+
+func NewErrPreempted(reason PreemptReason, err error) ErrPreempted {
+	return ErrPreempted{
+		reason: reason,
+		err:    err,
+	}
+}
+
+func (err ErrPreempted) Error() string {
+	if err.err == nil {
+		return fmt.Sprintf("task preempted: %v", err.reason)
+	}
+	return fmt.Sprintf("task preempted: %v: %v", err.reason, err.err)
+}
+
+func (err ErrPreempted) Code() ErrorCode {
+	return CodePreempted
+}
+
+func (err ErrPreempted) Is(target error) bool {
+	return target == CodePreempted
+}
+
+func (err ErrPreempted) Unwrap() error {
+	return err.err
+}
+
+func (err ErrPreempted) Reason() PreemptReason {
+	return err.reason
+}
+
+// ErrNotReady is returned by WaitReady when its context is done before enough
+// workers are ready.
+type ErrNotReady struct {
+	ready    int
+	required int
+	err      error
+}
+
+func NewErrNotReady(ready int, required int, err error) ErrNotReady {
+	return ErrNotReady{
+		ready:    ready,
+		required: required,
+		err:      err,
+	}
+}
+
+func (err ErrNotReady) Error() string {
+	return fmt.Sprintf("%d of the %d workers required are ready: %v", err.ready, err.required, err.err)
+}
+
+func (err ErrNotReady) Code() ErrorCode {
+	return CodeNotReady
+}
+
+func (err ErrNotReady) Is(target error) bool {
+	return target == CodeNotReady
+}
+
+func (err ErrNotReady) Unwrap() error {
+	return err.err
+}
+
+func (err ErrNotReady) Ready() int {
+	return err.ready
+}
+
+func (err ErrNotReady) Required() int {
+	return err.required
+}
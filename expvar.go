@@ -0,0 +1,71 @@
+package gorkpool
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+)
+
+// WithExpvar publishes the pool under name in expvar, so it shows up in
+// /debug/vars. A pool published under the name of an earlier one takes its
+// place.
+func WithExpvar(name string) Option {
+	return func(c *config) {
+		c.expvarName = name
+	}
+}
+
+// ExpvarStats is what the pool publishes in expvar. Completed and Failed
+// count the tasks handled by TaskHandler workers, each attempt on its own.
+type ExpvarStats struct {
+	Workers   int `json:"workers"`
+	Queued    int `json:"queued"`
+	InFlight  int `json:"in_flight"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+type expvarPool struct {
+	mutex sync.Mutex
+	stats func() ExpvarStats
+}
+
+func (v *expvarPool) String() string {
+	v.mutex.Lock()
+	stats := v.stats
+	v.mutex.Unlock()
+	b, _ := json.Marshal(stats())
+	return string(b)
+}
+
+// expvarMutex keeps pools published under the same name from racing.
+var expvarMutex sync.Mutex
+
+func (p *GorkPool[Id, Task, Result]) publish(name string) {
+	expvarMutex.Lock()
+	defer expvarMutex.Unlock()
+	switch v := expvar.Get(name).(type) {
+	case nil:
+		expvar.Publish(name, &expvarPool{stats: p.expvarStats})
+	case *expvarPool:
+		v.mutex.Lock()
+		v.stats = p.expvarStats
+		v.mutex.Unlock()
+	default:
+		p.logf("gorkpool: expvar %q is already taken", name)
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) expvarStats() ExpvarStats {
+	queued := p.QueueLen()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return ExpvarStats{
+		Workers:   p.workers.len(),
+		Queued:    queued,
+		InFlight:  p.inFlight,
+		Completed: p.completed,
+		Failed:    p.failed,
+	}
+}
@@ -0,0 +1,40 @@
+package gorkpool_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithExpvar(t *testing.T) {
+	// Setup
+	failure := errors.New("failure")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 2, func(ctx context.Context, x int) (int, error) {
+		if x < 0 {
+			return 0, failure
+		}
+		return -x, nil
+	}, gorkpool.WithExpvar("gorkpool_test"))
+	// Action
+	for _, x := range []int{1, 2, 3, -1} {
+		f, _ := pool.Submit(x)
+		f.Wait(context.Background())
+	}
+	var stats gorkpool.ExpvarStats
+	err := json.Unmarshal([]byte(expvar.Get("gorkpool_test").String()), &stats)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected published stats to be JSON, got %v", err)
+	}
+	if stats.Workers != 2 || stats.Completed != 3 || stats.Failed != 1 {
+		t.Errorf("expected 2 workers, 3 completed and 1 failed task, got %+v", stats)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
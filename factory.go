@@ -0,0 +1,10 @@
+package gorkpool
+
+// SetWorkerFactory makes the workers added from now on, including those
+// replaced with ReplaceWorker or recreated by Restart, come from fn. The
+// workers already in the pool are left alone, ReplaceWorker rolls them.
+func (p *GorkPool[Id, Task, Result]) SetWorkerFactory(fn WorkerFactoryFn[Id, Task, Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.createWorkerFn = fn
+}
@@ -0,0 +1,37 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSetWorkerFactory(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.SetWorkerFactory(func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return x * 10, ctx.Err()
+		}}, nil
+	})
+	before, _ := pool.Submit(1)
+	got, _ := before.Wait(context.Background())
+	err := pool.ReplaceWorker(0)
+	after, _ := pool.Submit(1)
+	rolled, _ := after.Wait(context.Background())
+	// Assert
+	if got != -1 {
+		t.Errorf("expected the existing worker to be left alone, got %d", got)
+	}
+	if err != nil || rolled != 10 {
+		t.Errorf("expected the rolled worker to come from the new factory, got %d (%v)", rolled, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
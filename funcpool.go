@@ -0,0 +1,64 @@
+package gorkpool
+
+import "context"
+
+// HandlerFunc lets a plain function be used as a TaskHandler.
+type HandlerFunc[Task any, Result any] func(ctx context.Context, task Task) (Result, error)
+
+func (fn HandlerFunc[Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	return fn(ctx, task)
+}
+
+type funcWorker[Task any, Result any] struct {
+	HandlerFunc[Task, Result]
+	id int
+}
+
+func (w *funcWorker[Task, Result]) ID() int {
+	return w.id
+}
+
+func (w *funcWorker[Task, Result]) Process() {}
+
+func (w *funcWorker[Task, Result]) SignalRemoval() {}
+
+// NewFuncPool creates a pool of n workers running fn for every task, with
+// results sent to OutputCh. Workers added later run fn as well.
+func NewFuncPool[Task any, Result any](ctx context.Context, n int, fn func(Task) Result, opts ...Option) *GorkPool[int, Task, Result] {
+	return NewHandlerFuncPool(ctx, n, func(ctx context.Context, task Task) (Result, error) {
+		return fn(task), nil
+	}, opts...)
+}
+
+// NewHandlerFuncPool is NewFuncPool for functions that can fail. Successful
+// results go to OutputCh while errors go through the retry policy, the dead
+// letter handler and ErrorCh like those of any TaskHandler.
+func NewHandlerFuncPool[Task any, Result any](ctx context.Context, n int, fn HandlerFunc[Task, Result], opts ...Option) *GorkPool[int, Task, Result] {
+	pool := NewGorkPoolWithOptions(ctx, func(id int, inputCh chan Task, outputCh chan Result) (GorkWorker[int, Task, Result], error) {
+		return &funcWorker[Task, Result]{HandlerFunc: fn, id: id}, nil
+	}, opts...)
+
+	for i := 0; i < n; i++ {
+		pool.AddWorker(i)
+	}
+
+	return pool
+}
+
+// Map runs fn over items with n workers, returning the results in the order of
+// items along with the first error in that order. n is taken as at least 1 and
+// at most len(items). The pool it uses is only around for the call.
+func Map[Task any, Result any](ctx context.Context, items []Task, n int, fn func(Task) (Result, error)) ([]Result, error) {
+	if n > len(items) {
+		n = len(items)
+	}
+	if n < 1 {
+		n = 1
+	}
+	pool := NewHandlerFuncPool(ctx, n, func(ctx context.Context, task Task) (Result, error) {
+		return fn(task)
+	}, WithInputBufferSize(n))
+	defer pool.Shutdown(context.Background())
+
+	return pool.SubmitAllWait(ctx, items)
+}
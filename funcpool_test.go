@@ -0,0 +1,121 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestNewFuncPool(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 3, func(x int) int { return x * x })
+	// Action
+	go func() {
+		for i := 1; i <= 3; i++ {
+			pool.AddTask(i)
+		}
+	}()
+	// Assert
+	if pool.Length() != 3 {
+		t.Errorf("expected %d workers, got %d", 3, pool.Length())
+	}
+	got := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		got = append(got, <-pool.OutputCh())
+	}
+	sort.Ints(got)
+	for i, want := range []int{1, 4, 9} {
+		if got[i] != want {
+			t.Errorf("expected results %v, got %v", []int{1, 4, 9}, got)
+			break
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestNewHandlerFuncPool(t *testing.T) {
+	// Setup
+	failure := errors.New("negative")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 2, func(ctx context.Context, x int) (int, error) {
+		if x < 0 {
+			return 0, failure
+		}
+		return x * x, nil
+	})
+	// Action
+	got, err := pool.SubmitWait(context.Background(), 3)
+	_, failed := pool.SubmitWait(context.Background(), -1)
+	// Assert
+	if err != nil || got != 9 {
+		t.Errorf("expected result %d, got %d, %v", 9, got, err)
+	}
+	if !errors.Is(failed, failure) {
+		t.Errorf("expected error %v, got %v", failure, failed)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestMap(t *testing.T) {
+	// Setup
+	items := []int{5, 1, 4, 2, 3}
+	// Action
+	results, err := gorkpool.Map(context.Background(), items, 3, func(x int) (string, error) {
+		time.Sleep(time.Duration(x) * time.Millisecond)
+		return strconv.Itoa(x * 10), nil
+	})
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for i, x := range items {
+		if results[i] != strconv.Itoa(x*10) {
+			t.Errorf("expected results in input order, got %v", results)
+			break
+		}
+	}
+}
+
+func TestMapWorkers(t *testing.T) {
+	for _, n := range []int{-1, 0, 100} {
+		// Setup
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		// Action
+		results, err := gorkpool.Map(ctx, []int{1, 2, 3}, n, func(x int) (int, error) {
+			return x * 2, nil
+		})
+		cancel()
+		// Assert
+		if err != nil || !reflect.DeepEqual(results, []int{2, 4, 6}) {
+			t.Errorf("expected Map with %d workers to run, got %v and %v", n, results, err)
+		}
+	}
+}
+
+func TestMapError(t *testing.T) {
+	// Setup
+	failure := errors.New("three")
+	// Action
+	_, err := gorkpool.Map(context.Background(), []int{1, 2, 3, 4}, 2, func(x int) (int, error) {
+		if x >= 3 {
+			return 0, fmt.Errorf("%d: %w", x, failure)
+		}
+		return x, nil
+	})
+	// Assert
+	if err == nil || err.Error() != "3: three" {
+		t.Errorf("expected the first error in input order, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package gorkpool
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the result of a task submitted with Submit.
+type Future[Result any] struct {
+	once   sync.Once
+	done   chan struct{}
+	result Result
+	err    error
+}
+
+func newFuture[Result any]() *Future[Result] {
+	return &Future[Result]{
+		done: make(chan struct{}),
+	}
+}
+
+// Done is closed once the result is available.
+func (f *Future[Result]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the task's result is available or ctx is done.
+func (f *Future[Result]) Wait(ctx context.Context) (Result, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		var zero Result
+		return zero, ctx.Err()
+	}
+}
+
+func (f *Future[Result]) resolve(result Result, err error) {
+	f.once.Do(func() {
+		f.result = result
+		f.err = err
+		close(f.done)
+	})
+}
+
+// Submit queues task like AddTask, but its result is delivered to the returned
+// Future instead of the output channel. Only TaskHandler workers take
+// submitted tasks.
+func (p *GorkPool[Id, Task, Result]) Submit(task Task, opts ...TaskOption) (*Future[Result], error) {
+	env, err := p.submit(context.Background(), task, opts)
+	if err != nil {
+		return nil, err
+	}
+	return env.future, nil
+}
+
+// SubmitWait submits task and waits for its result. If ctx is done first the
+// task is cancelled.
+func (p *GorkPool[Id, Task, Result]) SubmitWait(ctx context.Context, task Task, opts ...TaskOption) (Result, error) {
+	env, err := p.submit(ctx, task, opts)
+	if err != nil {
+		var zero Result
+		return zero, err
+	}
+
+	result, err := env.future.Wait(ctx)
+	if ctx.Err() != nil && p.leave(env) {
+		p.cancelEnvelope(env)
+	}
+	return result, err
+}
+
+func (p *GorkPool[Id, Task, Result]) submit(ctx context.Context, task Task, opts []TaskOption) (*envelope[Task, Result], error) {
+	env := p.newEnvelope(task, opts)
+	env.future = newFuture[Result]()
+	if p.cached(env) {
+		return env, nil
+	}
+	if env.dedupKey != "" {
+		if first, ok := p.coalesce(env); ok {
+			return first, nil
+		}
+	}
+	if err := p.pushCtx(ctx, env); err != nil {
+		// Let the submissions coalesced into env know too
+		p.forgetDedup(env)
+		var zero Result
+		env.future.resolve(zero, err)
+		return nil, err
+	}
+	if p.cfg.hedgeDelay > 0 {
+		p.launch("hedge", func() {
+			p.hedge(env)
+		})
+	}
+	return env, nil
+}
+
+// settle delivers the outcome of env to whoever is waiting for it.
+func (p *GorkPool[Id, Task, Result]) settle(env *envelope[Task, Result], result Result, err error) {
+	defer p.progressed()
+	if env.future != nil {
+		if err == nil && env.cacheKey != "" {
+			p.cache.Set(env.cacheKey, result, p.cfg.cacheTTL)
+		}
+		env.future.resolve(result, err)
+		return
+	}
+	if err != nil && p.errorCh != nil && !p.isKilled() {
+		p.errorCh <- p.taskFailed(env, err)
+	}
+
+	ok := err == nil && !p.isKilled()
+	if ok {
+		result, ok = p.intercept(result)
+	}
+	if env.order > 0 {
+		p.order.put(env.order, result, ok, p.output)
+		return
+	}
+	if ok {
+		p.output(result)
+	}
+}
+
+// abandon settles env with ErrPoolClosed when shutdown leaves it undelivered.
+func (p *GorkPool[Id, Task, Result]) abandon(env *envelope[Task, Result]) {
+	p.mutex.Lock()
+	p.report.Dropped++
+	p.mutex.Unlock()
+	p.keep(env)
+	p.finish(env)
+	var zero Result
+	p.settle(env, zero, NewErrPoolClosed())
+}
+
+// taskFailed is the ErrTaskFailed of env failing with err.
+func (p *GorkPool[Id, Task, Result]) taskFailed(env *envelope[Task, Result], err error) ErrTaskFailed {
+	failed := NewErrTaskFailed(env.task, err)
+	failed.taskID = env.id
+	return failed
+}
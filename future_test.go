@@ -0,0 +1,116 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSubmit(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	for i := 0; i < 3; i++ {
+		pool.AddWorker(i)
+	}
+	// Action
+	futures := make([]*gorkpool.Future[int], 10)
+	for i := range futures {
+		f, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("expected submit to succeed, got %v", err)
+		}
+		futures[i] = f
+	}
+	// Assert
+	for i, f := range futures {
+		got, err := f.Wait(context.Background())
+		if err != nil || got != -i {
+			t.Errorf("expected future %d to yield %d, got %d (%v)", i, -i, got, err)
+		}
+	}
+	if len(pool.OutputCh()) != 0 {
+		t.Errorf("expected submitted results to bypass the output channel, got %d results", len(pool.OutputCh()))
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitFailure(t *testing.T) {
+	// Setup
+	failure := errors.New("broken")
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return 0, failure
+	})
+	pool.AddWorker(0)
+	// Action
+	f, _ := pool.Submit(1)
+	_, err := f.Wait(context.Background())
+	// Assert
+	if !errors.Is(err, failure) {
+		t.Errorf("expected future error to be %v, got %v", failure, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitWaitContext(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	f, _ := pool.Submit(1)
+	ctx, cancelWait := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelWait()
+	// Action
+	_, err := f.Wait(ctx)
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected wait to give up with %v, got %v", context.DeadlineExceeded, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+	_, err = f.Wait(context.Background())
+	if !errors.Is(err, gorkpool.NewErrPoolClosed()) {
+		t.Errorf("expected undelivered task to fail with %v, got %v", gorkpool.NewErrPoolClosed(), err)
+	}
+}
+
+func TestSubmitWait(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if x < 0 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	got, err := pool.SubmitWait(context.Background(), 1)
+	// Assert
+	if err != nil || got != -1 {
+		t.Errorf("expected %d, got %d (%v)", -1, got, err)
+	}
+	// Action
+	ctx, cancelWait := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelWait()
+	_, err = pool.SubmitWait(ctx, -1)
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if got, err := pool.SubmitWait(context.Background(), 2); err != nil || got != -2 {
+		t.Errorf("expected timed out task to be cancelled and free the worker, got %d (%v)", got, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
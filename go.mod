@@ -2,4 +2,7 @@ module github.com/joaovictorsl/gorkpool
 
 go 1.20
 
-require golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842
+require (
+	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842
+	golang.org/x/time v0.5.0
+)
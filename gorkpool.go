@@ -2,26 +2,162 @@ package gorkpool
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type GorkPool[Id comparable, Task any, Result any] struct {
-	mutex          *sync.Mutex
-	workers        map[Id]GorkWorker[Id, Task, Result]
+	mutex *sync.RWMutex
+	// workers are added and removed by id holding mutex for reading only,
+	// see registry
+	workers *registry[Id, Task, Result]
+	// removals are the removed workers waiting to be signalled, removing
+	// telling whether the goroutine signalling them runs, both guarded by
+	// removalMutex
+	removalMutex   *sync.Mutex
+	removals       []*workerState[Id, Task, Result]
+	removing       bool
 	createWorkerFn WorkerFactoryFn[Id, Task, Result]
+	cfg            config
+	channelWorkers atomic.Int64
+	handlerWorkers atomic.Int64
+	workerSeq      atomic.Uint64
+	inFlight       int
+	inFlightWeight int
+	lastProgress   time.Time
+	nextID         func() Id
+	idGenerator    IdGenerator[Id]
+	latency        *latencyScaler[Id]
+	dispatcher     Dispatcher[Id, Task]
+	queueWait      histogram
+	processing     histogram
+	completed      int
+	failed         int
+	turn           uint64
+	turns          int
+	state          State
+	err            error
+	errs           []error
 
-	wg       *sync.WaitGroup
+	wg      *sync.WaitGroup
+	sources *sync.WaitGroup
+	// sends are the SubmitTo sends to the input channels of workers, which
+	// are only closed once they are done
+	sends    *sync.WaitGroup
 	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	done     chan struct{}
 	inputCh  chan Task
 	outputCh chan Result
+	errorCh  chan error
+	taskCh   chan *envelope[Task, Result]
+	queue    *taskQueue[Task, Result]
+	wake     chan struct{}
+	// resumed is closed by Resume, nil unless paused
+	resumed chan struct{}
+	// stealable is signalled when tasks are waiting in worker queues
+	stealable chan struct{}
+	// readiness is closed and replaced whenever workers get ready or leave
+	// while WaitReady is waiting, guarded by readyMutex. readyCount counts
+	// the ready workers.
+	readyMutex   *sync.Mutex
+	readiness    chan struct{}
+	readyCount   atomic.Int64
+	readyWaiters atomic.Int32
+
+	abort     chan struct{}
+	abortOnce *sync.Once
+	kill      chan struct{}
+	killOnce  *sync.Once
+	discarded chan struct{}
+	// sourcesStop is closed once the pool stops reading its sources
+	sourcesStop chan struct{}
+	leftovers   []Task
+	// undelivered are the tasks abandoned on shutdown, saved to store
+	store       TaskStore[Task]
+	undelivered []Task
+	// cache is the ResultCache set with WithResultCache, if any
+	cache ResultCache[Result]
+	// goroutines are those the pool started, see VerifyClean
+	goroutines *goroutines
+
+	tasks map[string]*envelope[Task, Result]
+	// parked are the tasks no worker takes. Workers joining unpark them
+	// holding mutex for reading only, along with parkMutex.
+	parked     []*envelope[Task, Result]
+	parkMutex  *sync.Mutex
+	order      *reorderer[Result]
+	schedules  map[*Schedule]struct{}
+	retry      RetryPolicy
+	deadLetter func(DeadLetter[Task])
+	// panicReporter is set with SetPanicReporter
+	panicReporter atomic.Pointer[func(Id, any, any, []byte)]
+	// held are the tasks waiting for their concurrency key, by key
+	held        map[string][]*envelope[Task, Result]
+	keyInFlight map[string]int
+	// keyOwners are the workers still holding tasks of a key, by key
+	keyOwners map[string]*keyOwner[Id, Task, Result]
+	dedup     map[string]*envelope[Task, Result]
+
+	interceptors []ResultInterceptor[Result]
+	routes       []outputRoute[Result]
+	startHooks   []TaskHook[Id, Task, Result]
+	endHooks     []TaskHook[Id, Task, Result]
+	// report is the Report of the last shutdown, final once reported
+	report   Report
+	reported bool
+	// Lifecycle hooks, see OnShutdownStart
+	shutdownStart    []func()
+	shutdownComplete []func(error)
+	workerStopped    []func(Id)
+	middleware       []Middleware[Task, Result]
+	watermarks       *watermarks
+	broadcast        *broadcast[Result]
+	// envelopes are the free envelopes, see alloc and recycle
+	envelopes *sync.Pool
+	// recent are the tasks kept with WithRecentTasks, nil without it
+	recent *recentTasks[Id, Task, Result]
 }
 
 type GorkWorker[Id comparable, Task any, Result any] interface {
 	ID() Id
 	Process()
+	// SignalRemoval tells the worker to return from Process. The pool calls
+	// it once at most, and never after Process returned, but a worker able to
+	// return on its own meanwhile shouldn't have it block on Process taking
+	// the signal, or use Runner instead.
 	SignalRemoval()
 }
 
+// TaskHandler is an optional interface for workers that let the pool drive
+// their processing. The pool calls Handle for every task the worker takes
+// instead of Process, and cancels ctx instead of calling SignalRemoval. ctx
+// belongs to the task and must not be used once Handle returned, the pool
+// reusing it for later tasks.
+type TaskHandler[Task any, Result any] interface {
+	Handle(ctx context.Context, task Task) (Result, error)
+}
+
+// Runner is an optional interface for workers that take a context instead of
+// handling SignalRemoval themselves. The pool calls Run instead of Process and
+// cancels ctx to remove the worker. Run should return once ctx is done or the
+// input channel is closed, and errors it returns are reported to the handler
+// set with WithErrorHandler.
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// Lifecycle is an optional interface for workers holding resources. Start is
+// called by AddWorker before the worker gets any task, failing AddWorker if it
+// errors, and Stop once the worker is done, whether it was removed or the pool
+// stopped.
+type Lifecycle interface {
+	Start() error
+	Stop(ctx context.Context) error
+}
+
 type WorkerFactoryFn[Id comparable, Task any, Result any] func(Id, chan Task, chan Result) (GorkWorker[Id, Task, Result], error)
 
 func NewGorkPool[Id comparable, Task any, Result any](
@@ -29,105 +165,797 @@ func NewGorkPool[Id comparable, Task any, Result any](
 	inputCh chan Task,
 	outputCh chan Result,
 	createWorkerFn WorkerFactoryFn[Id, Task, Result],
+	opts ...Option,
+) *GorkPool[Id, Task, Result] {
+	return newPool(ctx, inputCh, outputCh, createWorkerFn, newConfig(opts))
+}
+
+func newPool[Id comparable, Task any, Result any](
+	ctx context.Context,
+	inputCh chan Task,
+	outputCh chan Result,
+	createWorkerFn WorkerFactoryFn[Id, Task, Result],
+	cfg config,
 ) *GorkPool[Id, Task, Result] {
 	pool := &GorkPool[Id, Task, Result]{
-		mutex:          &sync.Mutex{},
-		workers:        make(map[Id]GorkWorker[Id, Task, Result], 0),
+		mutex:          &sync.RWMutex{},
+		workers:        &registry[Id, Task, Result]{},
+		removalMutex:   &sync.Mutex{},
+		parkMutex:      &sync.Mutex{},
 		createWorkerFn: createWorkerFn,
+		cfg:            cfg,
 		wg:             &sync.WaitGroup{},
-		ctx:            ctx,
-		inputCh:        inputCh,
-		outputCh:       outputCh,
+		sources:        &sync.WaitGroup{},
+		sends:          &sync.WaitGroup{},
+		wake:           make(chan struct{}, 1),
+		stealable:      make(chan struct{}, 1),
+		readyMutex:     &sync.Mutex{},
+		readiness:      make(chan struct{}),
+		watermarks:     &watermarks{mutex: &sync.Mutex{}},
+		goroutines:     newGoroutines(),
+		envelopes: &sync.Pool{New: func() any {
+			return &envelope[Task, Result]{}
+		}},
 	}
 
-	go pool.gracefullyShutdown()
+	if cfg.recentTasks > 0 {
+		pool.keepRecent(cfg.recentTasks)
+	}
+	if cfg.resultCache != nil {
+		pool.useCache()
+	}
+	pool.start(ctx, inputCh, outputCh)
+	if pool.cfg.expvarName != "" {
+		pool.publish(pool.cfg.expvarName)
+	}
 
 	return pool
 }
 
+// NewGorkPoolWithOptions is NewGorkPool with channels created by the pool
+// itself, sized with WithInputBufferSize and WithOutputBufferSize. They are
+// handed to createWorkerFn as usual.
+func NewGorkPoolWithOptions[Id comparable, Task any, Result any](
+	ctx context.Context,
+	createWorkerFn WorkerFactoryFn[Id, Task, Result],
+	opts ...Option,
+) *GorkPool[Id, Task, Result] {
+	cfg := newConfig(opts)
+	inputCh := make(chan Task, cfg.inputBufferSize)
+	outputCh := make(chan Result, cfg.outputBufferSize)
+	return NewGorkPool(ctx, inputCh, outputCh, createWorkerFn, opts...)
+}
+
+// start sets up everything a single run of the pool needs and starts it.
+func (p *GorkPool[Id, Task, Result]) start(ctx context.Context, inputCh chan Task, outputCh chan Result) {
+	p.ctx, p.cancel = context.WithCancelCause(ctx)
+	p.done = make(chan struct{})
+	p.inputCh = inputCh
+	p.outputCh = outputCh
+	p.errorCh = nil
+	if p.cfg.errorChannel {
+		p.errorCh = make(chan error, p.cfg.errorBufferSize)
+	}
+	p.taskCh = make(chan *envelope[Task, Result])
+	queueSize := p.cfg.queueSize
+	if queueSize <= 0 {
+		queueSize = cap(inputCh)
+	}
+	if p.cfg.adaptiveQueue != nil {
+		queueSize = p.cfg.adaptiveQueue.clamp(queueSize)
+	}
+	p.queue = newTaskQueue[Task, Result](queueSize)
+	p.queue.onChange = p.watermarks.update
+	p.queue.clock = p.cfg.clock
+	p.queue.weights, p.queue.quotas = p.cfg.tenantWeights, p.cfg.tenantQuotas
+
+	p.abort = make(chan struct{})
+	p.abortOnce = &sync.Once{}
+	p.kill = make(chan struct{})
+	p.killOnce = &sync.Once{}
+	p.discarded = make(chan struct{})
+	p.sourcesStop = make(chan struct{})
+	p.leftovers = nil
+	p.undelivered = nil
+
+	p.tasks = make(map[string]*envelope[Task, Result])
+	p.parked = nil
+	p.held = make(map[string][]*envelope[Task, Result])
+	p.keyInFlight = make(map[string]int)
+	p.keyOwners = make(map[string]*keyOwner[Id, Task, Result])
+	p.dedup = make(map[string]*envelope[Task, Result])
+	p.order = nil
+	p.broadcast = nil
+	if p.cfg.orderedResults {
+		p.order = newReorderer[Result]()
+	}
+	p.schedules = make(map[*Schedule]struct{})
+	p.lastProgress = p.cfg.clock.Now()
+	p.queueWait, p.processing = histogram{}, histogram{}
+	p.errs = nil
+	p.report, p.reported = Report{}, false
+	if p.cfg.taskStore != nil {
+		p.resume()
+	}
+
+	p.launch("run", p.run)
+	if p.cfg.healthInterval > 0 {
+		p.launch("health checks", p.checkHealth)
+	}
+	if p.cfg.onStall != nil {
+		p.launch("stall detection", p.detectStalls)
+	}
+	if p.cfg.idleTimeout > 0 {
+		p.launch("idle reaping", p.reapIdle)
+	}
+	if p.cfg.adaptiveQueue != nil {
+		p.launch("queue sizing", p.adaptQueue)
+	}
+	if p.latency != nil {
+		ctx := p.ctx
+		p.launch("latency scaling", func() {
+			p.scaleForLatency(ctx)
+		})
+	}
+}
+
 func (p *GorkPool[Id, Task, Result]) AddWorker(id Id) error {
-	w, err := p.createWorkerFn(id, p.inputCh, p.outputCh)
+	ws, err := p.create(id)
 	if err != nil {
 		return err
 	}
 
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	if _, ok := p.workers[w.ID()]; ok {
-		return NewErrIdConflict(w.ID())
+	p.mutex.RLock()
+	err = p.register(ws)
+	p.mutex.RUnlock()
+	if err != nil {
+		ws.discard()
+	}
+	return err
+}
+
+// create makes a worker with id through the factory and starts it. All the
+// work of setting it up happens here, outside of p.mutex, so that adding and
+// removing workers only holds it for the bookkeeping.
+func (p *GorkPool[Id, Task, Result]) create(id Id) (*workerState[Id, Task, Result], error) {
+	inputCh := p.inputCh
+	if p.perWorkerQueues() {
+		inputCh = make(chan Task, p.workerQueueSize())
+	}
+	p.mutex.RLock()
+	createWorkerFn := p.createWorkerFn
+	p.mutex.RUnlock()
+	w, err := createWorkerFn(id, inputCh, p.outputCh)
+	if err != nil {
+		return nil, NewErrFactoryFailed(id, err)
+	}
+	if l, ok := w.(Lifecycle); ok {
+		if err := l.Start(); err != nil {
+			return nil, err
+		}
 	}
 
-	p.wg.Add(1)
-	p.workers[w.ID()] = w
-	go func(w GorkWorker[Id, Task, Result]) {
-		w.Process()
-		p.wg.Done()
-	}(w)
+	ws := p.newWorkerState(w)
+	if p.perWorkerQueues() {
+		if ws.handler != nil {
+			ws.queue = make(chan *envelope[Task, Result], p.workerQueueSize()*ws.capacity)
+		} else {
+			ws.inputCh = inputCh
+		}
+	}
+	return ws, nil
+}
 
+// discard stops a worker made by create that didn't make it into the pool.
+func (ws *workerState[Id, Task, Result]) discard() {
+	if l, ok := ws.worker.(Lifecycle); ok {
+		l.Stop(context.Background())
+	}
+	if ws.cancel != nil {
+		ws.cancel()
+	}
+}
+
+// register adds ws to the workers and runs it, failing with ErrPoolClosed,
+// ErrIdConflict or ErrMaxWorkers if it can't join. The caller must hold
+// p.mutex, for reading at least.
+func (p *GorkPool[Id, Task, Result]) register(ws *workerState[Id, Task, Result]) error {
+	if !p.running() {
+		return NewErrPoolClosed()
+	}
+	w := ws.worker
+	ws.seq, ws.idleSince = p.workerSeq.Add(1), p.cfg.clock.Now()
+	ws.ready = !p.checksHealth(ws)
+	err := p.workers.add(ws, p.cfg.maxWorkers, func() {
+		p.wg.Add(1)
+		if ws.handler != nil {
+			p.handlerWorkers.Add(1)
+		} else {
+			p.channelWorkers.Add(1)
+		}
+		if ws.ready {
+			p.readyCount.Add(1)
+		}
+		p.launch("worker", func() {
+			p.runWorker(w.ID(), ws)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	p.prepare(ws)
+	p.parkMutex.Lock()
+	p.unpark(ws)
+	p.parkMutex.Unlock()
+	p.notifyWorkersChanged()
 	return nil
 }
 
+// RemoveWorker removes a worker picked by the removal policy, returning nil if
+// none could be removed. TryRemoveWorker tells why. The worker leaves the
+// pool right away and is signalled to stop in the background, RemoveWorkerWait
+// waiting for it to be done. Once the pool is shutting down workers can't be
+// removed anymore, failing with ErrPoolClosed, while those removed before are
+// waited for by the shutdown like the others.
 func (p *GorkPool[Id, Task, Result]) RemoveWorker() GorkWorker[Id, Task, Result] {
+	w, _ := p.TryRemoveWorker()
+	return w
+}
+
+// RemoveWorkerById removes the worker with id, returning nil if it couldn't.
+// TryRemoveWorkerById tells why.
+func (p *GorkPool[Id, Task, Result]) RemoveWorkerById(id Id) GorkWorker[Id, Task, Result] {
+	w, _ := p.TryRemoveWorkerById(id)
+	return w
+}
+
+// TryRemoveWorker is RemoveWorker returning ErrPoolClosed, ErrMinWorkers or
+// ErrWorkerNotFound if no worker could be removed.
+func (p *GorkPool[Id, Task, Result]) TryRemoveWorker() (GorkWorker[Id, Task, Result], error) {
+	ws, err := p.removeAny()
+	if err != nil {
+		return nil, err
+	}
+	return ws.worker, nil
+}
+
+// TryRemoveWorkerById is RemoveWorkerById returning ErrPoolClosed,
+// ErrMinWorkers or ErrWorkerNotFound if the worker couldn't be removed.
+func (p *GorkPool[Id, Task, Result]) TryRemoveWorkerById(id Id) (GorkWorker[Id, Task, Result], error) {
+	ws, err := p.removeById(id)
+	if err != nil {
+		return nil, err
+	}
+	return ws.worker, nil
+}
+
+// RemoveWorkerWait is RemoveWorker waiting for the worker to be done. If ctx
+// is done first it returns the worker along with an ErrRemovalTimeout. If no
+// worker could be removed it returns ErrPoolClosed, ErrMinWorkers or
+// ErrWorkerNotFound.
+func (p *GorkPool[Id, Task, Result]) RemoveWorkerWait(ctx context.Context) (GorkWorker[Id, Task, Result], error) {
+	ws, err := p.removeAny()
+	if err != nil {
+		return nil, err
+	}
+	return p.awaitRemoval(ctx, ws)
+}
+
+// RemoveWorkerByIdWait is RemoveWorkerById waiting for the worker to be done.
+// If ctx is done first it returns the worker along with an ErrRemovalTimeout.
+// If the worker couldn't be removed it returns ErrPoolClosed, ErrMinWorkers or
+// ErrWorkerNotFound.
+func (p *GorkPool[Id, Task, Result]) RemoveWorkerByIdWait(ctx context.Context, id Id) (GorkWorker[Id, Task, Result], error) {
+	ws, err := p.removeById(id)
+	if err != nil {
+		return nil, err
+	}
+	return p.awaitRemoval(ctx, ws)
+}
+
+// removable tells whether a worker can leave the pool. The caller must hold
+// p.mutex.
+func (p *GorkPool[Id, Task, Result]) removable() error {
+	if p.state != StateRunning {
+		return NewErrPoolClosed()
+	}
+	if n := p.workers.len(); n > 0 && n <= p.cfg.minWorkers {
+		return NewErrMinWorkers(p.cfg.minWorkers)
+	}
+	return nil
+}
+
+func (p *GorkPool[Id, Task, Result]) removeAny() (*workerState[Id, Task, Result], error) {
 	p.mutex.Lock()
+	if err := p.removable(); err != nil {
+		p.mutex.Unlock()
+		return nil, err
+	}
 
-	// Removes the first one on the iteration
-	var target GorkWorker[Id, Task, Result]
-	for id, w := range p.workers {
-		target = w
-		delete(p.workers, id)
-		break
+	id, target := p.pickRemoval()
+	if target != nil {
+		p.unregister(id, target)
 	}
 	p.mutex.Unlock()
 
 	// If no one was removed
 	if target == nil {
-		return nil
+		return nil, NewErrWorkerNotFound(nil)
 	}
 
-	target.SignalRemoval()
-	return target
+	p.signalRemoval(target)
+	return target, nil
 }
 
-func (p *GorkPool[Id, Task, Result]) RemoveWorkerById(id Id) GorkWorker[Id, Task, Result] {
-	p.mutex.Lock()
-	target, ok := p.workers[id]
-	if !ok {
-		p.mutex.Unlock()
-		return nil
+func (p *GorkPool[Id, Task, Result]) removeById(id Id) (*workerState[Id, Task, Result], error) {
+	p.mutex.RLock()
+	if p.state != StateRunning {
+		p.mutex.RUnlock()
+		return nil, NewErrPoolClosed()
+	}
+	target, err := p.workers.take(id, p.cfg.minWorkers)
+	if err != nil {
+		p.mutex.RUnlock()
+		return nil, err
 	}
+	p.departed(target)
+	p.mutex.RUnlock()
 
-	delete(p.workers, id)
-	p.mutex.Unlock()
+	p.signalRemoval(target)
+	return target, nil
+}
+
+func (p *GorkPool[Id, Task, Result]) awaitRemoval(ctx context.Context, ws *workerState[Id, Task, Result]) (GorkWorker[Id, Task, Result], error) {
+	if ws == nil {
+		return nil, nil
+	}
+
+	select {
+	case <-ws.done:
+		return ws.worker, nil
+	case <-ctx.Done():
+		return ws.worker, NewErrRemovalTimeout(ws.worker.ID(), ctx.Err())
+	}
+}
+
+// unregister removes ws from the workers. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) unregister(id Id, ws *workerState[Id, Task, Result]) {
+	if p.workers.remove(id, ws) {
+		p.departed(ws)
+	}
+}
 
-	target.SignalRemoval()
-	return target
+// departed does the bookkeeping for ws, just taken out of the workers. The
+// caller must hold p.mutex, for reading at least.
+func (p *GorkPool[Id, Task, Result]) departed(ws *workerState[Id, Task, Result]) {
+	p.workers.leave(ws)
+	if ws.handler != nil {
+		p.handlerWorkers.Add(-1)
+	} else {
+		p.channelWorkers.Add(-1)
+	}
+	if ws.ready {
+		p.readyCount.Add(-1)
+		p.readinessChanged()
+	}
+	p.notifyWorkersChanged()
 }
 
 func (p *GorkPool[Id, Task, Result]) Length() int {
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	return len(p.workers)
+	return p.workers.len()
 }
 
 func (p *GorkPool[Id, Task, Result]) Contains(id Id) bool {
+	_, ok := p.workers.get(id)
+	return ok
+}
+
+// WorkerIDs returns the ids of the workers of the pool, from the oldest to the
+// newest.
+func (p *GorkPool[Id, Task, Result]) WorkerIDs() []Id {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	workers := p.byAge()
+	ids := make([]Id, len(workers))
+	for i, ws := range workers {
+		ids[i] = ws.worker.ID()
+	}
+	return ids
+}
+
+// Range calls fn for each worker of the pool, from the oldest to the newest,
+// until it returns false. fn runs under the pool's lock, so workers don't come
+// and go in the meantime, and must not call the pool's methods.
+func (p *GorkPool[Id, Task, Result]) Range(fn func(id Id, w GorkWorker[Id, Task, Result]) bool) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
+	for _, ws := range p.byAge() {
+		if !fn(ws.worker.ID(), ws.worker) {
+			return
+		}
+	}
+}
 
-	_, ok := p.workers[id]
-	return ok
+// byAge returns the workers from the oldest to the newest.
+func (p *GorkPool[Id, Task, Result]) byAge() []*workerState[Id, Task, Result] {
+	workers := make([]*workerState[Id, Task, Result], 0, p.workers.len())
+	p.workers.eachShared(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		workers = append(workers, ws)
+		return true
+	})
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].seq < workers[j].seq
+	})
+	return workers
 }
 
-func (p *GorkPool[Id, Task, Result]) AddTask(task Task) {
-	p.inputCh <- task
+// QueueLen is the number of tasks submitted but not taken by a worker yet.
+func (p *GorkPool[Id, Task, Result]) QueueLen() int {
+	used, _ := p.queue.slots.load()
+	n := used + len(p.inputCh)
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	p.parkMutex.Lock()
+	n += len(p.parked)
+	p.parkMutex.Unlock()
+	for _, held := range p.held {
+		n += len(held)
+	}
+	p.workers.eachShared(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		// Tasks in the queues of channel workers don't hold a slot
+		n += len(ws.inputCh)
+		return true
+	})
+	return n
+}
+
+// InFlight is the number of tasks TaskHandler workers are handling. What
+// channel workers are doing is out of the pool's sight.
+func (p *GorkPool[Id, Task, Result]) InFlight() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.inFlight
+}
+
+// AddTask queues task, blocking while the queue is full. Once the pool is
+// shutting down it returns ErrPoolClosed.
+func (p *GorkPool[Id, Task, Result]) AddTask(task Task, opts ...TaskOption) error {
+	return p.pushCtx(context.Background(), p.newEnvelope(task, opts))
+}
+
+// AddTaskCtx is AddTask giving up once ctx is done, with ErrSubmitCanceled, or
+// the pool is closed, with ErrPoolClosed.
+func (p *GorkPool[Id, Task, Result]) AddTaskCtx(ctx context.Context, task Task, opts ...TaskOption) error {
+	return p.pushCtx(ctx, p.newEnvelope(task, opts))
+}
+
+func (p *GorkPool[Id, Task, Result]) enqueue(task Task, opts []TaskOption) bool {
+	return p.pushCtx(context.Background(), p.newEnvelope(task, opts)) == nil
+}
+
+func (p *GorkPool[Id, Task, Result]) newEnvelope(task Task, opts []TaskOption) *envelope[Task, Result] {
+	cfg := newTaskConfig(opts)
+	env := p.alloc(task)
+	env.id = cfg.id
+	env.priority, env.weight = cfg.priority, cfg.weight
+	env.deadline = cfg.deadline
+	env.tags, env.tenant = cfg.tags, cfg.tenant
+	env.submitted = p.cfg.clock.Now()
+	env.trace, env.metadata = cfg.trace, cfg.metadata
+	env.parent = cfg.parent
+	if p.cfg.taskBudget > 0 {
+		p.budget(env)
+	}
+	if cfg.ttl > 0 {
+		env.expires = p.cfg.clock.Now().Add(cfg.ttl)
+	}
+	if p.cfg.keyConcurrency > 0 {
+		env.limitKey = cfg.concurrencyKey
+	}
+	if p.cfg.dedupWindow > 0 {
+		env.dedupKey = cfg.dedupKey
+	}
+	if p.cache != nil {
+		env.cacheKey = cfg.cacheKey
+	}
+	return env
+}
+
+// push queues env, giving up if the pool shuts down or cancel is closed first.
+func (p *GorkPool[Id, Task, Result]) push(env *envelope[Task, Result], cancel <-chan struct{}) bool {
+	return p.pushUntil(env, p.ctx.Done(), cancel)
+}
+
+func (p *GorkPool[Id, Task, Result]) pushUntil(env *envelope[Task, Result], done <-chan struct{}, cancel <-chan struct{}) bool {
+	if p.validate(env) != nil {
+		return false
+	}
+	p.track(env)
+	p.numberResult(env)
+	if !p.queue.push(env, done, cancel) {
+		p.finish(env)
+		p.skipResult(env)
+		return false
+	}
+	return true
+}
+
+// TrySubmit queues task like AddTask without blocking, returning false if the
+// queue is full or the pool is closed.
+func (p *GorkPool[Id, Task, Result]) TrySubmit(task Task, opts ...TaskOption) bool {
+	return p.TryAddTask(task, opts...) == nil
+}
+
+// TryAddTask is TrySubmit telling why it couldn't queue task: ErrPoolClosed,
+// ErrQueueFull, ErrQuotaExceeded or the reason the task can't go to any
+// worker.
+func (p *GorkPool[Id, Task, Result]) TryAddTask(task Task, opts ...TaskOption) error {
+	if p.ctx.Err() != nil {
+		return NewErrPoolClosed()
+	}
+	env := p.newEnvelope(task, opts)
+	if !p.queue.reserve(env) {
+		return NewErrQuotaExceeded(env.tenant)
+	}
+	if env.reserved {
+		defer p.queue.unreserve(env)
+	}
+	return p.tryPush(env)
+}
+
+// pushCtx submits env following the backpressure policy, with the reason it
+// gave up, if any.
+func (p *GorkPool[Id, Task, Result]) pushCtx(ctx context.Context, env *envelope[Task, Result]) error {
+	if err := p.validate(env); err != nil {
+		return err
+	}
+	if p.cfg.inline && p.runInline(env) {
+		return nil
+	}
+	if ok, err := p.offer(env, p.ctx.Done(), ctx.Done()); ok || err != nil {
+		return err
+	}
+	if p.ctx.Err() == nil && ctx.Err() != nil {
+		return NewErrSubmitCanceled(ctx.Err())
+	}
+	return NewErrPoolClosed()
 }
 
 func (p *GorkPool[Id, Task, Result]) OutputCh() chan Result {
 	return p.outputCh
 }
 
+// ErrorCh is where failed tasks are reported, if the pool was created
+// WithErrorChannel, and nil otherwise. Channel workers can report their own
+// failures to it too.
+func (p *GorkPool[Id, Task, Result]) ErrorCh() chan error {
+	return p.errorCh
+}
+
+// run hands queued tasks to the workers until the context is done.
+func (p *GorkPool[Id, Task, Result]) run() {
+	for {
+		env, ok := p.next()
+		if !ok {
+			break
+		}
+		// env is no longer ours once delivered
+		queuedAt := env.queuedAt
+		if !p.throttle() || !p.deliver(env, p.ctx.Done(), false) {
+			p.queue.unpop(env)
+			break
+		}
+		p.progressed()
+		p.sampleWait(p.cfg.clock.Now().Sub(queuedAt))
+	}
+	p.gracefullyShutdown()
+}
+
+// pop takes the next queued task, dead lettering the expired and stale ones
+// on the way.
+func (p *GorkPool[Id, Task, Result]) pop() (*envelope[Task, Result], bool) {
+	for {
+		env, ok := p.queue.pop()
+		if !ok {
+			return nil, false
+		}
+
+		switch {
+		case p.isCanceled(env) || p.orphaned(env):
+			p.queue.release()
+		case env.expired(p.cfg.clock.Now()):
+			p.queue.release()
+			p.drop(env, context.DeadlineExceeded)
+		case env.stale(p.cfg.clock.Now()):
+			p.queue.release()
+			p.drop(env, NewErrTaskStale())
+		default:
+			return env, true
+		}
+	}
+}
+
+// next waits for a queued task, returning false once the context is done.
+func (p *GorkPool[Id, Task, Result]) next() (*envelope[Task, Result], bool) {
+	for {
+		if !p.unpaused(p.ctx.Done()) {
+			return nil, false
+		}
+		if env, ok := p.pop(); ok {
+			return env, true
+		}
+
+		select {
+		case <-p.queue.ready:
+		case <-p.ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// deliver blocks until a worker takes env or done is closed. When draining it
+// also gives up as soon as the pool has no workers left.
+func (p *GorkPool[Id, Task, Result]) deliver(env *envelope[Task, Result], done <-chan struct{}, draining bool) bool {
+	if p.perWorkerQueues() {
+		return p.route(env, done, draining)
+	}
+
+	spawned := false
+	for {
+		p.mutex.Lock()
+		workers := p.workers.len()
+		// Channel workers can't report back, so tracked tasks only go to
+		// TaskHandler workers. Without any workers tasks wait in the input
+		// channel like they always have.
+		var inputCh chan Task
+		if !env.tracked() && (p.channelWorkers.Load() > 0 || p.handlerWorkers.Load() == 0) {
+			inputCh = p.inputCh
+		}
+		p.mutex.Unlock()
+
+		if draining && workers == 0 {
+			return false
+		}
+		// A worker spawned for env may not be ready yet, so it waits for
+		// whichever worker is ready first
+		if !draining && !spawned && p.spawnsOnDemand() {
+			if workers > 0 && p.handOver(env, inputCh) {
+				return true
+			}
+			if spawned = p.spawn(); spawned {
+				continue
+			}
+		}
+
+		select {
+		case inputCh <- env.task:
+			// Channel workers are out of reach from now on
+			p.finish(env)
+		case p.taskCh <- env:
+		case <-p.wake:
+			if !draining && !p.unpaused(done) {
+				return false
+			}
+			continue
+		case <-done:
+			return false
+		}
+
+		p.queue.release()
+		return true
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) notifyWorkersChanged() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// flush hands the remaining queued tasks to the workers, if there are any
+// left and the pool isn't aborted, and abandons the rest.
+func (p *GorkPool[Id, Task, Result]) flush() {
+	sourcesDone := p.sourcesDone()
+	waiting := sourcesDone
+loop:
+	for {
+		env, ok := p.pop()
+		if !ok {
+			if waiting == nil {
+				break
+			}
+			// Sources may still bring tasks in
+			select {
+			case <-p.queue.ready:
+			case <-waiting:
+				waiting = nil
+			case <-p.abort:
+				break loop
+			}
+			continue
+		}
+		if !p.deliver(env, p.abort, true) {
+			p.queue.unpop(env)
+			break
+		}
+	}
+	close(p.sourcesStop)
+	<-sourcesDone
+
+	// No worker could take the parked tasks
+	parked := p.unparkAll()
+	if p.isAborted() {
+		p.discard(parked)
+		return
+	}
+
+	for _, env := range parked {
+		p.abandon(env)
+	}
+	for {
+		env, ok := p.queue.pop()
+		if !ok {
+			return
+		}
+		p.abandon(env)
+	}
+}
+
 func (p *GorkPool[Id, Task, Result]) gracefullyShutdown() {
-	<-p.ctx.Done()
-	close(p.inputCh)  // Stop receiving new tasks
-	p.wg.Wait()       // Wait all workers to finish
-	close(p.outputCh) // Indicate that this gorkpool is done
+	p.mutex.Lock()
+	p.transitionLocked(StateDraining)
+	startHooks, completeHooks := p.shutdownStart, p.shutdownComplete
+	started, completed, failed := p.cfg.clock.Now(), p.completed, p.failed
+	p.mutex.Unlock()
+	drained := make(chan struct{})
+	forced := p.forceDrain(drained)
+	for _, fn := range startHooks {
+		fn()
+	}
+	p.stopSchedules()
+	p.flush()
+	close(p.discarded)
+	killed := p.isKilled()
+	if killed {
+		p.stopWorkers(true, true)
+	} else if p.cfg.externalChannels {
+		// Channel workers won't see the input channel being closed
+		p.stopWorkers(true, false)
+	}
+	if !p.cfg.externalChannels {
+		close(p.inputCh) // Stop receiving new tasks
+	}
+	close(p.taskCh) // Stop TaskHandler workers too
+	if p.perWorkerQueues() {
+		p.sends.Wait()
+		p.closeWorkerQueues()
+	}
+	stuck := p.awaitWorkers(forced, killed) // Wait all workers to finish
+	close(drained)
+	for _, env := range p.unholdAll() {
+		p.abandon(env)
+	}
+	p.persist()
+
+	state, err := p.finalState()
+	p.mutex.Lock()
+	p.transitionLocked(state)
+	p.err = err
+	p.report.Completed, p.report.Failed = p.completed-completed, p.failed-failed
+	p.report.Duration = p.cfg.clock.Now().Sub(started)
+	p.reported = true
+	p.mutex.Unlock()
+
+	if !p.cfg.externalChannels && !stuck {
+		close(p.outputCh) // Indicate that this gorkpool is done
+	}
+	if p.errorCh != nil {
+		close(p.errorCh)
+	}
+	for _, fn := range completeHooks {
+		fn(err)
+	}
+	close(p.done)
 }
@@ -3,10 +3,13 @@ package gorkpool_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
 )
 
 type testWorker struct {
@@ -14,6 +17,8 @@ type testWorker struct {
 	input  chan int
 	output chan int
 	done   chan struct{}
+	// exited is closed once Process returned
+	exited chan struct{}
 }
 
 func newTestWorker(id int, input chan int, output chan int) *testWorker {
@@ -22,6 +27,7 @@ func newTestWorker(id int, input chan int, output chan int) *testWorker {
 		input:  input,
 		output: output,
 		done:   make(chan struct{}),
+		exited: make(chan struct{}),
 	}
 }
 
@@ -30,6 +36,7 @@ func (w *testWorker) ID() int {
 }
 
 func (w *testWorker) Process() {
+	defer close(w.exited)
 	for {
 		select {
 		case <-w.done:
@@ -44,7 +51,36 @@ func (w *testWorker) Process() {
 }
 
 func (w *testWorker) SignalRemoval() {
-	w.done <- struct{}{}
+	select {
+	case w.done <- struct{}{}:
+	case <-w.exited:
+	}
+}
+
+type testHandler struct {
+	id int
+	fn func(ctx context.Context, x int) (int, error)
+}
+
+func (w *testHandler) ID() int {
+	return w.id
+}
+
+func (w *testHandler) Process() {}
+
+func (w *testHandler) SignalRemoval() {}
+
+func (w *testHandler) Handle(ctx context.Context, x int) (int, error) {
+	return w.fn(ctx, x)
+}
+
+func setupHandlerPool(fn func(ctx context.Context, x int) (int, error)) (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inputCh := make(chan int, 10)
+	outputCh := make(chan int, 10)
+	return gorkpool.NewGorkPool(ctx, inputCh, outputCh, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: fn}, nil
+	}), cancel
 }
 
 func setupPool() (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
@@ -149,6 +185,70 @@ func TestRemoveWorkerById(t *testing.T) {
 	<-pool.OutputCh()
 }
 
+func TestLengthDuringChanges(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return x, nil
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			pool.AddWorker(i)
+			if i%2 == 1 {
+				pool.RemoveWorkerById(i)
+			}
+		}
+	}()
+	// Action
+	for {
+		if n := pool.Length(); n < 0 || n > 100 {
+			t.Fatalf("expected a worker count between 0 and 100, got %d", n)
+		}
+		pool.Contains(0)
+		select {
+		case <-done:
+		default:
+			continue
+		}
+		break
+	}
+	// Assert
+	if pool.Length() != 50 {
+		t.Errorf("expected pool to have %d worker(s), got %d", 50, pool.Length())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWorkerIDsAndRange(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return x, nil
+	})
+	for _, id := range []int{3, 1, 2} {
+		pool.AddWorker(id)
+	}
+	// Action
+	ids := pool.WorkerIDs()
+	var ranged []int
+	pool.Range(func(id int, w gorkpool.GorkWorker[int, int, int]) bool {
+		ranged = append(ranged, w.ID())
+		return len(ranged) < 2
+	})
+	// Assert
+	if want := []int{3, 1, 2}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected ids %v from the oldest worker to the newest, got %v", want, ids)
+	}
+	if want := []int{3, 1}; !reflect.DeepEqual(ranged, want) {
+		t.Errorf("expected Range to stop after %v, got %v", want, ranged)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
 func TestGracefullyShutdown(t *testing.T) {
 	// Setup
 	pool, cancel := setupPool()
@@ -156,16 +256,107 @@ func TestGracefullyShutdown(t *testing.T) {
 		pool.AddWorker(i)
 	}
 	runningGoroutines := runtime.NumGoroutine() - 1 // Removing golang test runner's goroutine
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	err := pool.VerifyClean(ctx)
 	// Assert
 	if runningGoroutines != 12 { // 10 Workers, 1 pool and my test's goroutine
 		t.Errorf("expected 12 goroutines to be running, got %d", runningGoroutines)
 	}
+	var leak gorkpool.ErrGoroutineLeak
+	if !errors.As(err, &leak) || !reflect.DeepEqual(leak.Goroutines(), map[string]int{"worker": 10, "run": 1}) {
+		t.Errorf("expected the 10 workers and the pool's goroutine to be running, got %v", err)
+	}
 	// Action
 	cancel()
-	<-pool.OutputCh()                              // Wait for other goroutines to end
+	pool.Wait()
+	gorkpooltest.VerifyClean(t, pool, time.Second) // Wait for other goroutines to end
 	runningGoroutines = runtime.NumGoroutine() - 1 // Removing golang test runner's goroutine
 	// Assert
 	if runningGoroutines != 1 {
 		t.Errorf("expected 1 goroutine to be running, got %d", runningGoroutines)
 	}
 }
+
+func TestTrySubmit(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	accepted := 0
+	// Action
+	for i := 0; i < 100; i++ {
+		if pool.TrySubmit(i) {
+			accepted++
+		}
+	}
+	// Assert
+	if accepted == 0 || accepted == 100 {
+		t.Errorf("expected some tasks to be shed once the queue is full, got %d accepted", accepted)
+	}
+	// Cleanup
+	cancel()
+	<-pool.OutputCh()
+	if pool.TrySubmit(0) {
+		t.Error("expected TrySubmit to fail after shutdown")
+	}
+}
+
+func TestTryAddTask(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = pool.TryAddTask(i)
+	}
+	// Assert
+	if !errors.Is(err, gorkpool.ErrQueueFull{}) {
+		t.Errorf("expected ErrQueueFull once the queue is full, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	<-pool.OutputCh()
+	if err := pool.TryAddTask(0); !errors.Is(err, gorkpool.ErrPoolClosed{}) {
+		t.Errorf("expected ErrPoolClosed after shutdown, got %v", err)
+	}
+}
+
+func TestAddTaskCtx(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Fill both the queue and the input channel
+	for i := 0; i < 3; i++ {
+		for pool.TrySubmit(0) {
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	ctx, cancelAdd := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelAdd()
+	// Action
+	err := pool.AddTaskCtx(ctx, 1)
+	// Assert
+	var canceled gorkpool.ErrSubmitCanceled
+	if !errors.As(err, &canceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ErrSubmitCanceled wrapping %v, got %v", context.DeadlineExceeded, err)
+	}
+	// Cleanup
+	cancel()
+	<-pool.OutputCh()
+	// Assert
+	if err := pool.AddTaskCtx(context.Background(), 1); !errors.Is(err, gorkpool.NewErrPoolClosed()) {
+		t.Errorf("expected %v after shutdown, got %v", gorkpool.NewErrPoolClosed(), err)
+	}
+}
+
+func TestAddTaskAfterShutdown(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	cancel()
+	<-pool.OutputCh()
+	// Action
+	err := pool.AddTask(1)
+	// Assert
+	if !errors.Is(err, gorkpool.NewErrPoolClosed()) {
+		t.Errorf("expected %v, got %v", gorkpool.NewErrPoolClosed(), err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package gorkpooltest helps testing code that uses a GorkPool: inline pools
+// that handle tasks as they are submitted, a worker recording what it handles,
+// and assertions on it.
+package gorkpooltest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+// Recorder is a TaskHandler worker that records the tasks it handles, in
+// order, before handing them to its function.
+type Recorder[Id comparable, Task any, Result any] struct {
+	id Id
+	fn gorkpool.HandlerFunc[Task, Result]
+
+	mutex   sync.Mutex
+	tasks   []Task
+	handled chan struct{}
+}
+
+// NewRecorder creates a Recorder handling tasks with fn. A nil fn answers the
+// zero Result.
+func NewRecorder[Id comparable, Task any, Result any](id Id, fn gorkpool.HandlerFunc[Task, Result]) *Recorder[Id, Task, Result] {
+	return &Recorder[Id, Task, Result]{
+		id:      id,
+		fn:      fn,
+		handled: make(chan struct{}, 1),
+	}
+}
+
+func (r *Recorder[Id, Task, Result]) ID() Id {
+	return r.id
+}
+
+func (r *Recorder[Id, Task, Result]) Process() {}
+
+func (r *Recorder[Id, Task, Result]) SignalRemoval() {}
+
+func (r *Recorder[Id, Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	r.mutex.Lock()
+	r.tasks = append(r.tasks, task)
+	r.mutex.Unlock()
+	select {
+	case r.handled <- struct{}{}:
+	default:
+	}
+
+	if r.fn == nil {
+		var zero Result
+		return zero, nil
+	}
+	return r.fn(ctx, task)
+}
+
+// Tasks returns the tasks handled so far.
+func (r *Recorder[Id, Task, Result]) Tasks() []Task {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Task(nil), r.tasks...)
+}
+
+func (r *Recorder[Id, Task, Result]) Count() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.tasks)
+}
+
+// NewInlinePool creates a pool WithInline, so tasks are handled before the
+// call submitting them returns, by a single Recorder running fn. The pool is
+// shut down when the test ends. Results going to the output channel are
+// buffered up to outputBufferSize.
+func NewInlinePool[Task any, Result any](t testing.TB, fn gorkpool.HandlerFunc[Task, Result], outputBufferSize int, opts ...gorkpool.Option) (*gorkpool.GorkPool[int, Task, Result], *Recorder[int, Task, Result]) {
+	t.Helper()
+	rec := NewRecorder[int](0, fn)
+	opts = append(opts, gorkpool.WithInline(), gorkpool.WithOutputBufferSize(outputBufferSize))
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, inputCh chan Task, outputCh chan Result) (gorkpool.GorkWorker[int, Task, Result], error) {
+		return rec, nil
+	}, opts...)
+	if err := pool.AddWorker(0); err != nil {
+		t.Fatalf("gorkpooltest: adding the recorder: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Shutdown(context.Background())
+	})
+	return pool, rec
+}
+
+// WaitProcessed waits up to timeout for r to have handled n tasks, failing t
+// otherwise. Pools that aren't inline need it before asserting.
+func WaitProcessed[Id comparable, Task any, Result any](t testing.TB, r *Recorder[Id, Task, Result], n int, timeout time.Duration) {
+	t.Helper()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for r.Count() < n {
+		select {
+		case <-r.handled:
+		case <-timer.C:
+			t.Fatalf("gorkpooltest: expected %d tasks to be processed within %v, got %d", n, timeout, r.Count())
+		}
+	}
+}
+
+// AssertProcessed fails t unless r handled exactly tasks, in that order.
+func AssertProcessed[Id comparable, Task any, Result any](t testing.TB, r *Recorder[Id, Task, Result], tasks ...Task) {
+	t.Helper()
+	if got := r.Tasks(); !reflect.DeepEqual(got, tasks) && !(len(got) == 0 && len(tasks) == 0) {
+		t.Errorf("gorkpooltest: expected tasks %v to be processed, got %v", tasks, got)
+	}
+}
+
+// AssertProcessedCount fails t unless r handled n tasks.
+func AssertProcessedCount[Id comparable, Task any, Result any](t testing.TB, r *Recorder[Id, Task, Result], n int) {
+	t.Helper()
+	if got := r.Count(); got != n {
+		t.Errorf("gorkpooltest: expected %d tasks to be processed, got %d", n, got)
+	}
+}
+
+// VerifyClean fails t unless every goroutine pool started is done within
+// timeout, for tests to call once the pool is shut down.
+func VerifyClean[Id comparable, Task any, Result any](t testing.TB, pool *gorkpool.GorkPool[Id, Task, Result], timeout time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := pool.VerifyClean(ctx); err != nil {
+		t.Errorf("gorkpooltest: expected the pool to be clean within %v, got %v", timeout, err)
+	}
+}
@@ -0,0 +1,66 @@
+package gorkpooltest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+func double(ctx context.Context, x int) (int, error) {
+	return 2 * x, nil
+}
+
+func TestNewInlinePool(t *testing.T) {
+	// Setup
+	pool, rec := gorkpooltest.NewInlinePool[int, int](t, double, 1)
+	// Action
+	result, err := pool.SubmitWait(context.Background(), 2)
+	pool.AddTask(3)
+	// Assert
+	if err != nil || result != 4 {
+		t.Errorf("expected result 4, got %d and %v", result, err)
+	}
+	// Handled before AddTask returned
+	gorkpooltest.AssertProcessed(t, rec, 2, 3)
+	if got := <-pool.OutputCh(); got != 6 {
+		t.Errorf("expected result 6 on the output channel, got %d", got)
+	}
+}
+
+func TestWaitProcessed(t *testing.T) {
+	// Setup
+	rec := gorkpooltest.NewRecorder[int](0, double)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return rec, nil
+	}, gorkpool.WithOutputBufferSize(3))
+	pool.AddWorker(0)
+	// Action
+	for i := 0; i < 3; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	gorkpooltest.WaitProcessed(t, rec, 3, time.Second)
+	gorkpooltest.AssertProcessedCount(t, rec, 3)
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestVerifyClean(t *testing.T) {
+	// Setup
+	rec := gorkpooltest.NewRecorder[int](0, double)
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return rec, nil
+	}, gorkpool.WithHealthCheck(time.Millisecond), gorkpool.WithHedging(time.Millisecond))
+	pool.AddWorker(0)
+	f, _ := pool.Submit(1)
+	f.Wait(context.Background())
+	// Action
+	pool.Shutdown(context.Background())
+	// Assert
+	gorkpooltest.VerifyClean(t, pool, time.Second)
+}
@@ -0,0 +1,99 @@
+package gorkpooltest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Response is what a MockWorker answers a task with. It waits Delay first,
+// giving up with the task's context error if that is done before, then
+// panics with Panic if set or returns Result and Err.
+type Response[Result any] struct {
+	Result Result
+	Err    error
+	Delay  time.Duration
+	Panic  any
+}
+
+// MockWorker is a TaskHandler worker answering tasks with a script of
+// Responses, one per task in order, then with its default Response once the
+// script runs out.
+type MockWorker[Id comparable, Task any, Result any] struct {
+	id Id
+
+	mutex    sync.Mutex
+	script   []Response[Result]
+	fallback Response[Result]
+	tasks    []Task
+}
+
+func NewMockWorker[Id comparable, Task any, Result any](id Id, script ...Response[Result]) *MockWorker[Id, Task, Result] {
+	return &MockWorker[Id, Task, Result]{
+		id:     id,
+		script: script,
+	}
+}
+
+// Then adds responses to the end of the script.
+func (w *MockWorker[Id, Task, Result]) Then(responses ...Response[Result]) *MockWorker[Id, Task, Result] {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.script = append(w.script, responses...)
+	return w
+}
+
+// Otherwise sets the response given once the script runs out, the zero Result
+// by default.
+func (w *MockWorker[Id, Task, Result]) Otherwise(response Response[Result]) *MockWorker[Id, Task, Result] {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.fallback = response
+	return w
+}
+
+func (w *MockWorker[Id, Task, Result]) ID() Id {
+	return w.id
+}
+
+func (w *MockWorker[Id, Task, Result]) Process() {}
+
+func (w *MockWorker[Id, Task, Result]) SignalRemoval() {}
+
+func (w *MockWorker[Id, Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	w.mutex.Lock()
+	w.tasks = append(w.tasks, task)
+	response := w.fallback
+	if len(w.script) > 0 {
+		response, w.script = w.script[0], w.script[1:]
+	}
+	w.mutex.Unlock()
+
+	if response.Delay > 0 {
+		timer := time.NewTimer(response.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			var zero Result
+			return zero, ctx.Err()
+		}
+	}
+	if response.Panic != nil {
+		panic(response.Panic)
+	}
+	return response.Result, response.Err
+}
+
+// Tasks returns the tasks handled so far, including those still in progress.
+func (w *MockWorker[Id, Task, Result]) Tasks() []Task {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]Task(nil), w.tasks...)
+}
+
+func (w *MockWorker[Id, Task, Result]) Calls() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.tasks)
+}
@@ -0,0 +1,69 @@
+package gorkpooltest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+func mockPool(w *gorkpooltest.MockWorker[int, int, int], opts ...gorkpool.Option) (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return w, nil
+	}, opts...)
+	pool.AddWorker(0)
+	return pool, cancel
+}
+
+func TestMockWorkerScript(t *testing.T) {
+	// Setup
+	w := gorkpooltest.NewMockWorker[int, int, int](0,
+		gorkpooltest.Response[int]{Err: errors.New("flaky")},
+		gorkpooltest.Response[int]{Result: 7},
+	).Otherwise(gorkpooltest.Response[int]{Result: 1})
+	pool, cancel := mockPool(w)
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 2})
+	// Action
+	first, err := pool.SubmitWait(context.Background(), 10)
+	second, _ := pool.SubmitWait(context.Background(), 20)
+	// Assert
+	if err != nil || first != 7 {
+		t.Errorf("expected the retry to get the scripted %d, got %d and %v", 7, first, err)
+	}
+	if second != 1 {
+		t.Errorf("expected the default response %d once the script ran out, got %d", 1, second)
+	}
+	if got := w.Tasks(); len(got) != 3 || got[0] != 10 || got[1] != 10 || got[2] != 20 {
+		t.Errorf("expected tasks [10 10 20], got %v", got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestMockWorkerPanicAndDelay(t *testing.T) {
+	// Setup
+	w := gorkpooltest.NewMockWorker[int, int, int](0,
+		gorkpooltest.Response[int]{Panic: "boom"},
+		gorkpooltest.Response[int]{Delay: time.Hour},
+	)
+	pool, cancel := mockPool(w, gorkpool.WithPanicHandler(func(any) {}))
+	// Action
+	_, panicErr := pool.SubmitWait(context.Background(), 1)
+	_, delayErr := pool.SubmitWait(context.Background(), 2, gorkpool.WithTimeout(10*time.Millisecond))
+	// Assert
+	var panicked gorkpool.ErrPanic
+	if !errors.As(panicErr, &panicked) {
+		t.Errorf("expected ErrPanic, got %v", panicErr)
+	}
+	if !errors.Is(delayErr, context.DeadlineExceeded) {
+		t.Errorf("expected the delay to run past the deadline, got %v", delayErr)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,122 @@
+package gorkpool
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// GroupMember is a pool that can be part of a PoolGroup, which every GorkPool
+// is. The group adds workers with AddWorkerAuto, so the pool needs an
+// IdGenerator.
+type GroupMember interface {
+	Length() int
+	QueueLen() int
+	addWorkerAuto() error
+	removeWorker() bool
+}
+
+func (p *GorkPool[Id, Task, Result]) addWorkerAuto() error {
+	_, err := p.AddWorkerAuto()
+	return err
+}
+
+func (p *GorkPool[Id, Task, Result]) removeWorker() bool {
+	return p.RemoveWorker() != nil
+}
+
+// PoolGroup shares a budget of workers among pools, moving workers to the
+// pools with the most tasks queued per worker.
+type PoolGroup struct {
+	mutex   *sync.Mutex
+	budget  int
+	members []GroupMember
+}
+
+func NewPoolGroup(budget int) *PoolGroup {
+	return &PoolGroup{
+		mutex:  &sync.Mutex{},
+		budget: budget,
+	}
+}
+
+// Join adds pool to the group. Its workers count against the budget from the
+// next rebalance on.
+func (g *PoolGroup) Join(pool GroupMember) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.members = append(g.members, pool)
+}
+
+// Run rebalances the group every interval until ctx is done.
+func (g *PoolGroup) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.Rebalance()
+		}
+	}
+}
+
+// Rebalance moves one worker at most: it gives one more worker to the most
+// pressed pool while the budget allows, or one from the least pressed pool
+// otherwise. Pools over the budget are shrunk down to it first.
+func (g *PoolGroup) Rebalance() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	total := 0
+	for _, m := range g.members {
+		total += m.Length()
+	}
+	for total > g.budget {
+		_, least := g.pressures()
+		if least == nil || !least.removeWorker() {
+			return
+		}
+		total--
+	}
+
+	most, least := g.pressures()
+	if most == nil || most.QueueLen() == 0 {
+		return
+	}
+	if total < g.budget {
+		most.addWorkerAuto()
+		return
+	}
+	if least != nil && least != most && pressure(least) < pressure(most) && least.removeWorker() {
+		most.addWorkerAuto()
+	}
+}
+
+// pressures finds the most pressed pool and the least pressed one that
+// can give up a worker. The caller must hold g.mutex.
+func (g *PoolGroup) pressures() (most GroupMember, least GroupMember) {
+	for _, m := range g.members {
+		if most == nil || pressure(m) > pressure(most) {
+			most = m
+		}
+		if m.Length() > 1 && (least == nil || pressure(m) < pressure(least)) {
+			least = m
+		}
+	}
+	return most, least
+}
+
+// pressure is how many tasks are queued per worker of m.
+func pressure(m GroupMember) float64 {
+	queued := float64(m.QueueLen())
+	if workers := m.Length(); workers > 0 {
+		return queued / float64(workers)
+	}
+	if queued > 0 {
+		return math.Inf(1)
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestPoolGroupRebalance(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	busy, cancelBusy := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		<-release
+		return -x, nil
+	})
+	idle, cancelIdle := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	for _, pool := range []*gorkpool.GorkPool[int, int, int]{busy, idle} {
+		pool.SetIdGenerator(gorkpool.SequentialIDs())
+	}
+	busy.AddWorkerAuto()
+	for i := 0; i < 3; i++ {
+		idle.AddWorkerAuto()
+	}
+	for i := 0; i < 6; i++ {
+		busy.AddTask(i)
+	}
+	group := gorkpool.NewPoolGroup(5)
+	group.Join(busy)
+	group.Join(idle)
+	// Action
+	group.Rebalance()
+	grown := busy.Length()
+	group.Rebalance()
+	// Assert
+	if grown != 2 {
+		t.Errorf("expected the pressed pool to grow within the budget, got %d workers", grown)
+	}
+	if busy.Length() != 3 || idle.Length() != 2 {
+		t.Errorf("expected a worker to move to the pressed pool, got %d and %d workers", busy.Length(), idle.Length())
+	}
+	// Cleanup
+	close(release)
+	cancelBusy()
+	cancelIdle()
+	busy.Wait()
+	idle.Wait()
+}
@@ -0,0 +1,92 @@
+package gorkpool
+
+import (
+	"sync"
+	"time"
+)
+
+// HealthChecker is an optional interface for workers that can tell whether
+// they are still fit to work. See WithHealthCheck.
+type HealthChecker interface {
+	Healthy() bool
+}
+
+// WithHealthCheck makes the pool ask its HealthChecker workers whether they
+// are healthy every interval. Those that say they aren't, or don't answer
+// within interval, are removed and replaced by a new worker with the same id.
+func WithHealthCheck(interval time.Duration) Option {
+	return func(c *config) {
+		c.healthInterval = interval
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) checkHealth() {
+	ticker := p.cfg.clock.NewTicker(p.cfg.healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		p.mutex.Lock()
+		targets := make(map[Id]*workerState[Id, Task, Result])
+		p.workers.each(func(id Id, ws *workerState[Id, Task, Result]) bool {
+			if _, ok := ws.worker.(HealthChecker); ok {
+				targets[id] = ws
+			}
+			return true
+		})
+		p.mutex.Unlock()
+
+		wg := &sync.WaitGroup{}
+		for id, ws := range targets {
+			wg.Add(1)
+			id, ws := id, ws
+			p.launch("health check", func() {
+				defer wg.Done()
+				if !p.healthy(ws.worker.(HealthChecker)) {
+					p.replace(id, ws, NewErrWorker(id, NewErrUnhealthy()))
+					return
+				}
+				p.markReady(ws)
+			})
+		}
+		wg.Wait()
+	}
+}
+
+// healthy asks c whether it is healthy, taking no answer in time for a no.
+func (p *GorkPool[Id, Task, Result]) healthy(c HealthChecker) bool {
+	answer := make(chan bool, 1)
+	p.launch("health answer", func() {
+		answer <- c.Healthy()
+	})
+
+	timer := p.cfg.clock.NewTimer(p.cfg.healthInterval)
+	defer timer.Stop()
+	select {
+	case ok := <-answer:
+		return ok
+	case <-timer.C():
+		return false
+	}
+}
+
+// replace removes ws for err, adding a new worker in its place.
+func (p *GorkPool[Id, Task, Result]) replace(id Id, ws *workerState[Id, Task, Result], err error) {
+	p.mutex.Lock()
+	if !p.workers.holds(id, ws) || !p.running() {
+		p.mutex.Unlock()
+		return
+	}
+	p.unregister(id, ws)
+	p.mutex.Unlock()
+
+	<-p.signal(ws)
+	p.reportErr(err)
+	if err := p.AddWorker(id); err != nil {
+		p.reportErr(NewErrWorker(id, err))
+	}
+}
@@ -0,0 +1,57 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type testChecker struct {
+	testHandler
+	healthy atomic.Bool
+}
+
+func (w *testChecker) Healthy() bool {
+	return w.healthy.Load()
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	// Setup
+	created := make(chan *testChecker, 2)
+	errs := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		w := &testChecker{testHandler: testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return -x, nil
+		}}}
+		w.healthy.Store(true)
+		created <- w
+		return w, nil
+	}, gorkpool.WithHealthCheck(5*time.Millisecond), gorkpool.WithErrorHandler(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	pool.AddWorker(0)
+	first := <-created
+	// Action
+	first.healthy.Store(false)
+	// Assert
+	select {
+	case <-created:
+	case <-time.After(time.Second):
+		t.Fatal("expected unhealthy worker to be replaced")
+	}
+	if err := <-errs; !errors.As(err, new(gorkpool.ErrUnhealthy)) {
+		t.Errorf("expected ErrUnhealthy to be reported, got %v", err)
+	}
+	waitFor(t, func() bool { return pool.Length() == 1 && pool.Contains(0) })
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
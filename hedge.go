@@ -0,0 +1,58 @@
+package gorkpool
+
+import "time"
+
+// WithHedging makes the pool hand tasks submitted with Submit or SubmitWait
+// to a second worker when the first hasn't completed them delay after taking
+// them, to tame tail latency. Whichever copy is done first settles the task
+// and the other is cancelled, so tasks must be safe to run twice. Tasks
+// still queued or waiting to be retried after delay aren't hedged, a copy
+// would only wait behind them. The copy shares the tenant and concurrency key
+// of the task, counting against their quota and limit like any other task.
+func WithHedging(delay time.Duration) Option {
+	return func(c *config) {
+		c.hedgeDelay = delay
+	}
+}
+
+// hedge queues a copy of env if it is still running after the hedging delay,
+// settling env with whichever copy is done first.
+func (p *GorkPool[Id, Task, Result]) hedge(env *envelope[Task, Result]) {
+	timer := p.cfg.clock.NewTimer(p.cfg.hedgeDelay)
+	defer timer.Stop()
+	select {
+	case <-env.future.done:
+		return
+	case <-p.ctx.Done():
+		return
+	case <-timer.C():
+	}
+
+	p.mutex.Lock()
+	handling := env.handling
+	p.mutex.Unlock()
+	if !handling {
+		return
+	}
+
+	twin := p.alloc(env.task)
+	twin.priority, twin.weight = env.priority, env.weight
+	twin.deadline, twin.expires = env.deadline, env.expires
+	twin.tags, twin.tenant = env.tags, env.tenant
+	twin.key, twin.keyed, twin.limitKey = env.key, env.keyed, env.limitKey
+	twin.submitted = env.submitted
+	twin.trace, twin.metadata = env.trace, env.metadata
+	twin.parent, twin.cacheKey = env.parent, env.cacheKey
+	twin.future = newFuture[Result]()
+	if p.tryPush(twin) != nil {
+		return
+	}
+
+	select {
+	case <-env.future.done:
+		p.cancelEnvelope(twin)
+	case <-twin.future.done:
+		env.future.resolve(twin.future.result, twin.future.err)
+		p.cancelEnvelope(env)
+	}
+}
@@ -0,0 +1,148 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithHedging(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	canceled := make(chan struct{})
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 2, func(ctx context.Context, x int) (int, error) {
+		if calls.Add(1) == 1 {
+			// The first copy is stuck until the hedge wins
+			<-ctx.Done()
+			close(canceled)
+			return 0, ctx.Err()
+		}
+		return 2 * x, nil
+	}, gorkpool.WithHedging(10*time.Millisecond))
+	// Action
+	result, err := pool.SubmitWait(context.Background(), 21)
+	// Assert
+	if err != nil || result != 42 {
+		t.Errorf("expected the hedged copy to return %d, got %d, %v", 42, result, err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Errorf("expected the slow copy to be cancelled")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected the task to be handled %d times, got %d", 2, n)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithHedgingFast(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 2, func(ctx context.Context, x int) (int, error) {
+		calls.Add(1)
+		return x, nil
+	}, gorkpool.WithHedging(50*time.Millisecond))
+	// Action
+	for i := 0; i < 5; i++ {
+		pool.SubmitWait(context.Background(), i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	// Assert
+	if n := calls.Load(); n != 5 {
+		t.Errorf("expected tasks done in time not to be hedged, got %d calls for %d tasks", n, 5)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithHedgingRetrying(t *testing.T) {
+	// Setup
+	var (
+		mutex    sync.Mutex
+		attempts []int
+	)
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 2, func(ctx context.Context, x int) (int, error) {
+		envelope, _ := gorkpool.EnvelopeFrom(ctx)
+		mutex.Lock()
+		attempts = append(attempts, envelope.Attempt)
+		mutex.Unlock()
+		if envelope.Attempt == 1 {
+			return 0, errors.New("failed")
+		}
+		return x, nil
+	}, gorkpool.WithHedging(10*time.Millisecond))
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 2, BaseDelay: 100 * time.Millisecond})
+	// Action
+	result, err := pool.SubmitWait(context.Background(), 42)
+	// Assert
+	if err != nil || result != 42 {
+		t.Errorf("expected the retry to return %d, got %d, %v", 42, result, err)
+	}
+	mutex.Lock()
+	if !reflect.DeepEqual(attempts, []int{1, 2}) {
+		t.Errorf("expected a task waiting to be retried not to be hedged, got attempts %v", attempts)
+	}
+	mutex.Unlock()
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithHedgingTenant(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	tenants := make(chan string, 2)
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 2, func(ctx context.Context, x int) (int, error) {
+		envelope, _ := gorkpool.EnvelopeFrom(ctx)
+		tenants <- envelope.Tenant
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return x, nil
+	}, gorkpool.WithHedging(10*time.Millisecond))
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 42, gorkpool.WithTenant("acme"))
+	// Assert
+	if err != nil {
+		t.Errorf("expected the hedged copy to succeed, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if tenant := <-tenants; tenant != "acme" {
+			t.Errorf("expected both copies to belong to tenant %q, got %q", "acme", tenant)
+		}
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithHedgingKeyConcurrency(t *testing.T) {
+	// Setup
+	var running, most atomic.Int64
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 2, func(ctx context.Context, x int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for m := most.Load(); n > m && !most.CompareAndSwap(m, n); m = most.Load() {
+		}
+		time.Sleep(50 * time.Millisecond)
+		return x, nil
+	}, gorkpool.WithHedging(10*time.Millisecond), gorkpool.WithKeyConcurrency(1))
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 42, gorkpool.WithConcurrencyKey("k"))
+	// Assert
+	if err != nil {
+		t.Errorf("expected the task to succeed, got %v", err)
+	}
+	if n := most.Load(); n != 1 {
+		t.Errorf("expected the hedged copy to keep to the key limit of %d, got %d running", 1, n)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
@@ -0,0 +1,93 @@
+package gorkpool
+
+import "time"
+
+// histogramBuckets is the number of bounded buckets, doubling from a
+// microsecond up to about a minute.
+const histogramBuckets = 27
+
+// Histogram counts durations in exponential buckets. Counts[i] is the number
+// of durations up to Bounds[i], and the last count, one past the bounds, is
+// the number of longer ones.
+type Histogram struct {
+	Bounds []time.Duration `json:"bounds"`
+	Counts []uint64        `json:"counts"`
+	Count  uint64          `json:"count"`
+	Sum    time.Duration   `json:"sum"`
+}
+
+// Mean is the average duration, zero if there is none.
+func (h Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Quantile is an upper bound of the q-th quantile, the bound of the bucket it
+// falls in. Durations beyond the last bound are reported as the last bound.
+func (h Histogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := uint64(q*float64(h.Count) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	var seen uint64
+	for i, n := range h.Counts {
+		seen += n
+		if seen >= rank && i < len(h.Bounds) {
+			return h.Bounds[i]
+		}
+	}
+	return h.Bounds[len(h.Bounds)-1]
+}
+
+// Stats are the latency histograms of the pool since it started. QueueWait
+// goes from when a task is queued to when it is dispatched, Processing from
+// when a TaskHandler worker picks it up to when it is done with it.
+type Stats struct {
+	QueueWait  Histogram `json:"queue_wait"`
+	Processing Histogram `json:"processing"`
+}
+
+// Stats takes a snapshot of the latency histograms of the pool.
+func (p *GorkPool[Id, Task, Result]) Stats() Stats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return Stats{
+		QueueWait:  p.queueWait.snapshot(),
+		Processing: p.processing.snapshot(),
+	}
+}
+
+// histogram is guarded by the pool mutex.
+type histogram struct {
+	counts [histogramBuckets + 1]uint64
+	count  uint64
+	sum    time.Duration
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := 0
+	for bound := time.Microsecond; i < histogramBuckets && d > bound; bound *= 2 {
+		i++
+	}
+	h.counts[i]++
+	h.count++
+	h.sum += d
+}
+
+func (h *histogram) snapshot() Histogram {
+	s := Histogram{
+		Bounds: make([]time.Duration, histogramBuckets),
+		Counts: append([]uint64(nil), h.counts[:]...),
+		Count:  h.count,
+		Sum:    h.sum,
+	}
+	for i, bound := 0, time.Microsecond; i < histogramBuckets; i, bound = i+1, bound*2 {
+		s.Bounds[i] = bound
+	}
+	return s
+}
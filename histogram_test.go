@@ -0,0 +1,59 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestStats(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		time.Sleep(3 * time.Millisecond)
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	for i := 0; i < 5; i++ {
+		f, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("expected submit to succeed, got %v", err)
+		}
+		f.Wait(context.Background())
+	}
+	waitFor(t, func() bool { return pool.Stats().QueueWait.Count == 5 })
+	stats := pool.Stats()
+	// Assert
+	if stats.Processing.Count != 5 {
+		t.Errorf("expected 5 processing samples, got %d", stats.Processing.Count)
+	}
+	if got := stats.Processing.Quantile(0.5); got < 3*time.Millisecond {
+		t.Errorf("expected median processing time of at least %v, got %v", 3*time.Millisecond, got)
+	}
+	if got := stats.Processing.Mean(); got < 3*time.Millisecond {
+		t.Errorf("expected mean processing time of at least %v, got %v", 3*time.Millisecond, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	// Setup
+	h := gorkpool.Histogram{
+		Bounds: []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond},
+		Counts: []uint64{5, 3, 1, 1},
+		Count:  10,
+	}
+	// Action
+	p50, p80, p100 := h.Quantile(0.5), h.Quantile(0.8), h.Quantile(1)
+	// Assert
+	if p50 != time.Millisecond || p80 != 2*time.Millisecond || p100 != 4*time.Millisecond {
+		t.Errorf("expected quantiles 1ms, 2ms and 4ms, got %v, %v and %v", p50, p80, p100)
+	}
+	if (gorkpool.Histogram{}).Quantile(0.5) != 0 {
+		t.Error("expected empty histogram quantile to be zero")
+	}
+}
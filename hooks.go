@@ -0,0 +1,73 @@
+package gorkpool
+
+import (
+	"context"
+	"time"
+)
+
+// TaskEvent describes a task handled by a TaskHandler worker to the task
+// hooks. Wait is how long the task was queued before the worker took it, zero
+// if it didn't go through the queue. Metadata and Trace are those of its
+// TaskEnvelope. Result, Err and Duration, the time spent handling it, are only
+// set for OnTaskEnd.
+type TaskEvent[Id comparable, Task any, Result any] struct {
+	ID       string
+	Task     Task
+	Worker   Id
+	Attempt  int
+	Priority int
+	Tenant   string
+	Metadata map[string]string
+	Trace    context.Context
+	Wait     time.Duration
+	Result   Result
+	Err      error
+	Duration time.Duration
+}
+
+// TaskHook is called from the worker goroutine, so it holds up the worker.
+type TaskHook[Id comparable, Task any, Result any] func(TaskEvent[Id, Task, Result])
+
+// OnTaskStart registers fn to be called when a TaskHandler worker takes a
+// task, before handling it.
+func (p *GorkPool[Id, Task, Result]) OnTaskStart(fn TaskHook[Id, Task, Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.startHooks = appendHook(p.startHooks, fn)
+}
+
+// OnTaskEnd registers fn to be called when a TaskHandler worker is done
+// handling a task, whatever the outcome.
+func (p *GorkPool[Id, Task, Result]) OnTaskEnd(fn TaskHook[Id, Task, Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.endHooks = appendHook(p.endHooks, fn)
+}
+
+// appendHook copies hooks, which may be being run without the pool mutex.
+func appendHook[F any](hooks []F, fn F) []F {
+	return append(append(make([]F, 0, len(hooks)+1), hooks...), fn)
+}
+
+func newTaskEvent[Id comparable, Task any, Result any](ws *workerState[Id, Task, Result], env *envelope[Task, Result], now time.Time) TaskEvent[Id, Task, Result] {
+	event := TaskEvent[Id, Task, Result]{
+		ID:       env.id,
+		Task:     env.task,
+		Worker:   ws.worker.ID(),
+		Attempt:  env.attempt,
+		Priority: env.priority,
+		Tenant:   env.tenant,
+		Metadata: env.metadata,
+		Trace:    env.trace,
+	}
+	if !env.queuedAt.IsZero() {
+		event.Wait = now.Sub(env.queuedAt)
+	}
+	return event
+}
+
+func runHooks[Id comparable, Task any, Result any](hooks []TaskHook[Id, Task, Result], event TaskEvent[Id, Task, Result]) {
+	for _, fn := range hooks {
+		fn(event)
+	}
+}
@@ -0,0 +1,51 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestTaskHooks(t *testing.T) {
+	// Setup
+	failure := errors.New("failure")
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		time.Sleep(2 * time.Millisecond)
+		if x < 0 {
+			return 0, failure
+		}
+		return -x, nil
+	})
+	var starts, ends []gorkpool.TaskEvent[int, int, int]
+	pool.OnTaskStart(func(e gorkpool.TaskEvent[int, int, int]) {
+		starts = append(starts, e)
+	})
+	pool.OnTaskEnd(func(e gorkpool.TaskEvent[int, int, int]) {
+		ends = append(ends, e)
+	})
+	pool.AddWorker(0)
+	// Action
+	for _, x := range []int{1, -1} {
+		f, _ := pool.Submit(x, gorkpool.WithTaskID("task"))
+		f.Wait(context.Background())
+	}
+	// Assert
+	if len(starts) != 2 || len(ends) != 2 {
+		t.Fatalf("expected 2 start and 2 end events, got %d and %d", len(starts), len(ends))
+	}
+	if starts[0].Task != 1 || starts[0].ID != "task" || starts[0].Worker != 0 || starts[0].Attempt != 1 {
+		t.Errorf("expected start event of task 1 on worker 0, got %+v", starts[0])
+	}
+	if ends[0].Result != -1 || ends[0].Err != nil || ends[0].Duration < 2*time.Millisecond {
+		t.Errorf("expected end event with result -1 after at least %v, got %+v", 2*time.Millisecond, ends[0])
+	}
+	if !errors.Is(ends[1].Err, failure) {
+		t.Errorf("expected end event with the failure, got %+v", ends[1])
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
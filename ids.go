@@ -0,0 +1,68 @@
+package gorkpool
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// idAttempts is how many ids AddWorkerAuto tries before giving up on
+// conflicts with the ids of workers added by hand.
+const idAttempts = 16
+
+// IdGenerator hands out worker ids for AddWorkerAuto. It must be safe for
+// concurrent use. It can be given to SpawnOnDemand as well.
+type IdGenerator[Id comparable] func() Id
+
+// SequentialIDs generates 0, 1, 2...
+func SequentialIDs() IdGenerator[int] {
+	var next int64 = -1
+	return func() int {
+		return int(atomic.AddInt64(&next, 1))
+	}
+}
+
+// UUIDs generates random version 4 UUIDs.
+func UUIDs() IdGenerator[string] {
+	return func() string {
+		var b [16]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			panic(fmt.Sprintf("gorkpool: generating uuid: %v", err))
+		}
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	}
+}
+
+// SetIdGenerator sets the generator of the ids of the workers added with
+// AddWorkerAuto.
+func (p *GorkPool[Id, Task, Result]) SetIdGenerator(gen IdGenerator[Id]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.idGenerator = gen
+}
+
+// AddWorkerAuto is AddWorker with an id from the generator set with
+// SetIdGenerator, which it returns. Ids already taken are skipped.
+func (p *GorkPool[Id, Task, Result]) AddWorkerAuto() (Id, error) {
+	p.mutex.Lock()
+	gen := p.idGenerator
+	p.mutex.Unlock()
+
+	var id Id
+	if gen == nil {
+		return id, NewErrNoIdGenerator()
+	}
+	var err error
+	for i := 0; i < idAttempts; i++ {
+		id = gen()
+		err = p.AddWorker(id)
+		var conflict ErrIdConflict
+		if !errors.As(err, &conflict) {
+			break
+		}
+	}
+	return id, err
+}
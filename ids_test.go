@@ -0,0 +1,61 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestAddWorkerAuto(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.SetIdGenerator(gorkpool.SequentialIDs())
+	pool.AddWorker(1)
+	// Action
+	first, err1 := pool.AddWorkerAuto()
+	second, err2 := pool.AddWorkerAuto()
+	// Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("expected workers to be added, got %v and %v", err1, err2)
+	}
+	if first != 0 || second != 2 {
+		t.Errorf("expected ids 0 and 2, skipping the taken 1, got %d and %d", first, second)
+	}
+	if pool.Length() != 3 {
+		t.Errorf("expected 3 workers, got %d", pool.Length())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestAddWorkerAutoNoGenerator(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	_, err := pool.AddWorkerAuto()
+	// Assert
+	if !errors.Is(err, gorkpool.ErrNoIdGenerator{}) {
+		t.Errorf("expected ErrNoIdGenerator, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestUUIDs(t *testing.T) {
+	// Setup
+	gen := gorkpool.UUIDs()
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	// Action
+	a, b := gen(), gen()
+	// Assert
+	if !uuid.MatchString(a) || a == b {
+		t.Errorf("expected distinct version 4 uuids, got %q and %q", a, b)
+	}
+}
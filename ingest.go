@@ -0,0 +1,105 @@
+package gorkpool
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// TaskDecoder reads the task of a submission request, usually from its JSON
+// body.
+type TaskDecoder[Task any] func(r *http.Request) (Task, error)
+
+// DecodeJSON is the TaskDecoder for bodies holding the task as JSON.
+func DecodeJSON[Task any](r *http.Request) (Task, error) {
+	var task Task
+	err := json.NewDecoder(r.Body).Decode(&task)
+	return task, err
+}
+
+type ingestReply[Result any] struct {
+	Result *Result `json:"result,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// IngestHandler accepts tasks POSTed to it, read by decode. Without wait they
+// are queued like with AddTask, answering 202 Accepted once they are. With
+// wait they are submitted and answered with their result as JSON, or their
+// error with 500 Internal Server Error; leaving before a result cancels the
+// task.
+//
+// Tasks that don't decode, or that no worker can take, get 400 Bad Request.
+// Those over their tenant's quota get 429 Too Many Requests, and those the
+// pool can't take because it is closed or its queue stayed full for the
+// request's context, 503 Service Unavailable.
+func (p *GorkPool[Id, Task, Result]) IngestHandler(decode TaskDecoder[Task], wait bool, opts ...TaskOption) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			replyIngest[Result](w, http.StatusMethodNotAllowed, nil, errors.New("tasks must be POSTed"))
+			return
+		}
+		task, err := decode(r)
+		if err != nil {
+			replyIngest[Result](w, http.StatusBadRequest, nil, err)
+			return
+		}
+
+		if !wait {
+			if err := p.AddTaskCtx(r.Context(), task, opts...); err != nil {
+				replyIngest[Result](w, rejectedStatus(err), nil, err)
+				return
+			}
+			replyIngest[Result](w, http.StatusAccepted, nil, nil)
+			return
+		}
+
+		env, err := p.submit(r.Context(), task, opts)
+		if err != nil {
+			replyIngest[Result](w, rejectedStatus(err), nil, err)
+			return
+		}
+		result, err := env.future.Wait(r.Context())
+		if r.Context().Err() != nil && p.leave(env) {
+			p.cancelEnvelope(env)
+		}
+		var closed ErrPoolClosed
+		switch {
+		case errors.As(err, &closed):
+			replyIngest[Result](w, http.StatusServiceUnavailable, nil, err)
+		case err != nil:
+			replyIngest[Result](w, http.StatusInternalServerError, nil, err)
+		default:
+			replyIngest(w, http.StatusOK, &result, nil)
+		}
+	})
+}
+
+// rejectedStatus is the status code answering a task the pool didn't take
+// for err.
+func rejectedStatus(err error) int {
+	var (
+		closed   ErrPoolClosed
+		canceled ErrSubmitCanceled
+		full     ErrQueueFull
+		quota    ErrQuotaExceeded
+	)
+	switch {
+	case errors.As(err, &quota):
+		return http.StatusTooManyRequests
+	case errors.As(err, &closed) || errors.As(err, &canceled) || errors.As(err, &full):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func replyIngest[Result any](w http.ResponseWriter, code int, result *Result, err error) {
+	reply := ingestReply[Result]{Result: result}
+	if err != nil {
+		reply.Error = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(reply)
+}
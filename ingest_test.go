@@ -0,0 +1,75 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func ingest(h http.Handler, method string, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, "/", strings.NewReader(body)))
+	return rec
+}
+
+func TestIngestHandlerWait(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if x == 0 {
+			return 0, gorkpool.NewErrPermanent(errors.New("zero"))
+		}
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	h := pool.IngestHandler(gorkpool.DecodeJSON[int], true)
+	// Action
+	ok := ingest(h, http.MethodPost, "3")
+	failed := ingest(h, http.MethodPost, "0")
+	bad := ingest(h, http.MethodPost, "three")
+	get := ingest(h, http.MethodGet, "")
+	// Assert
+	if ok.Code != http.StatusOK || strings.TrimSpace(ok.Body.String()) != `{"result":-3}` {
+		t.Errorf("expected %d with the result, got %d and %s", http.StatusOK, ok.Code, ok.Body)
+	}
+	if failed.Code != http.StatusInternalServerError || !strings.Contains(failed.Body.String(), "zero") {
+		t.Errorf("expected %d with the task error, got %d and %s", http.StatusInternalServerError, failed.Code, failed.Body)
+	}
+	if bad.Code != http.StatusBadRequest {
+		t.Errorf("expected undecodable task to get %d, got %d", http.StatusBadRequest, bad.Code)
+	}
+	if get.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET to get %d, got %d", http.StatusMethodNotAllowed, get.Code)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestIngestHandlerQueue(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	h := pool.IngestHandler(gorkpool.DecodeJSON[int], false)
+	// Action
+	rec := ingest(h, http.MethodPost, "4")
+	// Assert
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected queued task to get %d, got %d", http.StatusAccepted, rec.Code)
+	}
+	if got := <-pool.OutputCh(); got != -4 {
+		t.Errorf("expected result %d on the output channel, got %d", -4, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+	if closed := ingest(h, http.MethodPost, "5"); closed.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected closed pool to answer %d, got %d", http.StatusServiceUnavailable, closed.Code)
+	}
+}
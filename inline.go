@@ -0,0 +1,39 @@
+package gorkpool
+
+// WithInline makes AddTask, AddTaskCtx, Submit and SubmitWait handle their
+// task on the caller's goroutine, with the earliest added TaskHandler worker
+// whose circuit breaker isn't open, rather than queueing it. They only return once it is
+// done, so results going to the output channel need a buffer or a reader.
+// Without such a worker, and for retries, tasks are queued as usual.
+//
+// It is meant for tests, where it makes pools deterministic.
+func WithInline() Option {
+	return func(c *config) {
+		c.inline = true
+	}
+}
+
+// runInline handles env on the caller's goroutine, telling whether there was
+// a worker to do it.
+func (p *GorkPool[Id, Task, Result]) runInline(env *envelope[Task, Result]) bool {
+	p.mutex.Lock()
+	var first *workerState[Id, Task, Result]
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws.handler == nil || ws.open(p.cfg.clock.Now()) || ws.ctx.Err() != nil {
+			return true
+		}
+		if first == nil || ws.seq < first.seq {
+			first = ws
+		}
+		return true
+	})
+	running := p.running()
+	p.mutex.Unlock()
+	if first == nil || !running {
+		return false
+	}
+
+	p.track(env)
+	p.handle(first, env)
+	return true
+}
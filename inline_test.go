@@ -0,0 +1,29 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithInline(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 2, func(x int) int { return -x }, gorkpool.WithInline(), gorkpool.WithOutputBufferSize(1))
+	// Action
+	err := pool.AddTask(1)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected task to be added, got %v", err)
+	}
+	if n := len(pool.OutputCh()); n != 1 {
+		t.Fatalf("expected the result to be out before AddTask returned, got %d results", n)
+	}
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result %d, got %d", -1, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,37 @@
+package gorkpool
+
+// ResultInterceptor transforms a result on its way to OutputCh, or drops it by
+// returning false.
+type ResultInterceptor[Result any] func(Result) (Result, bool)
+
+// MapResults is a ResultInterceptor that only transforms results.
+func MapResults[Result any](fn func(Result) Result) ResultInterceptor[Result] {
+	return func(result Result) (Result, bool) {
+		return fn(result), true
+	}
+}
+
+// AddResultInterceptors appends fns to the interceptors the pool runs, in
+// order, on the results it sends to OutputCh. Results written by channel
+// workers themselves and results of Submit don't go through them.
+func (p *GorkPool[Id, Task, Result]) AddResultInterceptors(fns ...ResultInterceptor[Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	interceptors := make([]ResultInterceptor[Result], 0, len(p.interceptors)+len(fns))
+	interceptors = append(interceptors, p.interceptors...)
+	p.interceptors = append(interceptors, fns...)
+}
+
+func (p *GorkPool[Id, Task, Result]) intercept(result Result) (Result, bool) {
+	p.mutex.Lock()
+	interceptors := p.interceptors
+	p.mutex.Unlock()
+
+	for _, fn := range interceptors {
+		var ok bool
+		if result, ok = fn(result); !ok {
+			return result, false
+		}
+	}
+	return result, true
+}
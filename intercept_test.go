@@ -0,0 +1,32 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestAddResultInterceptors(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 1, func(x int) int { return x }, gorkpool.WithOutputBufferSize(4))
+	pool.AddResultInterceptors(
+		func(x int) (int, bool) { return x, x%2 == 0 },
+		gorkpool.MapResults(func(x int) int { return x * 10 }),
+	)
+	// Action
+	for i := 1; i <= 4; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	got := []int{<-pool.OutputCh(), <-pool.OutputCh()}
+	sort.Ints(got)
+	if got[0] != 20 || got[1] != 40 {
+		t.Errorf("expected results %v, got %v", []int{20, 40}, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,117 @@
+// Package jetstream feeds a GorkPool from a NATS JetStream consumer and
+// publishes the results to a subject, acknowledging each message only once its
+// task is done for at-least-once delivery.
+//
+// It works on the small interfaces below rather than depending on a NATS
+// client; the messages and connections of github.com/nats-io/nats.go already
+// satisfy them.
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+// Msg is a message delivered by a JetStream consumer.
+type Msg interface {
+	Data() []byte
+	Ack() error
+	// Nak asks for the message to be redelivered.
+	Nak() error
+	// Term stops the message from being redelivered.
+	Term() error
+}
+
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// Consume submits the JSON task of each message from msgs to pool, publishing
+// the JSON result to subject with pub. It blocks until ctx is done, msgs is
+// closed or a reply fails, then waits for the tasks in flight.
+//
+// A message is acked once its result is published and nacked when its task
+// fails, or when the pool closes before handling it, so JetStream redelivers
+// it, to this process or another. Messages that don't decode and tasks
+// failing with an ErrPermanent are terminated instead.
+func Consume[Id comparable, Task any, Result any](ctx context.Context, pool *gorkpool.GorkPool[Id, Task, Result], msgs <-chan Msg, pub Publisher, subject string, opts ...gorkpool.TaskOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+	failed := func(err error) {
+		if err == nil {
+			return
+		}
+		errMutex.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		errMutex.Unlock()
+		cancel()
+	}
+
+loop:
+	for {
+		var msg Msg
+		select {
+		case <-ctx.Done():
+			break loop
+		case m, ok := <-msgs:
+			if !ok {
+				break loop
+			}
+			msg = m
+		}
+
+		var task Task
+		if err := json.Unmarshal(msg.Data(), &task); err != nil {
+			failed(msg.Term())
+			continue
+		}
+		f, err := pool.Submit(task, opts...)
+		if err != nil {
+			failed(msg.Nak())
+			failed(err)
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := f.Wait(context.Background())
+			failed(settle(msg, pub, subject, result, err))
+		}()
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// settle acks, nacks or terminates msg according to the outcome of its task.
+func settle[Result any](msg Msg, pub Publisher, subject string, result Result, err error) error {
+	var permanent gorkpool.ErrPermanent
+	if errors.As(err, &permanent) {
+		return msg.Term()
+	}
+	if err != nil {
+		return msg.Nak()
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errors.Join(err, msg.Term())
+	}
+	if err := pub.Publish(subject, data); err != nil {
+		return errors.Join(err, msg.Nak())
+	}
+	return msg.Ack()
+}
@@ -0,0 +1,81 @@
+package jetstream_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/jetstream"
+)
+
+type testMsg struct {
+	data    string
+	mutex   *sync.Mutex
+	settled map[string]string
+}
+
+func (m testMsg) Data() []byte { return []byte(m.data) }
+func (m testMsg) Ack() error   { return m.settle("ack") }
+func (m testMsg) Nak() error   { return m.settle("nak") }
+func (m testMsg) Term() error  { return m.settle("term") }
+
+func (m testMsg) settle(how string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.settled[m.data] = how
+	return nil
+}
+
+type testPublisher struct {
+	mutex     sync.Mutex
+	published map[string]string
+}
+
+func (p *testPublisher) Publish(subject string, data []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.published[string(data)] = subject
+	return nil
+}
+
+func TestConsume(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 2, func(ctx context.Context, x int) (int, error) {
+		switch {
+		case x == 0:
+			return 0, gorkpool.NewErrPermanent(errors.New("zero"))
+		case x < 0:
+			return 0, errors.New("negative")
+		}
+		return x * 10, nil
+	})
+	var mutex sync.Mutex
+	settled := make(map[string]string)
+	msgs := make(chan jetstream.Msg, 4)
+	for _, data := range []string{"1", "0", "-1", "x"} {
+		msgs <- testMsg{data: data, mutex: &mutex, settled: settled}
+	}
+	close(msgs)
+	pub := &testPublisher{published: make(map[string]string)}
+	// Action
+	err := jetstream.Consume(ctx, pool, msgs, pub, "results")
+	// Assert
+	if err != nil {
+		t.Fatalf("expected Consume to return nil once msgs is closed, got %v", err)
+	}
+	want := map[string]string{"1": "ack", "0": "term", "-1": "nak", "x": "term"}
+	for data, how := range want {
+		if settled[data] != how {
+			t.Errorf("expected message %q to be %s, got %q", data, how, settled[data])
+		}
+	}
+	if len(pub.published) != 1 || pub.published["10"] != "results" {
+		t.Errorf("expected only result 10 to be published to results, got %v", pub.published)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,235 @@
+// Package kafka bridges a Kafka consumer group into a GorkPool, committing the
+// offset of a message only once its task is done.
+//
+// It works on the small Consumer interface below rather than depending on a
+// Kafka client, so any of them can be adapted to it.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type Partition struct {
+	Topic     string
+	Partition int32
+}
+
+type Message struct {
+	Partition
+	Offset int64
+	Value  []byte
+}
+
+// Consumer is a member of a consumer group.
+type Consumer interface {
+	Fetch(ctx context.Context) (Message, error)
+	// Commit marks the messages of p before offset as consumed.
+	Commit(ctx context.Context, p Partition, offset int64) error
+	Pause(ps ...Partition)
+	Resume(ps ...Partition)
+}
+
+// ResultHandler receives the outcome of the task of each message, before its
+// offset is committed.
+type ResultHandler[Result any] func(msg Message, result Result, err error)
+
+// Consume submits the JSON task of each message fetched from c to pool. It
+// blocks until ctx is done or c or a commit fails, then waits for the tasks in
+// flight.
+//
+// Once maxInFlight tasks are pending, the partitions are paused and Consume
+// stops fetching until half of them are done. Offsets are committed in order
+// as tasks finish, failed ones included; those still pending when the pool
+// closes are left for the group to redeliver. Messages that don't decode are
+// given to onResult, which may be nil, with their error and committed.
+func Consume[Id comparable, Task any, Result any](ctx context.Context, pool *gorkpool.GorkPool[Id, Task, Result], c Consumer, maxInFlight int, onResult ResultHandler[Result], opts ...gorkpool.TaskOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	b := &bridge[Result]{
+		consumer:   c,
+		onResult:   onResult,
+		cancel:     cancel,
+		partitions: make(map[Partition]*pending),
+		drained:    make(chan struct{}, 1),
+	}
+	for ctx.Err() == nil {
+		if b.full(maxInFlight) {
+			b.pause()
+			if !b.drain(ctx, maxInFlight/2) {
+				break
+			}
+			b.resume()
+		}
+
+		msg, err := c.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				b.failed(err)
+			}
+			break
+		}
+		b.track(msg)
+
+		var task Task
+		if err := json.Unmarshal(msg.Value, &task); err != nil {
+			b.done(msg, *new(Result), err)
+			continue
+		}
+		f, err := pool.Submit(task, opts...)
+		if err != nil {
+			b.failed(err)
+			break
+		}
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			result, err := f.Wait(context.Background())
+			var closed gorkpool.ErrPoolClosed
+			if errors.As(err, &closed) {
+				b.release()
+				return
+			}
+			b.done(msg, result, err)
+		}()
+	}
+
+	b.wg.Wait()
+	return b.err
+}
+
+// pending holds the offsets of a partition not committed yet, in the order
+// they were fetched.
+type pending struct {
+	offsets []int64
+	done    map[int64]bool
+}
+
+type bridge[Result any] struct {
+	consumer Consumer
+	onResult ResultHandler[Result]
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	mutex      sync.Mutex
+	partitions map[Partition]*pending
+	inFlight   int
+	paused     []Partition
+	err        error
+	// drained is signaled whenever a task is released
+	drained chan struct{}
+}
+
+func (b *bridge[Result]) failed(err error) {
+	b.mutex.Lock()
+	if b.err == nil {
+		b.err = err
+	}
+	b.mutex.Unlock()
+	b.cancel()
+}
+
+func (b *bridge[Result]) track(msg Message) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	p, ok := b.partitions[msg.Partition]
+	if !ok {
+		p = &pending{done: make(map[int64]bool)}
+		b.partitions[msg.Partition] = p
+	}
+	p.offsets = append(p.offsets, msg.Offset)
+	b.inFlight++
+}
+
+// done records the outcome of msg, committing its partition up to the first
+// message still pending.
+func (b *bridge[Result]) done(msg Message, result Result, err error) {
+	if b.onResult != nil {
+		b.onResult(msg, result, err)
+	}
+
+	b.mutex.Lock()
+	p := b.partitions[msg.Partition]
+	p.done[msg.Offset] = true
+	commit := int64(-1)
+	for len(p.offsets) > 0 && p.done[p.offsets[0]] {
+		commit = p.offsets[0] + 1
+		delete(p.done, p.offsets[0])
+		p.offsets = p.offsets[1:]
+	}
+	var cerr error
+	if commit >= 0 {
+		// Under the lock, so commits of a partition don't go backwards. It
+		// outlives Consume's context to commit the tasks it waits for.
+		cerr = b.consumer.Commit(context.Background(), msg.Partition, commit)
+	}
+	b.mutex.Unlock()
+
+	b.release()
+	if cerr != nil {
+		b.failed(cerr)
+	}
+}
+
+// release takes a task off the in flight count. The offset of one released
+// without being done is never committed.
+func (b *bridge[Result]) release() {
+	b.mutex.Lock()
+	b.inFlight--
+	b.mutex.Unlock()
+	select {
+	case b.drained <- struct{}{}:
+	default:
+	}
+}
+
+func (b *bridge[Result]) full(max int) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.inFlight >= max
+}
+
+// drain waits until at most low tasks are in flight, returning false if ctx is
+// done first.
+func (b *bridge[Result]) drain(ctx context.Context, low int) bool {
+	for {
+		b.mutex.Lock()
+		n := b.inFlight
+		b.mutex.Unlock()
+		if n <= low {
+			return true
+		}
+		select {
+		case <-b.drained:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (b *bridge[Result]) pause() {
+	b.mutex.Lock()
+	b.paused = b.paused[:0]
+	for p := range b.partitions {
+		b.paused = append(b.paused, p)
+	}
+	paused := b.paused
+	b.mutex.Unlock()
+	b.consumer.Pause(paused...)
+}
+
+func (b *bridge[Result]) resume() {
+	b.mutex.Lock()
+	paused := b.paused
+	b.mutex.Unlock()
+	b.consumer.Resume(paused...)
+}
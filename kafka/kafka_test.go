@@ -0,0 +1,153 @@
+package kafka_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/kafka"
+)
+
+type testConsumer struct {
+	msgs    chan kafka.Message
+	mutex   sync.Mutex
+	commits []int64
+	paused  int
+	resumed int
+}
+
+func newTestConsumer(n int) *testConsumer {
+	c := &testConsumer{msgs: make(chan kafka.Message, n)}
+	for i := 0; i < n; i++ {
+		c.msgs <- kafka.Message{
+			Partition: kafka.Partition{Topic: "tasks"},
+			Offset:    int64(i),
+			Value:     []byte(strconv.Itoa(i)),
+		}
+	}
+	return c
+}
+
+func (c *testConsumer) Fetch(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-c.msgs:
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (c *testConsumer) Commit(ctx context.Context, p kafka.Partition, offset int64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.commits = append(c.commits, offset)
+	return nil
+}
+
+func (c *testConsumer) Pause(ps ...kafka.Partition) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.paused++
+}
+
+func (c *testConsumer) Resume(ps ...kafka.Partition) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.resumed++
+}
+
+func (c *testConsumer) lastCommit() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.commits) == 0 {
+		return -1
+	}
+	return c.commits[len(c.commits)-1]
+}
+
+func TestConsumeCommitsInOrder(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 3, func(x int) int {
+		// The first task finishes last
+		if x == 0 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return x
+	})
+	c := newTestConsumer(3)
+	consumed := make(chan error)
+	// Action
+	go func() {
+		consumed <- kafka.Consume(ctx, pool, c, 10, nil)
+	}()
+	// Assert
+	for c.lastCommit() != 3 {
+		time.Sleep(time.Millisecond)
+	}
+	c.mutex.Lock()
+	for i := 1; i < len(c.commits); i++ {
+		if c.commits[i] <= c.commits[i-1] {
+			t.Errorf("expected commits to go forward, got %v", c.commits)
+		}
+	}
+	if c.commits[0] == 1 || c.commits[0] == 2 {
+		t.Errorf("expected no commit before the first task is done, got %v", c.commits)
+	}
+	c.mutex.Unlock()
+	// Cleanup
+	cancel()
+	<-consumed
+	pool.Wait()
+}
+
+func TestConsumePauses(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	gate := make(chan struct{})
+	pool := gorkpool.NewFuncPool(ctx, 4, func(x int) int {
+		<-gate
+		return x
+	})
+	c := newTestConsumer(4)
+	consumed := make(chan error)
+	// Action
+	go func() {
+		consumed <- kafka.Consume(ctx, pool, c, 2, nil)
+	}()
+	// Assert
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.mutex.Lock()
+		paused := c.paused
+		c.mutex.Unlock()
+		if paused > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected partitions to be paused")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if len(c.msgs) != 2 {
+		t.Errorf("expected 2 messages left unfetched while paused, got %d", len(c.msgs))
+	}
+	close(gate)
+	for c.lastCommit() != 4 {
+		time.Sleep(time.Millisecond)
+	}
+	c.mutex.Lock()
+	if c.resumed == 0 {
+		t.Error("expected partitions to be resumed")
+	}
+	c.mutex.Unlock()
+	// Cleanup
+	cancel()
+	if err := <-consumed; err != nil {
+		t.Errorf("expected Consume to return nil once cancelled, got %v", err)
+	}
+	pool.Wait()
+}
@@ -0,0 +1,152 @@
+package gorkpool
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+)
+
+// SubmitKeyed queues task like AddTask, routing all the tasks with the same
+// key to the same worker so they are handled in the order they were
+// submitted. Workers are picked by rendezvous hashing over their ids, so
+// adding or removing a worker only moves the keys of that worker. It needs
+// per worker queues, failing with ErrNoWorkerQueues otherwise, and keyed
+// tasks are never stolen.
+//
+// A key moving to another worker as workers come and go is handed over only
+// once its worker is done with the tasks of the key it has, so their order
+// holds across scaling. Until then the tasks of the key keep going to that
+// worker or, if it was removed, wait for the tasks it left behind to be
+// queued again ahead of them. Tasks handed to channel workers are out of
+// reach, so their keys move right away.
+func (p *GorkPool[Id, Task, Result]) SubmitKeyed(key string, task Task, opts ...TaskOption) error {
+	if !p.perWorkerQueues() {
+		return NewErrNoWorkerQueues()
+	}
+
+	env := p.newEnvelope(task, opts)
+	env.key, env.keyed = key, true
+	return p.pushCtx(context.Background(), env)
+}
+
+// keyOwner is the worker the tasks of a key went to, while it holds any.
+type keyOwner[Id comparable, Task any, Result any] struct {
+	ws *workerState[Id, Task, Result]
+	// pending are the owned tasks of the key the worker hasn't been done with
+	pending int
+	// waiting are the tasks of the key held back until those the worker left
+	// behind when removed are back
+	waiting []*envelope[Task, Result]
+}
+
+// keyTarget is pickTarget for keyed tasks, returning nil while the worker
+// owning the key has no room. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) keyTarget(env *envelope[Task, Result]) *workerState[Id, Task, Result] {
+	if o := p.keyOwners[env.key]; o != nil && o.pending > 0 {
+		// The key stays with its worker until it is done with it
+		if !p.workers.holds(o.ws.worker.ID(), o.ws) || !o.ws.accepts(env) {
+			return nil
+		}
+		return o.ws
+	}
+
+	var (
+		target *workerState[Id, Task, Result]
+		best   uint64
+	)
+	p.workers.each(func(id Id, ws *workerState[Id, Task, Result]) bool {
+		if !ws.takes(env) {
+			return true
+		}
+		if score := keyScore(env.key, id); target == nil || score > best {
+			target, best = ws, score
+		}
+		return true
+	})
+
+	if target == nil || !target.accepts(env) {
+		return nil
+	}
+	return target
+}
+
+func keyScore(key string, id any) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	fmt.Fprint(h, id)
+	return h.Sum64()
+}
+
+// holdKey holds env back if the worker owning its key was removed before
+// being done with it, telling whether it did. env keeps its slot in the queue.
+// The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) holdKey(env *envelope[Task, Result]) bool {
+	o := p.keyOwners[env.key]
+	if o == nil || o.pending == 0 || p.workers.holds(o.ws.worker.ID(), o.ws) {
+		return false
+	}
+	o.waiting = append(o.waiting, env)
+	return true
+}
+
+// own makes ws the owner of the key of env, for it was sent to ws. The caller
+// must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) own(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) {
+	o := p.keyOwners[env.key]
+	if o == nil {
+		o = &keyOwner[Id, Task, Result]{}
+		p.keyOwners[env.key] = o
+	}
+	o.ws = ws
+	o.pending++
+	env.owned = true
+}
+
+// disown accounts for the worker owning the key of env being done with it,
+// handing the key over if it was the last. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) disown(env *envelope[Task, Result]) {
+	if !env.owned {
+		return
+	}
+	env.owned = false
+	o := p.keyOwners[env.key]
+	if o == nil {
+		// Taken out on shutdown
+		return
+	}
+	o.pending--
+	if o.pending > 0 {
+		return
+	}
+	delete(p.keyOwners, env.key)
+	// The tasks left behind keep their place, so they go first
+	for _, env := range o.waiting {
+		p.queue.unpop(env)
+	}
+}
+
+// returned holds back env, an owned task left behind by a removed worker,
+// with the other tasks of its key until all of them are back. env must hold a
+// slot in the queue. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) returned(env *envelope[Task, Result]) {
+	if o := p.keyOwners[env.key]; o != nil {
+		o.waiting = append(o.waiting, env)
+		p.disown(env)
+		return
+	}
+	env.owned = false
+	p.queue.unpop(env)
+}
+
+// unholdKeys takes every task held back for its key out, leaving them to the
+// caller. They still hold their slot in the queue. The caller must hold
+// p.mutex.
+func (p *GorkPool[Id, Task, Result]) unholdKeys() []*envelope[Task, Result] {
+	var waiting []*envelope[Task, Result]
+	for key, o := range p.keyOwners {
+		waiting = append(waiting, o.waiting...)
+		delete(p.keyOwners, key)
+	}
+	return waiting
+}
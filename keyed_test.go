@@ -0,0 +1,184 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSubmitKeyed(t *testing.T) {
+	// Setup
+	mutex := &sync.Mutex{}
+	owners := make(map[int]map[int]bool)
+	seen := make(map[int][]int)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			key := x / 100
+			if owners[key] == nil {
+				owners[key] = make(map[int]bool)
+			}
+			owners[key][id] = true
+			seen[key] = append(seen[key], x%100)
+			return x, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithWorkStealing(), gorkpool.WithOutputBufferSize(40))
+	for i := 0; i < 3; i++ {
+		pool.AddWorker(i)
+	}
+	// Action
+	for n := 0; n < 10; n++ {
+		for key := 1; key <= 4; key++ {
+			pool.SubmitKeyed(strconv.Itoa(key), key*100+n)
+		}
+	}
+	for i := 0; i < 40; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	mutex.Lock()
+	for key := 1; key <= 4; key++ {
+		if len(owners[key]) != 1 {
+			t.Errorf("expected key %d to be handled by a single worker, got %v", key, owners[key])
+		}
+		for n, got := range seen[key] {
+			if got != n {
+				t.Errorf("expected key %d tasks in order, got %v", key, seen[key])
+				break
+			}
+		}
+	}
+	mutex.Unlock()
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitKeyedSharedDispatch(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	err := pool.SubmitKeyed("a", 1)
+	// Assert
+	var noQueues gorkpool.ErrNoWorkerQueues
+	if !errors.As(err, &noQueues) {
+		t.Errorf("expected ErrNoWorkerQueues, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+// keyedRecorder records the order the tasks of each key, x / 100, are handled
+// in, and whether two of them ever ran at once.
+type keyedRecorder struct {
+	mutex   *sync.Mutex
+	seen    map[int][]int
+	running map[int]bool
+	overlap bool
+}
+
+func newKeyedRecorder() *keyedRecorder {
+	return &keyedRecorder{mutex: &sync.Mutex{}, seen: make(map[int][]int), running: make(map[int]bool)}
+}
+
+func (r *keyedRecorder) handle(ctx context.Context, x int) (int, error) {
+	key := x / 100
+	r.mutex.Lock()
+	r.overlap = r.overlap || r.running[key]
+	r.running[key] = true
+	r.seen[key] = append(r.seen[key], x%100)
+	r.mutex.Unlock()
+	time.Sleep(200 * time.Microsecond)
+	r.mutex.Lock()
+	r.running[key] = false
+	r.mutex.Unlock()
+	return x, nil
+}
+
+func (r *keyedRecorder) check(t *testing.T, keys int, n int) {
+	t.Helper()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.overlap {
+		t.Errorf("expected tasks of a key never to run at once")
+	}
+	for key := 1; key <= keys; key++ {
+		if len(r.seen[key]) != n {
+			t.Errorf("expected %d tasks of key %d, got %v", n, key, r.seen[key])
+			continue
+		}
+		for i, got := range r.seen[key] {
+			if got != i {
+				t.Errorf("expected key %d tasks in order, got %v", key, r.seen[key])
+				break
+			}
+		}
+	}
+}
+
+// submitKeyed submits tasks from to to of keys 1 to keys.
+func submitKeyed(pool *gorkpool.GorkPool[int, int, int], keys int, from int, to int) {
+	for n := from; n < to; n++ {
+		for key := 1; key <= keys; key++ {
+			pool.SubmitKeyed(strconv.Itoa(key), key*100+n)
+		}
+	}
+}
+
+func TestSubmitKeyedScaleUp(t *testing.T) {
+	// Setup
+	r := newKeyedRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, r.handle, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy),
+		gorkpool.WithWorkerQueueSize(8), gorkpool.WithQueueSize(200), gorkpool.WithOutputBufferSize(200))
+	// Action
+	submitKeyed(pool, 8, 0, 10)
+	for i := 0; i < 20; i++ {
+		<-pool.OutputCh()
+	}
+	for id := 1; id <= 3; id++ {
+		pool.AddWorker(id)
+	}
+	submitKeyed(pool, 8, 10, 20)
+	for i := 20; i < 160; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	r.check(t, 8, 20)
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitKeyedScaleDown(t *testing.T) {
+	// Setup
+	r := newKeyedRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 4, r.handle, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy),
+		gorkpool.WithWorkerQueueSize(8), gorkpool.WithQueueSize(200), gorkpool.WithOutputBufferSize(200))
+	// Action
+	submitKeyed(pool, 8, 0, 10)
+	for i := 0; i < 20; i++ {
+		<-pool.OutputCh()
+	}
+	for i := 0; i < 3; i++ {
+		pool.RemoveWorker()
+	}
+	submitKeyed(pool, 8, 10, 20)
+	for i := 20; i < 160; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	r.check(t, 8, 20)
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,133 @@
+package gorkpool
+
+import (
+	"context"
+	"sync"
+)
+
+// Kill stops the pool without draining it. Workers are signalled right away,
+// results of tasks still being handled are discarded, and the tasks that were
+// still queued are returned.
+func (p *GorkPool[Id, Task, Result]) Kill() []Task {
+	p.killOnce.Do(func() {
+		close(p.kill)
+	})
+	return p.abortQueue()
+}
+
+// Drain stops the pool, returning the tasks that were still queued instead of
+// processing them, and waits for in-flight tasks to finish. If ctx is done
+// first it returns an ErrShutdownTimeout along with the queued tasks.
+func (p *GorkPool[Id, Task, Result]) Drain(ctx context.Context) ([]Task, error) {
+	leftovers := p.abortQueue()
+
+	select {
+	case <-p.done:
+		return leftovers, nil
+	case <-ctx.Done():
+		return leftovers, NewErrShutdownTimeout(p.runningWorkers(), ctx.Err())
+	}
+}
+
+// abortQueue shuts the pool down and takes whatever was still queued.
+func (p *GorkPool[Id, Task, Result]) abortQueue() []Task {
+	p.abortOnce.Do(func() {
+		close(p.abort)
+	})
+	p.cancel(nil)
+
+	<-p.discarded
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	leftovers := p.leftovers
+	p.leftovers = nil
+	return leftovers
+}
+
+func (p *GorkPool[Id, Task, Result]) isAborted() bool {
+	select {
+	case <-p.abort:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) isKilled() bool {
+	select {
+	case <-p.kill:
+		return true
+	default:
+		return false
+	}
+}
+
+// discard empties the queue, the input channel and parked into p.leftovers.
+func (p *GorkPool[Id, Task, Result]) discard(parked []*envelope[Task, Result]) {
+	var leftovers []Task
+	for _, env := range parked {
+		p.abandon(env)
+		leftovers = append(leftovers, env.task)
+	}
+	for {
+		env, ok := p.queue.pop()
+		if !ok {
+			break
+		}
+		p.abandon(env)
+		leftovers = append(leftovers, env.task)
+	}
+
+	for !p.cfg.externalChannels {
+		select {
+		case task := <-p.inputCh:
+			leftovers = append(leftovers, task)
+			continue
+		default:
+		}
+		break
+	}
+
+	if p.perWorkerQueues() {
+		leftovers = p.discardWorkerQueues(leftovers)
+	}
+
+	p.mutex.Lock()
+	p.leftovers = leftovers
+	for _, task := range leftovers {
+		p.report.Abandoned = append(p.report.Abandoned, task)
+	}
+	p.mutex.Unlock()
+}
+
+// stopWorkers signals every channel worker, TaskHandler worker or both still
+// running at once, returning when all of them got the signal.
+func (p *GorkPool[Id, Task, Result]) stopWorkers(channels bool, handlers bool) {
+	p.mutex.Lock()
+	targets := make([]*workerState[Id, Task, Result], 0, p.workers.len())
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if (ws.handler != nil && !handlers) || (ws.handler == nil && !channels) {
+			return true
+		}
+		if !ws.exited() {
+			targets = append(targets, ws)
+		}
+		return true
+	})
+	p.mutex.Unlock()
+
+	wg := &sync.WaitGroup{}
+	for _, ws := range targets {
+		wg.Add(1)
+		ws := ws
+		p.launch("kill", func() {
+			defer wg.Done()
+			select {
+			case <-ws.done:
+			default:
+				<-p.signal(ws)
+			}
+		})
+	}
+	wg.Wait()
+}
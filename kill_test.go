@@ -0,0 +1,100 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestKill(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	defer cancel()
+	for i := 0; i < 15; i++ {
+		pool.AddTask(i)
+	}
+	// Action
+	leftovers := pool.Kill()
+	// Assert
+	sort.Ints(leftovers)
+	if len(leftovers) != 15 {
+		t.Fatalf("expected %d tasks to be returned, got %v", 15, leftovers)
+	}
+	for i, task := range leftovers {
+		if task != i {
+			t.Errorf("expected task %d to be returned, got %d", i, task)
+		}
+	}
+	if _, ok := <-pool.OutputCh(); ok {
+		t.Error("expected output channel to be closed")
+	}
+}
+
+func TestKillInFlight(t *testing.T) {
+	// Setup
+	started := make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return -x, nil
+	})
+	defer cancel()
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-started
+	// Action
+	done := make(chan struct{})
+	go func() {
+		pool.Kill()
+		close(done)
+	}()
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Kill to return promptly")
+	}
+	if _, ok := <-pool.OutputCh(); ok {
+		t.Error("expected in-flight result to be discarded and output channel closed")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	// Setup
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if x == 0 {
+			close(started)
+			<-release
+		}
+		return -x, nil
+	})
+	defer cancel()
+	pool.AddWorker(0)
+	for i := 0; i < 5; i++ {
+		pool.AddTask(i)
+	}
+	<-started
+	// Action
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	leftovers, err := pool.Drain(context.Background())
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	sort.Ints(leftovers)
+	if len(leftovers) != 4 || leftovers[0] != 1 || leftovers[3] != 4 {
+		t.Errorf("expected queued tasks 1 to 4 to be returned, got %v", leftovers)
+	}
+	if got := <-pool.OutputCh(); got != 0 {
+		t.Errorf("expected in-flight task result %d, got %d", 0, got)
+	}
+	if _, ok := <-pool.OutputCh(); ok {
+		t.Error("expected output channel to be closed")
+	}
+}
@@ -0,0 +1,109 @@
+package gorkpool
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// latencySamples is how many queue waits the latency scaler keeps per round.
+const latencySamples = 1024
+
+type latencyScaler[Id comparable] struct {
+	objective time.Duration
+	interval  time.Duration
+	nextID    func() Id
+	// waits are the queue waits sampled since the last round
+	waits []time.Duration
+}
+
+// ScaleForLatency keeps the 95th percentile of how long tasks wait in the
+// queue under objective. Every interval it adds a worker, with the id nextID
+// returns, if tasks waited longer, and removes one if they waited less than
+// half of it with nothing left in the queue, within the bounds set by
+// WithMinWorkers and WithMaxWorkers. Calling it again changes the objective.
+func (p *GorkPool[Id, Task, Result]) ScaleForLatency(objective time.Duration, interval time.Duration, nextID func() Id) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	started := p.latency != nil
+	p.latency = &latencyScaler[Id]{objective: objective, interval: interval, nextID: nextID}
+	if !started && p.state == StateRunning {
+		ctx := p.ctx
+		p.launch("latency scaling", func() {
+			p.scaleForLatency(ctx)
+		})
+	}
+}
+
+// sampleWait records how long a task waited in the queue until dispatched.
+func (p *GorkPool[Id, Task, Result]) sampleWait(wait time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.queueWait.observe(wait)
+	if p.latency == nil || len(p.latency.waits) >= latencySamples {
+		return
+	}
+	p.latency.waits = append(p.latency.waits, wait)
+}
+
+func (p *GorkPool[Id, Task, Result]) scaleForLatency(ctx context.Context) {
+	for {
+		p.mutex.Lock()
+		interval := p.latency.interval
+		p.mutex.Unlock()
+
+		timer := p.cfg.clock.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		queued := p.QueueLen()
+		p.mutex.Lock()
+		scaler := p.latency
+		waits := scaler.waits
+		scaler.waits = nil
+		p.mutex.Unlock()
+
+		p95 := percentile(waits, 0.95)
+		switch {
+		case p95 > scaler.objective || (len(waits) == 0 && queued > 0):
+			p.scaleUp(scaler.nextID)
+		case p95 < scaler.objective/2 && queued == 0:
+			p.RemoveWorker()
+		}
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) scaleUp(nextID func() Id) {
+	p.mutex.Lock()
+	full := p.cfg.maxWorkers > 0 && p.workers.len() >= p.cfg.maxWorkers
+	p.mutex.Unlock()
+	if full {
+		return
+	}
+
+	id := nextID()
+	if err := p.AddWorker(id); err != nil {
+		p.reportErr(NewErrWorker(id, err))
+	}
+}
+
+// percentile is the q quantile of samples, zero without samples.
+func percentile(samples []time.Duration, q float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
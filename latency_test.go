@@ -0,0 +1,36 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestScaleForLatency(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return -x, nil
+	}, gorkpool.WithMinWorkers(1), gorkpool.WithMaxWorkers(3), gorkpool.WithQueueSize(100), gorkpool.WithOutputBufferSize(100))
+	next := 0
+	pool.ScaleForLatency(time.Millisecond, 10*time.Millisecond, func() int {
+		next++
+		return next
+	})
+	// Action
+	for i := 0; i < 100; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	waitFor(t, func() bool { return pool.Length() == 3 })
+	for i := 0; i < 100; i++ {
+		<-pool.OutputCh()
+	}
+	waitFor(t, func() bool { return pool.Length() == 1 })
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
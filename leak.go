@@ -0,0 +1,78 @@
+package gorkpool
+
+import (
+	"context"
+	"sync"
+)
+
+// goroutines keeps count of the goroutines a pool starts, by kind.
+type goroutines struct {
+	mutex   *sync.Mutex
+	running map[string]int
+	// exited is signaled whenever one of them returns
+	exited chan struct{}
+}
+
+func newGoroutines() *goroutines {
+	return &goroutines{
+		mutex:   &sync.Mutex{},
+		running: make(map[string]int),
+		exited:  make(chan struct{}, 1),
+	}
+}
+
+// launch runs fn on a goroutine of its own, keeping count of it as a kind one.
+func (p *GorkPool[Id, Task, Result]) launch(kind string, fn func()) {
+	g := p.goroutines
+	g.mutex.Lock()
+	g.running[kind]++
+	g.mutex.Unlock()
+	go func() {
+		defer func() {
+			g.mutex.Lock()
+			if g.running[kind]--; g.running[kind] == 0 {
+				delete(g.running, kind)
+			}
+			g.mutex.Unlock()
+			select {
+			case g.exited <- struct{}{}:
+			default:
+			}
+		}()
+		fn()
+	}()
+}
+
+// snapshot returns how many goroutines of each kind are running, nil if none.
+func (g *goroutines) snapshot() map[string]int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if len(g.running) == 0 {
+		return nil
+	}
+	running := make(map[string]int, len(g.running))
+	for kind, n := range g.running {
+		running[kind] = n
+	}
+	return running
+}
+
+// VerifyClean waits for every goroutine the pool started, those of its
+// workers included, to return, for tests to check nothing is left behind once
+// the pool is shut down. It fails with ErrGoroutineLeak, telling how many of
+// each kind are still running, once ctx is done. Goroutines started by workers
+// themselves, like those of ProcessWorker waiting for their child, aren't
+// accounted for, nor are those of a PoolManager.
+func (p *GorkPool[Id, Task, Result]) VerifyClean(ctx context.Context) error {
+	for {
+		running := p.goroutines.snapshot()
+		if running == nil {
+			return nil
+		}
+		select {
+		case <-p.goroutines.exited:
+		case <-ctx.Done():
+			return NewErrGoroutineLeak(running)
+		}
+	}
+}
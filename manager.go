@@ -0,0 +1,154 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ManagedPool is what a PoolManager needs from the pools it manages, which
+// every GorkPool is whatever its type parameters.
+type ManagedPool interface {
+	State() State
+	Length() int
+	QueueLen() int
+	InFlight() int
+	Stats() Stats
+	Shutdown(ctx context.Context) error
+}
+
+// PoolManager keeps pools by name, for applications running many of them.
+type PoolManager struct {
+	mutex *sync.Mutex
+	pools map[string]ManagedPool
+}
+
+// PoolSummary is the state of a managed pool.
+type PoolSummary struct {
+	State    State
+	Workers  int
+	Queued   int
+	InFlight int
+	Stats    Stats
+}
+
+// ManagerStats adds up the summaries of the managed pools.
+type ManagerStats struct {
+	Pools    map[string]PoolSummary
+	Workers  int
+	Queued   int
+	InFlight int
+}
+
+func NewPoolManager() *PoolManager {
+	return &PoolManager{
+		mutex: &sync.Mutex{},
+		pools: make(map[string]ManagedPool),
+	}
+}
+
+// Register adds pool under name, failing with ErrPoolExists if the name is
+// taken.
+func (m *PoolManager) Register(name string, pool ManagedPool) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.pools[name]; ok {
+		return NewErrPoolExists(name)
+	}
+	m.pools[name] = pool
+	return nil
+}
+
+// Unregister removes the pool under name, telling whether there was one. The
+// pool itself keeps running.
+func (m *PoolManager) Unregister(name string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	_, ok := m.pools[name]
+	delete(m.pools, name)
+	return ok
+}
+
+// Lookup returns the pool under name. LookupPool returns it with its type.
+func (m *PoolManager) Lookup(name string) (ManagedPool, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	pool, ok := m.pools[name]
+	return pool, ok
+}
+
+// LookupPool is Lookup for a pool of the given type, returning false if the
+// pool under name is of another type.
+func LookupPool[Id comparable, Task any, Result any](m *PoolManager, name string) (*GorkPool[Id, Task, Result], bool) {
+	pool, ok := m.Lookup(name)
+	if !ok {
+		return nil, false
+	}
+	p, ok := pool.(*GorkPool[Id, Task, Result])
+	return p, ok
+}
+
+// Names returns the names of the managed pools, sorted.
+func (m *PoolManager) Names() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	names := make([]string, 0, len(m.pools))
+	for name := range m.pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Stats summarizes every managed pool.
+func (m *PoolManager) Stats() ManagerStats {
+	pools := m.snapshot()
+	stats := ManagerStats{Pools: make(map[string]PoolSummary, len(pools))}
+	for name, pool := range pools {
+		summary := PoolSummary{
+			State:    pool.State(),
+			Workers:  pool.Length(),
+			Queued:   pool.QueueLen(),
+			InFlight: pool.InFlight(),
+			Stats:    pool.Stats(),
+		}
+		stats.Pools[name] = summary
+		stats.Workers += summary.Workers
+		stats.Queued += summary.Queued
+		stats.InFlight += summary.InFlight
+	}
+	return stats
+}
+
+// ShutdownAll shuts every managed pool down at once, returning the errors of
+// those that didn't in time joined together.
+func (m *PoolManager) ShutdownAll(ctx context.Context) error {
+	pools := m.snapshot()
+	errs := make([]error, 0)
+	mutex := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for name, pool := range pools {
+		wg.Add(1)
+		go func(name string, pool ManagedPool) {
+			defer wg.Done()
+			if err := pool.Shutdown(ctx); err != nil {
+				mutex.Lock()
+				errs = append(errs, NewErrPoolShutdown(name, err))
+				mutex.Unlock()
+			}
+		}(name, pool)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (m *PoolManager) snapshot() map[string]ManagedPool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	pools := make(map[string]ManagedPool, len(m.pools))
+	for name, pool := range m.pools {
+		pools[name] = pool
+	}
+	return pools
+}
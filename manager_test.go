@@ -0,0 +1,41 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestPoolManager(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	m := gorkpool.NewPoolManager()
+	ints := gorkpool.NewFuncPool(ctx, 2, func(x int) int { return -x })
+	strs := gorkpool.NewFuncPool(ctx, 1, func(s string) string { return s + s })
+	// Action
+	m.Register("ints", ints)
+	m.Register("strs", strs)
+	conflict := m.Register("ints", strs)
+	stats := m.Stats()
+	typed, typedOk := gorkpool.LookupPool[int, int, int](m, "ints")
+	_, wrongOk := gorkpool.LookupPool[int, int, int](m, "strs")
+	err := m.ShutdownAll(context.Background())
+	// Assert
+	if !errors.As(conflict, new(gorkpool.ErrPoolExists)) {
+		t.Errorf("expected ErrPoolExists, got %v", conflict)
+	}
+	if names := m.Names(); len(names) != 2 || names[0] != "ints" || names[1] != "strs" {
+		t.Errorf("expected pools ints and strs, got %v", names)
+	}
+	if stats.Workers != 3 || stats.Pools["ints"].Workers != 2 {
+		t.Errorf("expected 3 workers, 2 of them in ints, got %+v", stats)
+	}
+	if !typedOk || typed != ints || wrongOk {
+		t.Error("expected typed lookup to only find pools of its type")
+	}
+	if err != nil || ints.State() != gorkpool.StateStopped || strs.State() != gorkpool.StateStopped {
+		t.Errorf("expected every pool to be shut down, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package gorkpool
+
+import (
+	"context"
+	"time"
+)
+
+// TaskEnvelope is what the pool knows about a task being handled. TaskHandler
+// workers and middleware get it from their context with EnvelopeFrom.
+// Metadata is shared with the pool and must not be changed.
+type TaskEnvelope struct {
+	ID          string
+	SubmittedAt time.Time
+	Attempt     int
+	Priority    int
+	Deadline    time.Time
+	Tenant      string
+	// Trace is the context given to WithTraceContext, if any
+	Trace    context.Context
+	Metadata map[string]string
+}
+
+// WithMetadata attaches a key/value pair to the task, for workers, hooks and
+// middleware to read.
+func WithMetadata(key string, value string) TaskOption {
+	return func(c *taskConfig) {
+		if c.metadata == nil {
+			c.metadata = make(map[string]string)
+		}
+		c.metadata[key] = value
+	}
+}
+
+// WithTraceContext carries ctx, usually the one of the request submitting the
+// task, over to its handling. The values of ctx, like tracing spans, can be
+// looked up in the context of TaskHandler workers, though its cancellation
+// doesn't carry over.
+func WithTraceContext(ctx context.Context) TaskOption {
+	return func(c *taskConfig) {
+		c.trace = ctx
+	}
+}
+
+type envelopeKey struct{}
+
+// EnvelopeFrom returns the TaskEnvelope of the task handled with ctx, if any.
+func EnvelopeFrom(ctx context.Context) (TaskEnvelope, bool) {
+	env, ok := ctx.Value(envelopeKey{}).(TaskEnvelope)
+	return env, ok
+}
+
+// taskValues is the context TaskHandler workers handle a task in, carrying its
+// TaskEnvelope, the values of its trace context and what ReportProgress and
+// Checkpoint need.
+type taskValues struct {
+	context.Context
+	envelope   TaskEnvelope
+	progress   func(Progress)
+	checkpoint checkpoint
+}
+
+func (c *taskValues) Value(key any) any {
+	switch key.(type) {
+	case envelopeKey:
+		return c.envelope
+	case progressKey:
+		if c.progress == nil {
+			return nil
+		}
+		return progressReporter{taskID: c.envelope.ID, fn: c.progress}
+	case checkpointKey:
+		return c.checkpoint
+	}
+	if v := c.Context.Value(key); v != nil || c.envelope.Trace == nil {
+		return v
+	}
+	return c.envelope.Trace.Value(key)
+}
+
+// taskValues wraps ctx into the context env is handled in, kept in env rather
+// than allocated for every task. AckHandler workers get one of their own, their
+// Receive being free to use it once the delivery is settled and env recycled
+// or handled again.
+func (p *GorkPool[Id, Task, Result]) taskValues(ctx context.Context, ws *workerState[Id, Task, Result], env *envelope[Task, Result]) context.Context {
+	values := &env.values
+	if _, ok := ws.handler.(ackHandler[Task, Result]); ok {
+		values = new(taskValues)
+	}
+	*values = taskValues{
+		Context: ctx,
+		envelope: TaskEnvelope{
+			ID:          env.id,
+			SubmittedAt: env.submitted,
+			Attempt:     env.attempt,
+			Priority:    env.priority,
+			Deadline:    env.deadline,
+			Tenant:      env.tenant,
+			Trace:       env.trace,
+			Metadata:    env.metadata,
+		},
+		progress:   p.cfg.onProgress,
+		checkpoint: checkpoint{worker: ws.ctx, draining: p.ctx.Done()},
+	}
+	return values
+}
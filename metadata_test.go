@@ -0,0 +1,73 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type traceKey struct{}
+
+func TestEnvelopeFrom(t *testing.T) {
+	// Setup
+	var envelopes []gorkpool.TaskEnvelope
+	var traced []any
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		env, _ := gorkpool.EnvelopeFrom(ctx)
+		envelopes = append(envelopes, env)
+		traced = append(traced, ctx.Value(traceKey{}))
+		if env.Attempt == 1 {
+			return 0, errors.New("flaky")
+		}
+		return x, nil
+	})
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 2})
+	var hookMetadata map[string]string
+	pool.OnTaskEnd(func(e gorkpool.TaskEvent[int, int, int]) {
+		hookMetadata = e.Metadata
+	})
+	trace := context.WithValue(context.Background(), traceKey{}, "span-1")
+	before := time.Now()
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 1,
+		gorkpool.WithTaskID("job-1"),
+		gorkpool.WithPriority(3),
+		gorkpool.WithMetadata("user", "alice"),
+		gorkpool.WithTraceContext(trace))
+	// Assert
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if len(envelopes) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(envelopes))
+	}
+	env := envelopes[1]
+	if env.ID != "job-1" || env.Attempt != 2 || env.Priority != 3 || env.Metadata["user"] != "alice" {
+		t.Errorf("expected the envelope of job-1 on attempt 2, got %+v", env)
+	}
+	if env.SubmittedAt.Before(before) {
+		t.Errorf("expected the task to be submitted after %v, got %v", before, env.SubmittedAt)
+	}
+	if traced[0] != "span-1" || traced[1] != "span-1" {
+		t.Errorf("expected the trace values in the handler context, got %v", traced)
+	}
+	if hookMetadata["user"] != "alice" {
+		t.Errorf("expected the hooks to see the metadata, got %v", hookMetadata)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestEnvelopeFromOutsideTask(t *testing.T) {
+	// Action
+	_, ok := gorkpool.EnvelopeFrom(context.Background())
+	// Assert
+	if ok {
+		t.Errorf("expected no envelope outside of a task")
+	}
+}
@@ -0,0 +1,23 @@
+package gorkpool
+
+// Middleware wraps the handling of tasks by TaskHandler workers, for concerns
+// shared by all of them such as timing, logging or retries.
+type Middleware[Task any, Result any] func(next TaskHandler[Task, Result]) TaskHandler[Task, Result]
+
+// Use appends mws to the middleware of the pool. The first one is the
+// outermost, and they apply to tasks taken from then on by any TaskHandler
+// worker, including those already in the pool.
+func (p *GorkPool[Id, Task, Result]) Use(mws ...Middleware[Task, Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	middleware := make([]Middleware[Task, Result], 0, len(p.middleware)+len(mws))
+	middleware = append(middleware, p.middleware...)
+	p.middleware = append(middleware, mws...)
+}
+
+func wrap[Task any, Result any](h TaskHandler[Task, Result], middleware []Middleware[Task, Result]) TaskHandler[Task, Result] {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
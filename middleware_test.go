@@ -0,0 +1,46 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestUse(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	var calls []string
+	trace := func(name string) gorkpool.Middleware[int, int] {
+		return func(next gorkpool.TaskHandler[int, int]) gorkpool.TaskHandler[int, int] {
+			return gorkpool.HandlerFunc[int, int](func(ctx context.Context, x int) (int, error) {
+				calls = append(calls, name)
+				return next.Handle(ctx, x)
+			})
+		}
+	}
+	double := func(next gorkpool.TaskHandler[int, int]) gorkpool.TaskHandler[int, int] {
+		return gorkpool.HandlerFunc[int, int](func(ctx context.Context, x int) (int, error) {
+			result, err := next.Handle(ctx, x)
+			return result * 2, err
+		})
+	}
+	// Action
+	pool.Use(trace("outer"), trace("inner"))
+	pool.Use(double)
+	f, _ := pool.Submit(3)
+	got, err := f.Wait(context.Background())
+	// Assert
+	if err != nil || got != -6 {
+		t.Errorf("expected result -6, got %d (%v)", got, err)
+	}
+	if len(calls) != 2 || calls[0] != "outer" || calls[1] != "inner" {
+		t.Errorf("expected middleware to run outer first, got %v", calls)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,188 @@
+package gorkpool
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type config struct {
+	externalChannels bool
+	inputBufferSize  int
+	outputBufferSize int
+	minWorkers       int
+	maxWorkers       int
+	queueSize        int
+	logger           Logger
+	panicHandler     func(any)
+	errorHandler     func(error)
+	removalPolicy    RemovalPolicy
+	dispatchMode     DispatchMode
+	workerQueueSize  int
+	workStealing     bool
+	orderedResults   bool
+	errorChannel     bool
+	errorBufferSize  int
+	backpressure     BackpressurePolicy
+	limiter          *rate.Limiter
+	keyConcurrency   int
+	tenantWeights    map[string]int
+	tenantQuotas     map[string]int
+	dedupWindow      time.Duration
+	// visibilityTimeout is the lease on deliveries to AckHandler workers
+	visibilityTimeout time.Duration
+	healthInterval    time.Duration
+	stallAfter        time.Duration
+	onStall           func()
+	idleTimeout       time.Duration
+	cpuMultiplier     float64
+	expvarName        string
+	profilerLabels    string
+	breaker           CircuitBreaker
+	cancelOnError     bool
+	// taskStore is a TaskStore of the task type of the pool
+	taskStore any
+	inline    bool
+	clock     Clock
+	// batchSize and batchWindow shape the batches of BatchHandler workers
+	batchSize   int
+	batchWindow time.Duration
+	onProgress  func(Progress)
+	hedgeDelay  time.Duration
+	// resultCache is a ResultCache of the result type of the pool
+	resultCache any
+	cacheTTL    time.Duration
+	taskBudget  time.Duration
+	// drainTimeout bounds each of the two phases of a shutdown
+	drainTimeout  time.Duration
+	adaptiveQueue *adaptiveQueue
+	traceRegions  bool
+	readyWorkers  int
+	recentTasks   int
+}
+
+// Logger is what the pool reports recovered panics and dropped tasks to.
+// *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+type Option func(*config)
+
+// WithExternalChannels leaves the input and output channels open when the pool
+// stops, for callers that share them with other producers or consumers. Since
+// workers can't rely on the input channel being closed, the pool signals its
+// channel workers to stop instead, and Kill and Drain don't take the tasks
+// left in the input channel.
+func WithExternalChannels() Option {
+	return func(c *config) {
+		c.externalChannels = true
+	}
+}
+
+// WithInputBufferSize sets the buffer size of the input channel created by
+// NewGorkPoolWithOptions.
+func WithInputBufferSize(n int) Option {
+	return func(c *config) {
+		c.inputBufferSize = n
+	}
+}
+
+// WithOutputBufferSize sets the buffer size of the output channel created by
+// NewGorkPoolWithOptions.
+func WithOutputBufferSize(n int) Option {
+	return func(c *config) {
+		c.outputBufferSize = n
+	}
+}
+
+// WithMinWorkers makes RemoveWorker and RemoveWorkerById refuse to take the
+// pool below n workers.
+func WithMinWorkers(n int) Option {
+	return func(c *config) {
+		c.minWorkers = n
+	}
+}
+
+// WithMaxWorkers makes AddWorker fail with ErrMaxWorkers once the pool has n
+// workers.
+func WithMaxWorkers(n int) Option {
+	return func(c *config) {
+		c.maxWorkers = n
+	}
+}
+
+// WithQueueSize sets how many tasks the pool holds before AddTask blocks.
+// It defaults to the capacity of the input channel.
+func WithQueueSize(n int) Option {
+	return func(c *config) {
+		c.queueSize = n
+	}
+}
+
+func WithLogger(l Logger) Option {
+	return func(c *config) {
+		c.logger = l
+	}
+}
+
+// WithPanicHandler makes the pool recover from worker panics, calling fn with
+// the recovered value. A panicking TaskHandler fails the task with ErrPanic
+// and keeps serving, while a panicking channel worker is removed from the
+// pool.
+func WithPanicHandler(fn func(any)) Option {
+	return func(c *config) {
+		c.panicHandler = fn
+	}
+}
+
+// SetPanicReporter is WithPanicHandler telling fn the id of the panicking
+// worker, the task it was handling, nil if unknown, and the stack trace of
+// the panic, for error trackers. A nil fn stops the reports.
+func (p *GorkPool[Id, Task, Result]) SetPanicReporter(fn func(workerID Id, task any, recovered any, stack []byte)) {
+	if fn == nil {
+		p.panicReporter.Store(nil)
+		return
+	}
+	p.panicReporter.Store(&fn)
+}
+
+// recovers tells whether the pool recovers from worker panics.
+func (p *GorkPool[Id, Task, Result]) recovers() bool {
+	return p.cfg.panicHandler != nil || p.panicReporter.Load() != nil
+}
+
+// WithErrorHandler sets fn to be called with an ErrWorker whenever a Runner
+// worker returns an error or a Lifecycle worker fails to stop.
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.errorHandler = fn
+	}
+}
+
+// WithErrorChannel makes the pool send the errors of failed tasks nobody else
+// waits for to ErrorCh, wrapped in an ErrTaskFailed, instead of dropping them.
+// Like the output channel, ErrorCh must be drained until the pool closes it.
+func WithErrorChannel(bufferSize int) Option {
+	return func(c *config) {
+		c.errorChannel = true
+		c.errorBufferSize = bufferSize
+	}
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+	return c
+}
+
+func (p *GorkPool[Id, Task, Result]) logf(format string, args ...any) {
+	if p.cfg.logger != nil {
+		p.cfg.logger.Printf(format, args...)
+	}
+}
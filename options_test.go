@@ -0,0 +1,245 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithExternalChannels(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	inputCh := make(chan int, 10)
+	outputCh := make(chan int, 10)
+	pool := gorkpool.NewGorkPool(ctx, inputCh, outputCh, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return newTestWorker(id, ic, oc), nil
+	}, gorkpool.WithExternalChannels())
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-outputCh
+	// Action
+	cancel()
+	pool.Wait()
+	// Assert
+	if pool.State() != gorkpool.StateStopped {
+		t.Errorf("expected pool to be %v, got %v", gorkpool.StateStopped, pool.State())
+	}
+	inputCh <- 1
+	outputCh <- 1
+	if got := <-outputCh; got != 1 {
+		t.Errorf("expected output channel to still be usable, got %d", got)
+	}
+}
+
+func TestNewGorkPoolWithOptions(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	var inputCap, outputCap int
+	// Action
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		inputCap, outputCap = cap(ic), cap(oc)
+		return newTestWorker(id, ic, oc), nil
+	}, gorkpool.WithInputBufferSize(4), gorkpool.WithOutputBufferSize(8))
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result %d, got %d", -1, got)
+	}
+	if inputCap != 4 || outputCap != 8 {
+		t.Errorf("expected buffer sizes 4 and 8, got %d and %d", inputCap, outputCap)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithMaxWorkers(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return newTestWorker(id, ic, oc), nil
+	}, gorkpool.WithMaxWorkers(2))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	err := pool.AddWorker(2)
+	// Assert
+	var maxErr gorkpool.ErrMaxWorkers
+	if !errors.As(err, &maxErr) {
+		t.Errorf("expected ErrMaxWorkers, got %v", err)
+	}
+	if pool.Length() != 2 {
+		t.Errorf("expected %d workers, got %d", 2, pool.Length())
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithMinWorkers(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return newTestWorker(id, ic, oc), nil
+	}, gorkpool.WithMinWorkers(1))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	first := pool.RemoveWorker()
+	second := pool.RemoveWorker()
+	// Assert
+	if first == nil {
+		t.Error("expected a worker to be removed")
+	}
+	if second != nil {
+		t.Error("expected removal below the minimum to be refused")
+	}
+	if pool.Length() != 1 {
+		t.Errorf("expected %d worker, got %d", 1, pool.Length())
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithMinMaxWorkersConcurrent(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: double}, nil
+	}, gorkpool.WithMinWorkers(2), gorkpool.WithMaxWorkers(5))
+	var added, removed atomic.Int64
+	run := func(fn func(id int)) {
+		wg := &sync.WaitGroup{}
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 10; i++ {
+					fn(g*10 + i)
+				}
+			}(g)
+		}
+		wg.Wait()
+	}
+	// Action
+	run(func(id int) {
+		if pool.AddWorker(id) == nil {
+			added.Add(1)
+		}
+	})
+	run(func(id int) {
+		if pool.RemoveWorkerById(id) != nil {
+			removed.Add(1)
+		}
+	})
+	// Assert
+	if added.Load() != 5 {
+		t.Errorf("expected %d workers to be added, got %d", 5, added.Load())
+	}
+	if removed.Load() != 3 {
+		t.Errorf("expected %d workers to be removed, got %d", 3, removed.Load())
+	}
+	if pool.Length() != 2 || len(pool.WorkerIDs()) != 2 {
+		t.Errorf("expected %d workers, got %d with ids %v", 2, pool.Length(), pool.WorkerIDs())
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithPanicHandler(t *testing.T) {
+	// Setup
+	recovered := make(chan any, 1)
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			if x == 0 {
+				panic("boom")
+			}
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithPanicHandler(func(v any) { recovered <- v }), gorkpool.WithOutputBufferSize(1))
+	pool.AddWorker(0)
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 0)
+	// Assert
+	var panicErr gorkpool.ErrPanic
+	if !errors.As(err, &panicErr) || panicErr.Value() != "boom" {
+		t.Errorf("expected ErrPanic with value %q, got %v", "boom", err)
+	}
+	if v := <-recovered; v != "boom" {
+		t.Errorf("expected panic handler to get %q, got %v", "boom", v)
+	}
+	if got, err := pool.SubmitWait(context.Background(), 1); err != nil || got != -1 {
+		t.Errorf("expected worker to keep serving, got %d, %v", got, err)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+type panicReport struct {
+	workerID  int
+	task      any
+	recovered any
+	stack     []byte
+}
+
+func TestSetPanicReporter(t *testing.T) {
+	// Setup
+	reports := make(chan panicReport, 1)
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 0, func(ctx context.Context, x int) (int, error) {
+		panic("boom")
+	})
+	pool.AddWorker(3)
+	pool.SetPanicReporter(func(workerID int, task any, recovered any, stack []byte) {
+		reports <- panicReport{workerID, task, recovered, stack}
+	})
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 7)
+	// Assert
+	var panicErr gorkpool.ErrPanic
+	if !errors.As(err, &panicErr) {
+		t.Errorf("expected ErrPanic, got %v", err)
+	}
+	report := <-reports
+	if report.workerID != 3 || report.task != 7 || report.recovered != "boom" {
+		t.Errorf("expected worker 3 to panic with %q on task 7, got %+v", "boom", report)
+	}
+	if !strings.Contains(string(report.stack), "TestSetPanicReporter") {
+		t.Errorf("expected the stack trace of the panic, got %s", report.stack)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithErrorChannel(t *testing.T) {
+	// Setup
+	failure := errors.New("odd task")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			if x%2 == 1 {
+				return 0, failure
+			}
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithErrorChannel(1), gorkpool.WithOutputBufferSize(1))
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	pool.AddTask(2)
+	// Assert
+	err := <-pool.ErrorCh()
+	var taskErr gorkpool.ErrTaskFailed
+	if !errors.As(err, &taskErr) || taskErr.Task() != 1 || !errors.Is(err, failure) {
+		t.Errorf("expected failure of task 1, got %v", err)
+	}
+	if got := <-pool.OutputCh(); got != -2 {
+		t.Errorf("expected result %d, got %d", -2, got)
+	}
+	cancel()
+	pool.Wait()
+	if _, ok := <-pool.ErrorCh(); ok {
+		t.Error("expected error channel to be closed")
+	}
+}
@@ -0,0 +1,82 @@
+package gorkpool
+
+import "sync"
+
+// WithOrderedResults makes results show up on OutputCh in the order their
+// tasks were submitted, holding back the ones that complete early. Failed,
+// cancelled and abandoned tasks are skipped. Since channel workers write to
+// OutputCh themselves, ordered tasks only go to TaskHandler workers.
+func WithOrderedResults() Option {
+	return func(c *config) {
+		c.orderedResults = true
+	}
+}
+
+type orderedResult[Result any] struct {
+	result Result
+	ok     bool
+}
+
+// reorderer sends results to the output channel in the order they were
+// numbered.
+type reorderer[Result any] struct {
+	mutex   *sync.Mutex
+	last    uint64
+	next    uint64
+	pending map[uint64]orderedResult[Result]
+}
+
+func newReorderer[Result any]() *reorderer[Result] {
+	return &reorderer[Result]{
+		mutex:   &sync.Mutex{},
+		next:    1,
+		pending: make(map[uint64]orderedResult[Result]),
+	}
+}
+
+func (r *reorderer[Result]) assign() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.last++
+	return r.last
+}
+
+// put records the outcome of the n-th task, sending every result that is no
+// longer waiting for an earlier one. Only the first outcome of each task
+// counts.
+func (r *reorderer[Result]) put(n uint64, result Result, ok bool, send func(Result)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, seen := r.pending[n]; seen || n < r.next {
+		return
+	}
+
+	r.pending[n] = orderedResult[Result]{result: result, ok: ok}
+	for {
+		res, ready := r.pending[r.next]
+		if !ready {
+			return
+		}
+		delete(r.pending, r.next)
+		r.next++
+		if res.ok {
+			send(res.result)
+		}
+	}
+}
+
+// numberResult gives env its place in the output order, if results are
+// ordered and nobody else is waiting for it.
+func (p *GorkPool[Id, Task, Result]) numberResult(env *envelope[Task, Result]) {
+	if p.order != nil && env.order == 0 && env.future == nil {
+		env.order = p.order.assign()
+	}
+}
+
+// skipResult gives up the place of env in the output order.
+func (p *GorkPool[Id, Task, Result]) skipResult(env *envelope[Task, Result]) {
+	if env.order > 0 {
+		var zero Result
+		p.order.put(env.order, zero, false, p.output)
+	}
+}
@@ -0,0 +1,36 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithOrderedResults(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			time.Sleep(time.Duration(5-x) * 5 * time.Millisecond)
+			return x, nil
+		}}, nil
+	}, gorkpool.WithOrderedResults(), gorkpool.WithInputBufferSize(5))
+	for i := 0; i < 5; i++ {
+		pool.AddWorker(i)
+	}
+	// Action
+	for i := 0; i < 5; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	for i := 0; i < 5; i++ {
+		if got := <-pool.OutputCh(); got != i {
+			t.Errorf("expected result %d, got %d", i, got)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,114 @@
+package gorkpool
+
+// Pause stops the pool from handing its queued tasks to the workers until
+// Resume, for maintenance windows or to hold back work manually. Tasks are
+// still accepted and queued meanwhile, and the ones already taken by workers
+// run to completion. Shutdown drains even a paused pool.
+func (p *GorkPool[Id, Task, Result]) Pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+		p.notifyWorkersChanged()
+	}
+}
+
+// Resume lets a paused pool dispatch its tasks again.
+func (p *GorkPool[Id, Task, Result]) Resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.resumed != nil {
+		close(p.resumed)
+		p.resumed = nil
+		// Time spent paused isn't a stall
+		p.lastProgress = p.cfg.clock.Now()
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) Paused() bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.resumed != nil
+}
+
+// unpaused waits for the pool to be resumed, if paused, returning false if
+// done is closed first.
+func (p *GorkPool[Id, Task, Result]) unpaused(done <-chan struct{}) bool {
+	p.mutex.RLock()
+	resumed := p.resumed
+	p.mutex.RUnlock()
+	if resumed == nil {
+		return true
+	}
+	select {
+	case <-resumed:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// PauseWorker takes the worker with id out of rotation until ResumeWorker,
+// without removing it and losing its state, e.g. while its connection is
+// re-established. It finishes the tasks it already took, and the ones handed
+// to it with SubmitTo or Broadcast wait for it to resume. Paused workers are
+// resumed when the pool shuts down, to drain it. Only TaskHandler workers
+// and, with per worker queues, channel workers can be paused, ErrWorkerNotFound
+// is returned for the others as for workers not in the pool.
+func (p *GorkPool[Id, Task, Result]) PauseWorker(id Id) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	ws, ok := p.workers.get(id)
+	if !ok || (ws.handler == nil && ws.inputCh == nil) {
+		return NewErrWorkerNotFound(id)
+	}
+	if ws.resumed == nil {
+		ws.resumed = make(chan struct{})
+		select {
+		case ws.pausing <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// ResumeWorker puts a worker paused with PauseWorker back into rotation.
+func (p *GorkPool[Id, Task, Result]) ResumeWorker(id Id) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	ws, ok := p.workers.get(id)
+	if !ok {
+		return NewErrWorkerNotFound(id)
+	}
+	if ws.resumed != nil {
+		close(ws.resumed)
+		ws.resumed = nil
+		p.notifyWorkersChanged()
+	}
+	return nil
+}
+
+// paused tells whether ws was paused with PauseWorker. The caller must hold
+// p.mutex.
+func (ws *workerState[Id, Task, Result]) paused() bool {
+	return ws.resumed != nil
+}
+
+// workerUnpaused waits for ws to be resumed, if paused, telling whether it was
+// before ws was stopped.
+func (p *GorkPool[Id, Task, Result]) workerUnpaused(ws *workerState[Id, Task, Result]) bool {
+	p.mutex.RLock()
+	resumed := ws.resumed
+	p.mutex.RUnlock()
+	if resumed == nil {
+		return true
+	}
+	select {
+	case <-resumed:
+		return true
+	case <-p.ctx.Done():
+		return true
+	case <-ws.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+func TestPauseResume(t *testing.T) {
+	// Setup
+	var handled atomic.Int64
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 2, func(ctx context.Context, x int) (int, error) {
+		handled.Add(1)
+		return x, nil
+	}, gorkpool.WithQueueSize(10), gorkpool.WithOutputBufferSize(10))
+	// Action
+	pool.Pause()
+	for i := 0; i < 5; i++ {
+		pool.AddTask(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	// Assert
+	if !pool.Paused() {
+		t.Errorf("expected pool to be paused")
+	}
+	if n := handled.Load(); n != 0 {
+		t.Errorf("expected no tasks handled while paused, got %d", n)
+	}
+	if n := pool.QueueLen(); n != 5 {
+		t.Errorf("expected %d tasks queued while paused, got %d", 5, n)
+	}
+	// Action
+	pool.Resume()
+	for i := 0; i < 5; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	if pool.Paused() {
+		t.Errorf("expected pool to be resumed")
+	}
+	if n := handled.Load(); n != 5 {
+		t.Errorf("expected %d tasks handled once resumed, got %d", 5, n)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestShutdownPaused(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, func(ctx context.Context, x int) (int, error) {
+		return x, nil
+	}, gorkpool.WithQueueSize(10), gorkpool.WithOutputBufferSize(10))
+	pool.Pause()
+	for i := 0; i < 5; i++ {
+		pool.AddTask(i)
+	}
+	// Action
+	err := pool.Shutdown(context.Background())
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	n := 0
+	for range pool.OutputCh() {
+		n++
+	}
+	if n != 5 {
+		t.Errorf("expected paused pool to be drained, got %d of %d results", n, 5)
+	}
+}
+
+func TestPauseWorker(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	workers := map[int]*gorkpooltest.Recorder[int, int, int]{}
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		workers[id] = gorkpooltest.NewRecorder[int](id, double)
+		return workers[id], nil
+	}, gorkpool.WithOutputBufferSize(10))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	if err := pool.PauseWorker(0); err != nil {
+		t.Fatalf("expected worker 0 to be paused, got %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		pool.AddTask(i)
+	}
+	for i := 0; i < 5; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	gorkpooltest.AssertProcessedCount(t, workers[0], 0)
+	gorkpooltest.AssertProcessedCount(t, workers[1], 5)
+	// Action
+	pool.PauseWorker(1)
+	pool.ResumeWorker(0)
+	pool.AddTask(6)
+	<-pool.OutputCh()
+	// Assert
+	gorkpooltest.AssertProcessed(t, workers[0], 6)
+	var notFound gorkpool.ErrWorkerNotFound
+	if err := pool.PauseWorker(7); !errors.As(err, &notFound) {
+		t.Errorf("expected ErrWorkerNotFound for worker 7, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,40 @@
+package gorkpool
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+)
+
+// WithProfilerLabels labels the goroutines of the workers with the pool name
+// and worker id, and the handling of each task by a TaskHandler worker with
+// the task type, so that profiles can be sliced by them with the pool, worker
+// and task labels.
+func WithProfilerLabels(pool string) Option {
+	return func(c *config) {
+		c.profilerLabels = pool
+	}
+}
+
+// labelWorker labels the goroutine of ws, which it must be called from.
+func (p *GorkPool[Id, Task, Result]) labelWorker(id Id, ws *workerState[Id, Task, Result]) {
+	ws.labels = pprof.WithLabels(context.Background(), pprof.Labels(
+		"pool", p.cfg.profilerLabels,
+		"worker", fmt.Sprint(id),
+	))
+	pprof.SetGoroutineLabels(ws.labels)
+}
+
+// labelTask adds the type of task to the labels of the goroutine of ws until
+// unlabelTask is called.
+func (ws *workerState[Id, Task, Result]) labelTask(task Task) {
+	if ws.labels != nil {
+		pprof.SetGoroutineLabels(pprof.WithLabels(ws.labels, pprof.Labels("task", fmt.Sprintf("%T", task))))
+	}
+}
+
+func (ws *workerState[Id, Task, Result]) unlabelTask() {
+	if ws.labels != nil {
+		pprof.SetGoroutineLabels(ws.labels)
+	}
+}
@@ -0,0 +1,38 @@
+package gorkpool_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithProfilerLabels(t *testing.T) {
+	// Setup
+	started, release := make(chan struct{}), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		close(started)
+		<-release
+		return -x, nil
+	}, gorkpool.WithProfilerLabels("labeled"))
+	f, _ := pool.Submit(1)
+	<-started
+	// Action
+	var buf bytes.Buffer
+	pprof.Lookup("goroutine").WriteTo(&buf, 1)
+	close(release)
+	f.Wait(context.Background())
+	// Assert
+	for _, label := range []string{`"pool":"labeled"`, `"worker":"0"`, `"task":"int"`} {
+		if !strings.Contains(buf.String(), label) {
+			t.Errorf("expected goroutine profile to have label %s", label)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,55 @@
+package gorkpool
+
+import "context"
+
+// PreemptReason tells why Checkpoint asks a task to stop.
+type PreemptReason int
+
+const (
+	// PreemptCanceled tasks were cancelled or ran past their deadline.
+	PreemptCanceled PreemptReason = iota + 1
+	// PreemptRemoved tasks are handled by a worker being removed. They are
+	// queued again for another worker once they return the ErrPreempted.
+	PreemptRemoved
+	// PreemptDraining tasks are handled while the pool shuts down, for tasks
+	// that would rather save their progress than hold the shutdown up.
+	PreemptDraining
+)
+
+var preemptReasons = [...]string{"", "canceled", "removed", "draining"}
+
+func (r PreemptReason) String() string {
+	if r <= 0 || int(r) >= len(preemptReasons) {
+		return "unknown"
+	}
+	return preemptReasons[r]
+}
+
+type checkpointKey struct{}
+
+// checkpoint is what Checkpoint looks at besides the context of the task.
+type checkpoint struct {
+	worker   context.Context
+	draining <-chan struct{}
+}
+
+// Checkpoint tells whether the task handled with ctx should stop early, for
+// Handle to call every now and then on long tasks. It returns nil for the task
+// to go on, or an ErrPreempted telling why it should stop, for Handle to
+// return once the task is left in a safe state. Outside of TaskHandler
+// workers, as in batches, it only tells whether ctx is done.
+func Checkpoint(ctx context.Context) error {
+	c, _ := ctx.Value(checkpointKey{}).(checkpoint)
+	switch {
+	case c.worker != nil && c.worker.Err() != nil:
+		return NewErrPreempted(PreemptRemoved, c.worker.Err())
+	case ctx.Err() != nil:
+		return NewErrPreempted(PreemptCanceled, ctx.Err())
+	}
+	select {
+	case <-c.draining:
+		return NewErrPreempted(PreemptDraining, nil)
+	default:
+		return nil
+	}
+}
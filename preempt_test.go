@@ -0,0 +1,86 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+// preemptible handles its first task until Checkpoint tells it to stop,
+// reporting why, and the others right away.
+func preemptible(started chan struct{}, reasons chan gorkpool.PreemptReason) gorkpool.HandlerFunc[int, int] {
+	return func(ctx context.Context, x int) (int, error) {
+		select {
+		case <-started:
+			return x, nil
+		default:
+			close(started)
+		}
+		for {
+			var preempted gorkpool.ErrPreempted
+			if err := gorkpool.Checkpoint(ctx); errors.As(err, &preempted) {
+				reasons <- preempted.Reason()
+				return 0, err
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestCheckpointRemoved(t *testing.T) {
+	// Setup
+	started, reasons := make(chan struct{}), make(chan gorkpool.PreemptReason, 1)
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, preemptible(started, reasons))
+	f, _ := pool.Submit(1)
+	<-started
+	pool.AddWorker(1)
+	// Action
+	pool.RemoveWorkerById(0)
+	result, err := f.Wait(context.Background())
+	// Assert
+	if reason := <-reasons; reason != gorkpool.PreemptRemoved {
+		t.Errorf("expected the task to be preempted by the removal, got %v", reason)
+	}
+	if err != nil || result != 1 {
+		t.Errorf("expected the task to be handled again by another worker, got %d and %v", result, err)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestCheckpointDraining(t *testing.T) {
+	// Setup
+	started, reasons := make(chan struct{}), make(chan gorkpool.PreemptReason, 1)
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, preemptible(started, reasons))
+	f, _ := pool.Submit(1)
+	<-started
+	// Action
+	pool.Shutdown(context.Background())
+	_, err := f.Wait(context.Background())
+	// Assert
+	if reason := <-reasons; reason != gorkpool.PreemptDraining {
+		t.Errorf("expected the task to be preempted by the shutdown, got %v", reason)
+	}
+	if !errors.Is(err, gorkpool.CodePreempted) {
+		t.Errorf("expected the task to fail with ErrPreempted, got %v", err)
+	}
+}
+
+func TestCheckpointCanceled(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	// Action
+	cancel()
+	err := gorkpool.Checkpoint(ctx)
+	// Assert
+	var preempted gorkpool.ErrPreempted
+	if !errors.As(err, &preempted) || preempted.Reason() != gorkpool.PreemptCanceled || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled task to be preempted, got %v", err)
+	}
+	if err := gorkpool.Checkpoint(context.Background()); err != nil {
+		t.Errorf("expected no preemption, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package gorkpool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// Codec frames the messages exchanged with the child of a ProcessWorker.
+type Codec interface {
+	Encode(w io.Writer, v any) error
+	Decode(r *bufio.Reader, v any) error
+}
+
+type jsonLines struct{}
+
+func (jsonLines) Encode(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func (jsonLines) Decode(r *bufio.Reader, v any) error {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(line, v)
+}
+
+type lengthPrefixedGob struct{}
+
+func (lengthPrefixedGob) Encode(w io.Writer, v any) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(buf.Len()))
+	_, err := w.Write(append(size[:], buf.Bytes()...))
+	return err
+}
+
+func (lengthPrefixedGob) Decode(r *bufio.Reader, v any) error {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return err
+	}
+	data := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+var (
+	// JSONLines writes each message as a line of JSON.
+	JSONLines Codec = jsonLines{}
+	// LengthPrefixedGob writes each message gob encoded on its own, after its
+	// size as a big endian uint32.
+	LengthPrefixedGob Codec = lengthPrefixedGob{}
+)
+
+// ProcessReply is what the child of a ProcessWorker answers each task with.
+type ProcessReply[Result any] struct {
+	Result Result `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ProcessWorker is a TaskHandler worker running a child process, which it
+// writes each task to on stdin and reads a ProcessReply from on stdout, one at
+// a time, framed by its Codec. The child's stderr is the host's.
+//
+// A task the child exits or is killed on fails with ErrProcessExited and the
+// worker is replaced, so the factory should create a new ProcessWorker. Tasks
+// are killed with the child when their context is done.
+type ProcessWorker[Id comparable, Task any, Result any] struct {
+	id    Id
+	codec Codec
+	name  string
+	args  []string
+
+	// mutex lets a single task at a time talk to the child
+	mutex  sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	pipe   *os.File
+	stdout *bufio.Reader
+	exited chan struct{}
+	err    error
+}
+
+func NewProcessWorker[Id comparable, Task any, Result any](id Id, codec Codec, name string, args ...string) *ProcessWorker[Id, Task, Result] {
+	return &ProcessWorker[Id, Task, Result]{
+		id:    id,
+		codec: codec,
+		name:  name,
+		args:  args,
+	}
+}
+
+func (w *ProcessWorker[Id, Task, Result]) ID() Id {
+	return w.id
+}
+
+func (w *ProcessWorker[Id, Task, Result]) Process() {}
+
+func (w *ProcessWorker[Id, Task, Result]) SignalRemoval() {}
+
+func (w *ProcessWorker[Id, Task, Result]) Start() error {
+	cmd := exec.Command(w.name, w.args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	// Not StdoutPipe, which Wait closes with the last reply maybe unread
+	stdout, child, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = child
+	err = cmd.Start()
+	child.Close()
+	if err != nil {
+		stdout.Close()
+		return err
+	}
+
+	w.cmd, w.stdin, w.pipe, w.stdout = cmd, stdin, stdout, bufio.NewReader(stdout)
+	w.exited = make(chan struct{})
+	go func() {
+		// err is only read once exited is closed
+		w.err = cmd.Wait()
+		close(w.exited)
+	}()
+	return nil
+}
+
+// Stop closes the child's stdin and waits for it to exit, killing it if ctx is
+// done first.
+func (w *ProcessWorker[Id, Task, Result]) Stop(ctx context.Context) error {
+	w.stdin.Close()
+	defer w.pipe.Close()
+	select {
+	case <-w.exited:
+		return nil
+	case <-ctx.Done():
+		w.cmd.Process.Kill()
+		<-w.exited
+		return ctx.Err()
+	}
+}
+
+func (w *ProcessWorker[Id, Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	var reply ProcessReply[Result]
+	select {
+	case <-w.exited:
+		// It died on an earlier task, this one isn't to blame
+		return reply.Result, NewErrConnLost(NewErrProcessExited(w.err))
+	default:
+	}
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	defer func() {
+		close(stop)
+		// The context of the task is cancelled once it is done, which mustn't
+		// kill the child for the next one
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			w.cmd.Process.Kill()
+		case <-stop:
+		}
+	}()
+
+	err := w.codec.Encode(w.stdin, task)
+	if err == nil {
+		err = w.codec.Decode(w.stdout, &reply)
+	}
+	if err != nil {
+		// Whatever went wrong, the stream can't be trusted anymore
+		w.cmd.Process.Kill()
+		<-w.exited
+		if ctx.Err() != nil {
+			return reply.Result, ctx.Err()
+		}
+		return reply.Result, NewErrProcessExited(w.err)
+	}
+	if reply.Error != "" {
+		return reply.Result, errors.New(reply.Error)
+	}
+	return reply.Result, nil
+}
@@ -0,0 +1,97 @@
+package gorkpool_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+// TestHelperProcess is the child of the ProcessWorker tests. It negates
+// positive tasks, fails negative ones and exits on zero.
+func TestHelperProcess(t *testing.T) {
+	name := os.Getenv("GORKPOOL_HELPER_CODEC")
+	if name == "" {
+		return
+	}
+	codec := gorkpool.JSONLines
+	if name == "gob" {
+		codec = gorkpool.LengthPrefixedGob
+	}
+
+	r := bufio.NewReader(os.Stdin)
+	for {
+		var task int
+		if err := codec.Decode(r, &task); err != nil {
+			os.Exit(0)
+		}
+		var reply gorkpool.ProcessReply[int]
+		switch {
+		case task == 0:
+			os.Exit(3)
+		case task < 0:
+			reply.Error = "negative"
+		default:
+			reply.Result = -task
+		}
+		codec.Encode(os.Stdout, reply)
+	}
+}
+
+func setupProcessPool(t *testing.T, codec string) (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	t.Setenv("GORKPOOL_HELPER_CODEC", codec)
+	c := gorkpool.JSONLines
+	if codec == "gob" {
+		c = gorkpool.LengthPrefixedGob
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return gorkpool.NewProcessWorker[int, int, int](id, c, os.Args[0], "-test.run=^TestHelperProcess$"), nil
+	})
+	if err := pool.AddWorker(0); err != nil {
+		t.Fatalf("expected worker process to start, got %v", err)
+	}
+	return pool, cancel
+}
+
+func TestProcessWorker(t *testing.T) {
+	for _, codec := range []string{"json", "gob"} {
+		// Setup
+		pool, cancel := setupProcessPool(t, codec)
+		// Action
+		result, err := pool.SubmitWait(context.Background(), 4)
+		_, taskErr := pool.SubmitWait(context.Background(), -4)
+		// Assert
+		if err != nil || result != -4 {
+			t.Errorf("expected %s result %d, got %d and %v", codec, -4, result, err)
+		}
+		if taskErr == nil || taskErr.Error() != "negative" {
+			t.Errorf("expected %s task error from the child, got %v", codec, taskErr)
+		}
+		// Cleanup
+		cancel()
+		pool.Wait()
+	}
+}
+
+func TestProcessWorkerExited(t *testing.T) {
+	// Setup
+	pool, cancel := setupProcessPool(t, "json")
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 0)
+	result, next := pool.SubmitWait(context.Background(), 5)
+	// Assert
+	var exited gorkpool.ErrProcessExited
+	if !errors.As(err, &exited) {
+		t.Errorf("expected ErrProcessExited, got %v", err)
+	}
+	if next != nil || result != -5 {
+		t.Errorf("expected the replacement worker to give %d, got %d and %v", -5, result, next)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
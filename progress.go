@@ -0,0 +1,42 @@
+package gorkpool
+
+import "context"
+
+// Progress is an update on a task reported with ReportProgress. TaskID is the
+// id the task was submitted with, see WithTaskID, empty if it had none.
+type Progress struct {
+	TaskID  string
+	Percent float64
+	Message string
+}
+
+// WithProgress makes the pool call fn with the progress TaskHandler workers
+// report on their tasks. fn is called on the worker's goroutine, so it should
+// be quick. Batches of BatchHandler workers don't report progress.
+func WithProgress(fn func(Progress)) Option {
+	return func(c *config) {
+		c.onProgress = fn
+	}
+}
+
+type progressKey struct{}
+
+type progressReporter struct {
+	taskID string
+	fn     func(Progress)
+}
+
+// ReportProgress reports how far along the task handled with ctx is, for
+// Handle to call on long tasks. It does nothing unless the pool was created
+// WithProgress.
+func ReportProgress(ctx context.Context, percent float64, message string) {
+	r, ok := ctx.Value(progressKey{}).(progressReporter)
+	if !ok {
+		return
+	}
+	r.fn(Progress{
+		TaskID:  r.taskID,
+		Percent: percent,
+		Message: message,
+	})
+}
@@ -0,0 +1,64 @@
+package gorkpool_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestReportProgress(t *testing.T) {
+	// Setup
+	var (
+		mutex   sync.Mutex
+		updates []gorkpool.Progress
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		gorkpool.ReportProgress(ctx, 50, "halfway")
+		gorkpool.ReportProgress(ctx, 100, "done")
+		return x, nil
+	}, gorkpool.WithProgress(func(p gorkpool.Progress) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		updates = append(updates, p)
+	}))
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 1, gorkpool.WithTaskID("export"))
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []gorkpool.Progress{
+		{TaskID: "export", Percent: 50, Message: "halfway"},
+		{TaskID: "export", Percent: 100, Message: "done"},
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("expected progress %v, got %v", want, updates)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestReportProgressWithoutHandler(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		gorkpool.ReportProgress(ctx, 10, "ignored")
+		return x, nil
+	})
+	// Action
+	result, err := pool.SubmitWait(context.Background(), 3)
+	// Assert
+	if err != nil || result != 3 {
+		t.Errorf("expected result 3, got %d and %v", result, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,399 @@
+package gorkpool
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+type envelope[Task any, Result any] struct {
+	id       string
+	task     Task
+	priority int
+	weight   int
+	attempt  int
+	deadline time.Time
+	expires  time.Time
+	queuedAt time.Time
+	future   *Future[Result]
+	seq      uint64
+	index    int
+	key      string
+	keyed    bool
+	// owned keyed envelopes count against the worker owning their key
+	owned  bool
+	tags   []string
+	tenant string
+	// vtime is the virtual time the tenant's share of the queue gives the
+	// task, see taskQueue.schedule
+	vtime float64
+	// reserved envelopes hold a place in the quota of their tenant
+	reserved bool
+	// limitKey is the concurrency key of the task when keys are limited
+	limitKey string
+	dedupKey string
+	// order is the place of the result in the output when it is ordered
+	order uint64
+	// direct envelopes are handed to a worker by Broadcast, skipping the
+	// queue, and aren't retried or queued again
+	direct bool
+	// submitted, trace and metadata go in the TaskEnvelope of the task
+	submitted time.Time
+	trace     context.Context
+	metadata  map[string]string
+	// parent is the context the task was submitted with, cancelling it
+	parent context.Context
+	// cacheKey is where the result goes in the ResultCache of the pool
+	cacheKey string
+	// values is the context the task is handled in, kept here so that it
+	// doesn't take an allocation of its own
+	values taskValues
+
+	// Guarded by the pool's mutex
+	canceled bool
+	cancel   context.CancelFunc
+	// admitted envelopes count against the limit of their concurrency key
+	admitted bool
+	// waiters is how many submissions share the envelope through its dedup key
+	waiters int
+	// handling envelopes are with a worker, not waiting in the queue or to be
+	// retried
+	handling bool
+}
+
+type taskHeap[Task any, Result any] []*envelope[Task, Result]
+
+// tracked envelopes need a TaskHandler worker to report back their outcome.
+func (env *envelope[Task, Result]) tracked() bool {
+	return env.attempt > 0 || env.future != nil || env.order > 0 || env.limitKey != ""
+}
+
+// pinned envelopes can only go to some of the workers.
+func (env *envelope[Task, Result]) pinned() bool {
+	return env.keyed || len(env.tags) > 0
+}
+
+// alloc takes an envelope for task from the pool's free envelopes.
+func (p *GorkPool[Id, Task, Result]) alloc(task Task) *envelope[Task, Result] {
+	env := p.envelopes.Get().(*envelope[Task, Result])
+	env.task, env.index = task, -1
+	return env
+}
+
+// recycle gives back an envelope a TaskHandler worker is done with. Only those
+// nothing else holds on to are taken: not reachable by CancelTask, a Future,
+// their dedup or concurrency key, the output order or a tenant, and not going
+// through worker queues, which still read them once sent.
+func (p *GorkPool[Id, Task, Result]) recycle(env *envelope[Task, Result]) {
+	if env.id != "" || env.future != nil || env.dedupKey != "" || env.limitKey != "" ||
+		env.order > 0 || env.tenant != "" || p.perWorkerQueues() {
+		return
+	}
+	*env = envelope[Task, Result]{}
+	p.envelopes.Put(env)
+}
+
+func (env *envelope[Task, Result]) expired(now time.Time) bool {
+	return !env.deadline.IsZero() && !now.Before(env.deadline)
+}
+
+func (h taskHeap[Task, Result]) Len() int {
+	return len(h)
+}
+
+func (h taskHeap[Task, Result]) Less(i, j int) bool {
+	// Higher priority first, then fairly across tenants, FIFO otherwise
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	if h[i].vtime != h[j].vtime {
+		return h[i].vtime < h[j].vtime
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h taskHeap[Task, Result]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *taskHeap[Task, Result]) Push(x any) {
+	env := x.(*envelope[Task, Result])
+	env.index = len(*h)
+	*h = append(*h, env)
+}
+
+func (h *taskHeap[Task, Result]) Pop() any {
+	old := *h
+	n := len(old)
+	env := old[n-1]
+	old[n-1] = nil
+	env.index = -1
+	*h = old[:n-1]
+	return env
+}
+
+// taskQueue is the pool's pending queue. A slot is held from push until the
+// envelope is handed to a worker, so a full queue blocks producers the same
+// way a full input channel does.
+type taskQueue[Task any, Result any] struct {
+	mutex *sync.Mutex
+	items taskHeap[Task, Result]
+	seq   uint64
+	slots *slots
+	// onChange is told how many slots are taken whenever that changes
+	onChange func(used int, size int)
+	ready    chan struct{}
+	clock    Clock
+
+	// Tenants sharing the queue, guarded by mutex
+	vtime   float64
+	tenants map[string]*tenantState
+	weights map[string]int
+	quotas  map[string]int
+}
+
+func newTaskQueue[Task any, Result any](size int) *taskQueue[Task, Result] {
+	if size < 1 {
+		size = 1
+	}
+
+	return &taskQueue[Task, Result]{
+		mutex:   &sync.Mutex{},
+		items:   make(taskHeap[Task, Result], 0),
+		slots:   newSlots(size),
+		ready:   make(chan struct{}, 1),
+		tenants: make(map[string]*tenantState),
+	}
+}
+
+// push waits for a free slot, giving up when either done or cancel is closed
+// first. A nil cancel never fires.
+func (q *taskQueue[Task, Result]) push(env *envelope[Task, Result], done <-chan struct{}, cancel <-chan struct{}) bool {
+	select {
+	case <-done:
+		return false
+	case <-cancel:
+		return false
+	default:
+	}
+
+	if !q.slots.acquire(done, cancel) {
+		return false
+	}
+
+	q.changed()
+	q.insert(env)
+	return true
+}
+
+// tryPush is push without waiting for a free slot.
+func (q *taskQueue[Task, Result]) tryPush(env *envelope[Task, Result]) bool {
+	if !q.slots.tryAcquire() {
+		return false
+	}
+
+	q.changed()
+	q.insert(env)
+	return true
+}
+
+func (q *taskQueue[Task, Result]) insert(env *envelope[Task, Result]) {
+	q.mutex.Lock()
+	q.seq++
+	env.seq = q.seq
+	env.queuedAt = q.clock.Now()
+	q.schedule(env)
+	q.enqueued(env)
+	heap.Push(&q.items, env)
+	q.mutex.Unlock()
+
+	q.notify()
+}
+
+func (q *taskQueue[Task, Result]) pop() (*envelope[Task, Result], bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	env := heap.Pop(&q.items).(*envelope[Task, Result])
+	if env.vtime > q.vtime {
+		q.vtime = env.vtime
+	}
+	q.dequeued(env, true)
+	return env, true
+}
+
+// unpop puts back an envelope that was popped but couldn't be delivered.
+func (q *taskQueue[Task, Result]) unpop(env *envelope[Task, Result]) {
+	q.mutex.Lock()
+	q.enqueued(env)
+	heap.Push(&q.items, env)
+	q.mutex.Unlock()
+
+	q.notify()
+}
+
+// remove takes env out of the queue, freeing its slot, if it is still queued.
+func (q *taskQueue[Task, Result]) remove(env *envelope[Task, Result]) bool {
+	q.mutex.Lock()
+	if env.index < 0 || env.index >= len(q.items) || q.items[env.index] != env {
+		q.mutex.Unlock()
+		return false
+	}
+	heap.Remove(&q.items, env.index)
+	q.dequeued(env, true)
+	q.mutex.Unlock()
+
+	q.release()
+	return true
+}
+
+// evictOldest takes out the envelope queued the longest, freeing its slot.
+func (q *taskQueue[Task, Result]) evictOldest() (*envelope[Task, Result], bool) {
+	q.mutex.Lock()
+	if len(q.items) == 0 {
+		q.mutex.Unlock()
+		return nil, false
+	}
+	oldest := q.items[0]
+	for _, env := range q.items[1:] {
+		if env.seq < oldest.seq {
+			oldest = env
+		}
+	}
+	heap.Remove(&q.items, oldest.index)
+	q.dequeued(oldest, true)
+	q.mutex.Unlock()
+
+	q.release()
+	return oldest, true
+}
+
+// release frees the slot held by a delivered envelope.
+func (q *taskQueue[Task, Result]) release() {
+	q.slots.release()
+	q.changed()
+}
+
+func (q *taskQueue[Task, Result]) changed() {
+	if q.onChange != nil {
+		q.onChange(q.slots.load())
+	}
+}
+
+// slots is a semaphore whose size can change, limiting how many tasks the
+// queue holds.
+type slots struct {
+	mutex *sync.Mutex
+	used  int
+	size  int
+	// free is signalled when a slot may have been freed
+	free chan struct{}
+	// acquired, released and full count what happened since the last sample,
+	// full being the times no slot was free
+	acquired uint64
+	released uint64
+	full     uint64
+}
+
+func newSlots(size int) *slots {
+	return &slots{
+		mutex: &sync.Mutex{},
+		size:  size,
+		free:  make(chan struct{}, 1),
+	}
+}
+
+// acquire waits for a free slot, giving up when either done or cancel is
+// closed first.
+func (s *slots) acquire(done <-chan struct{}, cancel <-chan struct{}) bool {
+	for !s.tryAcquire() {
+		select {
+		case <-s.free:
+		case <-done:
+			return false
+		case <-cancel:
+			return false
+		}
+	}
+	return true
+}
+
+func (s *slots) tryAcquire() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.used >= s.size {
+		s.full++
+		return false
+	}
+	s.used++
+	s.acquired++
+	if s.used < s.size {
+		// Pass on the signal to the next one waiting
+		s.signal()
+	}
+	return true
+}
+
+func (s *slots) release() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.used--
+	s.released++
+	s.signal()
+}
+
+// resize changes the number of slots. Shrinking below the slots in use makes
+// new ones wait until enough of them are released.
+func (s *slots) resize(size int) {
+	if size < 1 {
+		size = 1
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.size = size
+	s.signal()
+}
+
+func (s *slots) load() (used int, size int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.used, s.size
+}
+
+// sample returns the slots in use and their number along with how many were
+// acquired and released since the last sample, and whether none was free at
+// some point.
+func (s *slots) sample() (used int, size int, acquired uint64, released uint64, full bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	used, size, acquired, released, full = s.used, s.size, s.acquired, s.released, s.full > 0
+	s.acquired, s.released, s.full = 0, 0, 0
+	return used, size, acquired, released, full
+}
+
+// The caller must hold s.mutex.
+func (s *slots) signal() {
+	select {
+	case s.free <- struct{}{}:
+	default:
+	}
+}
+
+func (q *taskQueue[Task, Result]) len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.items)
+}
+
+func (q *taskQueue[Task, Result]) notify() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,81 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAddTaskPriority(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	inputCh := make(chan int, 3)
+	outputCh := make(chan int, 10)
+	pool := gorkpool.NewGorkPool(ctx, inputCh, outputCh, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return newTestWorker(id, ic, oc), nil
+	})
+	// Fill the input channel so the next tasks wait in the pool's queue
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	waitFor(t, func() bool { return len(inputCh) == 3 })
+	// Action
+	pool.AddTask(4)
+	pool.AddTask(5)
+	pool.AddTask(6, gorkpool.WithPriority(10))
+	pool.AddWorker(0)
+	// Assert
+	position := make(map[int]int)
+	for i := 0; i < 6; i++ {
+		position[<-outputCh] = i
+	}
+	if position[-6] > position[-5] {
+		t.Errorf("expected high priority task to run before queued low priority task, got positions %d and %d", position[-6], position[-5])
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestQueueLenAndInFlight(t *testing.T) {
+	// Setup
+	busy, release := make(chan struct{}, 1), make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		select {
+		case busy <- struct{}{}:
+		default:
+		}
+		<-release
+		return x, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	<-busy
+	pool.AddTask(2)
+	pool.AddTask(3)
+	// Assert
+	if got := pool.InFlight(); got != 1 {
+		t.Errorf("expected %d task in flight, got %d", 1, got)
+	}
+	// The slot of the first task is released right after the worker takes it
+	waitFor(t, func() bool { return pool.QueueLen() == 2 })
+	close(release)
+	waitFor(t, func() bool { return pool.InFlight() == 0 && pool.QueueLen() == 0 })
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,24 @@
+package gorkpool
+
+import "golang.org/x/time/rate"
+
+// WithRateLimit throttles dispatch to r tasks per second, with bursts of up
+// to burst tasks. Tasks wait in the queue for their token, so submitting
+// still blocks (or not) according to the backpressure policy.
+func WithRateLimit(r rate.Limit, burst int) Option {
+	return func(c *config) {
+		c.limiter = rate.NewLimiter(r, burst)
+	}
+}
+
+// throttle waits for the rate limiter to let a task through, returning false
+// once the context is done.
+func (p *GorkPool[Id, Task, Result]) throttle() bool {
+	p.mutex.Lock()
+	limiter := p.cfg.limiter
+	p.mutex.Unlock()
+	if limiter == nil {
+		return true
+	}
+	return limiter.Wait(p.ctx) == nil
+}
@@ -0,0 +1,33 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"golang.org/x/time/rate"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 2, func(x int) int { return -x },
+		gorkpool.WithOutputBufferSize(5),
+		gorkpool.WithRateLimit(rate.Every(10*time.Millisecond), 1))
+	// Action
+	start := time.Now()
+	for i := 1; i <= 5; i++ {
+		pool.AddTask(i)
+	}
+	for i := 0; i < 5; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected 5 tasks to take at least %v, got %v", 40*time.Millisecond, elapsed)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
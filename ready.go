@@ -0,0 +1,102 @@
+package gorkpool
+
+import "context"
+
+// WithReadyWorkers sets how many workers WaitReady waits for. It defaults to
+// the minimum set with WithMinWorkers, or a single worker without one.
+func WithReadyWorkers(n int) Option {
+	return func(c *config) {
+		c.readyWorkers = n
+	}
+}
+
+func (c *config) requiredReady() int {
+	switch {
+	case c.readyWorkers > 0:
+		return c.readyWorkers
+	case c.minWorkers > 0:
+		return c.minWorkers
+	default:
+		return 1
+	}
+}
+
+// WaitReady blocks until enough workers are ready to take tasks, see
+// WithReadyWorkers, so that a service can hold off taking traffic until then.
+// Workers are ready once added, which Lifecycle workers are only after Start,
+// except for HealthChecker workers with WithHealthCheck, which have to say
+// they are healthy first. It fails with ErrNotReady if ctx is done first and
+// ErrPoolClosed if the pool stops.
+func (p *GorkPool[Id, Task, Result]) WaitReady(ctx context.Context) error {
+	p.readyWaiters.Add(1)
+	defer p.readyWaiters.Add(-1)
+	for {
+		// readiness is taken before counting, so readinessChanged wakes
+		// this up for any change made since
+		p.readyMutex.Lock()
+		readiness := p.readiness
+		p.readyMutex.Unlock()
+		p.mutex.RLock()
+		ready, required := int(p.readyCount.Load()), p.cfg.requiredReady()
+		running := p.running()
+		p.mutex.RUnlock()
+		if ready >= required {
+			return nil
+		}
+		if !running {
+			return NewErrPoolClosed()
+		}
+
+		select {
+		case <-readiness:
+		case <-p.ctx.Done():
+		case <-ctx.Done():
+			return NewErrNotReady(ready, required, ctx.Err())
+		}
+	}
+}
+
+// checksHealth tells whether ws has to pass a health check to get ready.
+func (p *GorkPool[Id, Task, Result]) checksHealth(ws *workerState[Id, Task, Result]) bool {
+	_, ok := ws.worker.(HealthChecker)
+	return ok && p.cfg.healthInterval > 0
+}
+
+// prepare gets ws, which just joined the pool, ready. HealthChecker workers
+// are asked whether they are healthy first with WithHealthCheck, those that
+// aren't getting ready once a later health check passes. register sets
+// ws.ready for the others. The caller must hold p.mutex, for reading at least.
+func (p *GorkPool[Id, Task, Result]) prepare(ws *workerState[Id, Task, Result]) {
+	if ws.ready {
+		p.readinessChanged()
+		return
+	}
+	c := ws.worker.(HealthChecker)
+	p.launch("readiness check", func() {
+		if p.healthy(c) {
+			p.markReady(ws)
+		}
+	})
+}
+
+func (p *GorkPool[Id, Task, Result]) markReady(ws *workerState[Id, Task, Result]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if ws.ready || !p.workers.holds(ws.worker.ID(), ws) {
+		return
+	}
+	ws.ready = true
+	p.readyCount.Add(1)
+	p.readinessChanged()
+}
+
+// readinessChanged wakes up WaitReady, if anyone is waiting.
+func (p *GorkPool[Id, Task, Result]) readinessChanged() {
+	if p.readyWaiters.Load() == 0 {
+		return
+	}
+	p.readyMutex.Lock()
+	defer p.readyMutex.Unlock()
+	close(p.readiness)
+	p.readiness = make(chan struct{})
+}
@@ -0,0 +1,120 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func setupReadyPool(opts ...gorkpool.Option) (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: double}, nil
+	}, opts...)
+	return pool, cancel
+}
+
+// sharedChecker is a HealthChecker whose health is shared with the workers
+// replacing it.
+type sharedChecker struct {
+	testHandler
+	healthy *atomic.Bool
+}
+
+func (w *sharedChecker) Healthy() bool {
+	return w.healthy.Load()
+}
+
+func TestWaitReady(t *testing.T) {
+	// Setup
+	pool, cancel := setupReadyPool(gorkpool.WithReadyWorkers(2))
+	ready := make(chan error, 1)
+	go func() {
+		ready <- pool.WaitReady(context.Background())
+	}()
+	// Action
+	pool.AddWorker(0)
+	// Assert
+	select {
+	case err := <-ready:
+		t.Fatalf("expected WaitReady to wait for a second worker, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	pool.AddWorker(1)
+	select {
+	case err := <-ready:
+		if err != nil {
+			t.Errorf("expected the pool to be ready, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected WaitReady to return once two workers were added")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	// Setup
+	pool, cancel := setupReadyPool(gorkpool.WithMinWorkers(2))
+	pool.AddWorker(0)
+	ctx, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	// Action
+	err := pool.WaitReady(ctx)
+	// Assert
+	var notReady gorkpool.ErrNotReady
+	if !errors.As(err, &notReady) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrNotReady wrapping the deadline, got %v", err)
+	}
+	if notReady.Ready() != 1 || notReady.Required() != 2 {
+		t.Errorf("expected 1 of 2 workers ready, got %d of %d", notReady.Ready(), notReady.Required())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWaitReadyHealthCheck(t *testing.T) {
+	// Setup
+	healthy := &atomic.Bool{}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &sharedChecker{testHandler: testHandler{id: id, fn: double}, healthy: healthy}, nil
+	}, gorkpool.WithHealthCheck(5*time.Millisecond), gorkpool.WithErrorHandler(func(error) {}))
+	pool.AddWorker(0)
+	waitCtx, stop := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer stop()
+	if err := pool.WaitReady(waitCtx); !errors.Is(err, gorkpool.CodeNotReady) {
+		t.Fatalf("expected an unhealthy worker not to be ready, got %v", err)
+	}
+	// Action
+	healthy.Store(true)
+	// Assert
+	waitCtx, stop = context.WithTimeout(context.Background(), time.Second)
+	defer stop()
+	if err := pool.WaitReady(waitCtx); err != nil {
+		t.Errorf("expected the pool to be ready once its worker is healthy, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWaitReadyPoolClosed(t *testing.T) {
+	// Setup
+	pool, cancel := setupReadyPool()
+	// Action
+	cancel()
+	err := pool.WaitReady(context.Background())
+	// Assert
+	if !errors.Is(err, gorkpool.CodePoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+	// Cleanup
+	pool.Wait()
+}
@@ -0,0 +1,72 @@
+package gorkpool
+
+import (
+	"sort"
+	"time"
+)
+
+// WithIdleTimeout removes TaskHandler workers that have had nothing to do for
+// d, down to the minimum set by WithMinWorkers, so the pool shrinks back after
+// a burst. The pool can't tell whether other workers are idle, so they stay.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.idleTimeout = d
+	}
+}
+
+// idle tells whether ws has been idle for at least d by now. The caller must
+// hold p.mutex.
+func (ws *workerState[Id, Task, Result]) idle(now time.Time, d time.Duration) bool {
+	return ws.handler != nil && ws.busy == 0 && len(ws.queue) == 0 && now.Sub(ws.idleSince) >= d
+}
+
+func (p *GorkPool[Id, Task, Result]) reapIdle() {
+	interval := p.cfg.idleTimeout / 2
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := p.cfg.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case now := <-ticker.C():
+			p.reap(now)
+		}
+	}
+}
+
+// reap removes the workers idle for too long by now, those idle the longest
+// first.
+func (p *GorkPool[Id, Task, Result]) reap(now time.Time) {
+	p.mutex.Lock()
+	if !p.running() {
+		p.mutex.Unlock()
+		return
+	}
+	idle := make([]*workerState[Id, Task, Result], 0)
+	p.workers.each(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		if ws.idle(now, p.cfg.idleTimeout) {
+			idle = append(idle, ws)
+		}
+		return true
+	})
+	sort.Slice(idle, func(i, j int) bool {
+		return idle[i].idleSince.Before(idle[j].idleSince)
+	})
+
+	reaped := make([]*workerState[Id, Task, Result], 0, len(idle))
+	for _, ws := range idle {
+		if p.workers.len() <= p.cfg.minWorkers {
+			break
+		}
+		p.unregister(ws.worker.ID(), ws)
+		reaped = append(reaped, ws)
+	}
+	p.mutex.Unlock()
+
+	for _, ws := range reaped {
+		p.signalRemoval(ws)
+	}
+}
@@ -0,0 +1,27 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithIdleTimeout(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 4, func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	}, gorkpool.WithIdleTimeout(10*time.Millisecond), gorkpool.WithMinWorkers(1))
+	// Action
+	waitFor(t, func() bool { return pool.Length() == 1 })
+	// Assert
+	time.Sleep(20 * time.Millisecond)
+	if got := pool.Length(); got != 1 {
+		t.Errorf("expected the pool to keep %d worker, got %d", 1, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,94 @@
+package gorkpool
+
+import (
+	"sync"
+	"time"
+)
+
+// TaskRecord is a task handled by a TaskHandler worker, as kept by
+// WithRecentTasks. Wait is how long it was queued and Duration how long it
+// took to handle, from Started to Finished.
+type TaskRecord[Id comparable, Task any, Result any] struct {
+	ID       string
+	Task     Task
+	Worker   Id
+	Attempt  int
+	Result   Result
+	Err      error
+	Wait     time.Duration
+	Started  time.Time
+	Finished time.Time
+	Duration time.Duration
+}
+
+// WithRecentTasks keeps the last n tasks TaskHandler workers were done with,
+// whatever the outcome, for RecentTasks to tell what the pool has been doing
+// lately. Tasks and results are kept as they are, so n should be low enough
+// for them to fit in memory.
+func WithRecentTasks(n int) Option {
+	return func(c *config) {
+		c.recentTasks = n
+	}
+}
+
+// recentTasks is a ring of the last tasks handled.
+type recentTasks[Id comparable, Task any, Result any] struct {
+	mutex   *sync.Mutex
+	records []TaskRecord[Id, Task, Result]
+	// next is where the next record goes, the oldest one once full
+	next int
+	full bool
+}
+
+func (p *GorkPool[Id, Task, Result]) keepRecent(n int) {
+	p.recent = &recentTasks[Id, Task, Result]{
+		mutex:   &sync.Mutex{},
+		records: make([]TaskRecord[Id, Task, Result], n),
+	}
+}
+
+// remember keeps the task of event, done with at now, if keeping recent tasks.
+func (p *GorkPool[Id, Task, Result]) remember(event TaskEvent[Id, Task, Result], now time.Time) {
+	if p.recent == nil {
+		return
+	}
+	p.recent.add(TaskRecord[Id, Task, Result]{
+		ID:       event.ID,
+		Task:     event.Task,
+		Worker:   event.Worker,
+		Attempt:  event.Attempt,
+		Result:   event.Result,
+		Err:      event.Err,
+		Wait:     event.Wait,
+		Started:  now.Add(-event.Duration),
+		Finished: now,
+		Duration: event.Duration,
+	})
+}
+
+func (r *recentTasks[Id, Task, Result]) add(record TaskRecord[Id, Task, Result]) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records[r.next] = record
+	r.next++
+	if r.next == len(r.records) {
+		r.next, r.full = 0, true
+	}
+}
+
+// RecentTasks returns the tasks kept with WithRecentTasks, from the oldest to
+// the newest, nil without it.
+func (p *GorkPool[Id, Task, Result]) RecentTasks() []TaskRecord[Id, Task, Result] {
+	if p.recent == nil {
+		return nil
+	}
+	r := p.recent
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.full {
+		return append([]TaskRecord[Id, Task, Result](nil), r.records[:r.next]...)
+	}
+	records := make([]TaskRecord[Id, Task, Result], 0, len(r.records))
+	records = append(records, r.records[r.next:]...)
+	return append(records, r.records[:r.next]...)
+}
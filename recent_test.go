@@ -0,0 +1,65 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithRecentTasks(t *testing.T) {
+	// Setup
+	failure := errors.New("odd")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		if x%2 == 1 {
+			return 0, failure
+		}
+		return 2 * x, nil
+	}, gorkpool.WithRecentTasks(3))
+	// Action
+	for x := 1; x <= 5; x++ {
+		future, _ := pool.Submit(x)
+		future.Wait(context.Background())
+	}
+	recent := pool.RecentTasks()
+	// Assert
+	if len(recent) != 3 {
+		t.Fatalf("expected the last 3 tasks, got %d", len(recent))
+	}
+	for i, record := range recent {
+		x := i + 3
+		if record.Task != x || record.Worker != 0 {
+			t.Errorf("expected task %d handled by worker 0, got %+v", x, record)
+		}
+		if x%2 == 1 && !errors.Is(record.Err, failure) {
+			t.Errorf("expected task %d to have failed, got %v", x, record.Err)
+		}
+		if x%2 == 0 && (record.Err != nil || record.Result != 2*x) {
+			t.Errorf("expected task %d to give %d, got %d, %v", x, 2*x, record.Result, record.Err)
+		}
+		if record.Finished.Before(record.Started) || record.Finished.Sub(record.Started) != record.Duration {
+			t.Errorf("expected task %d to take from Started to Finished, got %+v", x, record)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRecentTasksDisabled(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, double)
+	// Action
+	future, _ := pool.Submit(1)
+	future.Wait(context.Background())
+	// Assert
+	if recent := pool.RecentTasks(); recent != nil {
+		t.Errorf("expected no recent tasks without WithRecentTasks, got %v", recent)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,60 @@
+package gorkpool
+
+// ApplyOptions changes the options of a running pool. Only WithMinWorkers,
+// WithMaxWorkers, WithRateLimit, WithQueueSize and WithRemovalPolicy can be
+// changed this way, the others are ignored. Workers beyond a lowered maximum
+// are removed following the removal policy, and if there is an IdGenerator
+// workers are added up to a raised minimum.
+func (p *GorkPool[Id, Task, Result]) ApplyOptions(opts ...Option) error {
+	p.mutex.Lock()
+	cfg := p.cfg
+	// Ignored, but the options would write to the maps the queue reads
+	cfg.tenantWeights, cfg.tenantQuotas = nil, nil
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p.cfg.minWorkers = cfg.minWorkers
+	p.cfg.maxWorkers = cfg.maxWorkers
+	p.cfg.limiter = cfg.limiter
+	p.cfg.removalPolicy = cfg.removalPolicy
+	resized := p.cfg.queueSize != cfg.queueSize
+	p.cfg.queueSize = cfg.queueSize
+	p.mutex.Unlock()
+
+	if resized {
+		size := cfg.queueSize
+		if size <= 0 {
+			size = cap(p.inputCh)
+		}
+		p.queue.slots.resize(size)
+		p.queue.changed()
+	}
+	return p.reconcile()
+}
+
+// reconcile brings the number of workers of a running pool within the
+// minimum and maximum.
+func (p *GorkPool[Id, Task, Result]) reconcile() error {
+	for {
+		p.mutex.Lock()
+		running := p.running()
+		excess := p.cfg.maxWorkers > 0 && p.workers.len() > p.cfg.maxWorkers
+		lacking := p.idGenerator != nil && p.workers.len() < p.cfg.minWorkers
+		p.mutex.Unlock()
+
+		switch {
+		case !running:
+			return nil
+		case excess:
+			if _, err := p.removeAny(); err != nil {
+				return err
+			}
+		case lacking:
+			if _, err := p.AddWorkerAuto(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
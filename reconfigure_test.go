@@ -0,0 +1,95 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestApplyOptionsWorkers(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.SetIdGenerator(gorkpool.SequentialIDs())
+	for i := 0; i < 4; i++ {
+		pool.AddWorkerAuto()
+	}
+	// Action
+	shrinkErr := pool.ApplyOptions(gorkpool.WithMaxWorkers(2))
+	shrunk := pool.Length()
+	addErr := pool.AddWorker(10)
+	growErr := pool.ApplyOptions(gorkpool.WithMaxWorkers(6), gorkpool.WithMinWorkers(5))
+	// Assert
+	if shrinkErr != nil || shrunk != 2 {
+		t.Errorf("expected pool to shrink to 2 workers, got %d (%v)", shrunk, shrinkErr)
+	}
+	if !errors.As(addErr, new(gorkpool.ErrMaxWorkers)) {
+		t.Errorf("expected ErrMaxWorkers past the new maximum, got %v", addErr)
+	}
+	if growErr != nil || pool.Length() != 5 {
+		t.Errorf("expected pool to grow to 5 workers, got %d (%v)", pool.Length(), growErr)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestApplyOptionsQueueSize(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	// Without workers to route them to, tasks stay in the queue
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithQueueSize(10))
+	// Action
+	pool.ApplyOptions(gorkpool.WithQueueSize(3))
+	accepted := 0
+	for pool.TryAddTask(accepted) == nil {
+		accepted++
+	}
+	// Assert
+	if accepted != 3 {
+		t.Errorf("expected 3 tasks to fit in the resized queue, got %d", accepted)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestApplyOptionsIgnored(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	}, gorkpool.WithTenantWeight("a", 1), gorkpool.WithTenantQuota("a", 100), gorkpool.WithQueueSize(10))
+	go func() {
+		for range pool.OutputCh() {
+		}
+	}()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			pool.ApplyOptions(gorkpool.WithTenantWeight("a", i+1), gorkpool.WithTenantQuota("a", 1))
+		}
+	}()
+	// Action
+	for i := 0; i < 100; i++ {
+		pool.Submit(i, gorkpool.WithTenant("a"))
+	}
+	<-done
+	waitFor(t, func() bool { return pool.QueueLen() == 0 })
+	pool.RemoveWorkerById(0)
+	first := pool.TryAddTask(1, gorkpool.WithTenant("a"))
+	second := pool.TryAddTask(2, gorkpool.WithTenant("a"))
+	// Assert
+	if first != nil || second != nil {
+		t.Errorf("expected the quota not to be changed by ApplyOptions, got %v and %v", first, second)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
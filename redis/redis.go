@@ -0,0 +1,252 @@
+// Package redis feeds a GorkPool from a Redis list and writes the results
+// back, so that several processes running the same pool can share one work
+// queue as competing consumers.
+//
+// It speaks just enough of the Redis protocol for that, to keep gorkpool free
+// of a client dependency.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+// pollTimeout is how long a BRPOP blocks, so Consume notices its context
+// being done at least that often.
+const pollTimeout = time.Second
+
+// Client is a connection to a Redis server. It is safe for concurrent use,
+// but commands are sent one at a time, so Consume wants one Client to pop
+// tasks and another to write results.
+type Client struct {
+	mutex sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+}
+
+func Dial(addr string) (*Client, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient speaks to Redis over conn, for connections Dial doesn't make,
+// like TLS ones.
+func NewClient(conn net.Conn) *Client {
+	return &Client{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+	}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// ErrReply is an error reply from the server.
+type ErrReply struct {
+	msg string
+}
+
+func NewErrReply(msg string) ErrReply {
+	return ErrReply{
+		msg: msg,
+	}
+}
+
+func (e ErrReply) Error() string {
+	return "redis: " + e.msg
+}
+
+// Do sends a command and returns its reply: a string, an int64, a []any, or
+// nil.
+func (c *Client) Do(args ...string) (any, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(arg))+"\r\n"+arg+"\r\n"...)
+	}
+	if _, err := c.conn.Write(buf); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+func (c *Client) read() (any, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, line := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return line, nil
+	case '-':
+		return nil, NewErrReply(line)
+	case ':':
+		return strconv.ParseInt(line, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		items := make([]any, n)
+		for i := range items {
+			// An error inside an array doesn't break the stream
+			items[i], err = c.read()
+			var reply ErrReply
+			if err != nil && !errors.As(err, &reply) {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+}
+
+// Message is how tasks are stored in the tasks list. ID is up to the producer
+// and comes back with the task's Reply.
+type Message[Task any] struct {
+	ID   string `json:"id"`
+	Task Task   `json:"task"`
+}
+
+// Reply is how results are stored in the results list.
+type Reply[Result any] struct {
+	ID     string `json:"id"`
+	Result Result `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Push adds task to the tasks list under key.
+func Push[Task any](c *Client, key string, id string, task Task) error {
+	data, err := json.Marshal(Message[Task]{ID: id, Task: task})
+	if err != nil {
+		return err
+	}
+	_, err = c.Do("LPUSH", key, string(data))
+	return err
+}
+
+// Consume pops tasks from the list under tasks with src and submits them to
+// pool, pushing a Reply for each onto the list under results with dst. It
+// blocks until ctx is done or either fails, then waits for the tasks in
+// flight.
+//
+// Tasks are popped atomically, so each goes to a single consumer. The ones a
+// pool closes on before handling are handed back to the list for the others;
+// those in flight when a process dies are lost. Messages that don't decode get
+// a Reply with their error, under their ID if it decoded, and are dropped.
+func Consume[Id comparable, Task any, Result any](ctx context.Context, pool *gorkpool.GorkPool[Id, Task, Result], src, dst *Client, tasks, results string, opts ...gorkpool.TaskOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+	failed := func(err error) {
+		errMutex.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		errMutex.Unlock()
+		cancel()
+	}
+
+	for ctx.Err() == nil {
+		reply, err := src.Do("BRPOP", tasks, strconv.Itoa(int(pollTimeout/time.Second)))
+		if err != nil {
+			failed(err)
+			break
+		}
+		items, ok := reply.([]any)
+		if !ok || len(items) != 2 {
+			continue
+		}
+		payload, _ := items[1].(string)
+
+		var msg Message[Task]
+		if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+			// Handing it back would only have it fail again
+			if err := send(dst, results, msg.ID, *new(Result), fmt.Errorf("redis: decoding task: %w", err)); err != nil {
+				failed(err)
+				break
+			}
+			continue
+		}
+		f, err := pool.Submit(msg.Task, opts...)
+		if err != nil {
+			handBack(dst, tasks, payload, failed)
+			failed(err)
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := f.Wait(context.Background())
+			var closed gorkpool.ErrPoolClosed
+			if errors.As(err, &closed) {
+				handBack(dst, tasks, payload, failed)
+				return
+			}
+			if err := send(dst, results, msg.ID, result, err); err != nil {
+				failed(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// handBack puts payload back at the end of the tasks list that is popped next.
+func handBack(c *Client, key string, payload string, failed func(error)) {
+	if _, err := c.Do("RPUSH", key, payload); err != nil {
+		failed(err)
+	}
+}
+
+func send[Result any](c *Client, key string, id string, result Result, err error) error {
+	reply := Reply[Result]{ID: id, Result: result}
+	if err != nil {
+		reply.Error = err.Error()
+	}
+	data, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do("LPUSH", key, string(data))
+	return err
+}
@@ -0,0 +1,230 @@
+package redis_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/redis"
+)
+
+// fakeServer is just enough of Redis for LPUSH, RPUSH and BRPOP.
+type fakeServer struct {
+	ln     net.Listener
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	lists  map[string][]string
+	closed bool
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected to listen, got %v", err)
+	}
+	s := &fakeServer{ln: ln, lists: make(map[string][]string)}
+	s.cond = sync.NewCond(&s.mutex)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) Close() {
+	s.ln.Close()
+	s.mutex.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mutex.Unlock()
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeServer) exec(args []string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch args[0] {
+	case "LPUSH":
+		s.lists[args[1]] = append(args[2:3], s.lists[args[1]]...)
+	case "RPUSH":
+		s.lists[args[1]] = append(s.lists[args[1]], args[2])
+	case "BRPOP":
+		timeout, _ := strconv.Atoi(args[2])
+		deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+		timer := time.AfterFunc(time.Until(deadline), func() {
+			s.mutex.Lock()
+			s.cond.Broadcast()
+			s.mutex.Unlock()
+		})
+		defer timer.Stop()
+		for len(s.lists[args[1]]) == 0 && !s.closed && time.Now().Before(deadline) {
+			s.cond.Wait()
+		}
+		list := s.lists[args[1]]
+		if len(list) == 0 {
+			return "*-1\r\n"
+		}
+		v := list[len(list)-1]
+		s.lists[args[1]] = list[:len(list)-1]
+		return fmt.Sprintf("*2\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(args[1]), args[1], len(v), v)
+	default:
+		return "-ERR unknown command\r\n"
+	}
+	s.cond.Broadcast()
+	return ":" + strconv.Itoa(len(s.lists[args[1]])) + "\r\n"
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	var n int
+	if _, err := fmt.Fscanf(r, "*%d\r\n", &n); err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		var size int
+		if _, err := fmt.Fscanf(r, "$%d\r\n", &size); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func dial(t *testing.T, s *fakeServer) *redis.Client {
+	c, err := redis.Dial(s.ln.Addr().String())
+	if err != nil {
+		t.Fatalf("expected to dial, got %v", err)
+	}
+	return c
+}
+
+func TestConsume(t *testing.T) {
+	// Setup
+	s := newFakeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		pool := gorkpool.NewFuncPool(ctx, 2, func(x int) int { return -x })
+		src, dst := dial(t, s), dial(t, s)
+		go func() {
+			errs <- redis.Consume(ctx, pool, src, dst, "tasks", "results")
+		}()
+	}
+	producer := dial(t, s)
+	// Action
+	for i := 1; i <= 20; i++ {
+		if err := redis.Push(producer, "tasks", strconv.Itoa(i), i); err != nil {
+			t.Fatalf("expected task to be pushed, got %v", err)
+		}
+	}
+	// Assert
+	seen := make(map[string]bool)
+	for len(seen) < 20 {
+		reply, err := producer.Do("BRPOP", "results", "1")
+		if err != nil {
+			t.Fatalf("expected result to be popped, got %v", err)
+		}
+		items, ok := reply.([]any)
+		if !ok {
+			t.Fatalf("expected 20 results, got %d", len(seen))
+		}
+		var res redis.Reply[int]
+		if err := json.Unmarshal([]byte(items[1].(string)), &res); err != nil {
+			t.Fatalf("expected result to decode, got %v", err)
+		}
+		if id, _ := strconv.Atoi(res.ID); res.Result != -id || res.Error != "" {
+			t.Errorf("expected result %d for task %q, got %+v", -id, res.ID, res)
+		}
+		if seen[res.ID] {
+			t.Errorf("expected task %q to be handled once", res.ID)
+		}
+		seen[res.ID] = true
+	}
+	// Cleanup
+	cancel()
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("expected Consume to return nil once cancelled, got %v", err)
+		}
+	}
+	s.Close()
+}
+
+func TestConsumeMalformed(t *testing.T) {
+	// Setup
+	s := newFakeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewFuncPool(ctx, 1, func(x int) int { return -x })
+	src, dst, producer := dial(t, s), dial(t, s), dial(t, s)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- redis.Consume(ctx, pool, src, dst, "tasks", "results")
+	}()
+	// Action
+	producer.Do("LPUSH", "tasks", `{"id":"1","task":"one"}`)
+	redis.Push(producer, "tasks", "2", 2)
+	// Assert
+	for _, want := range []redis.Reply[int]{{ID: "1"}, {ID: "2", Result: -2}} {
+		reply, err := producer.Do("BRPOP", "results", "1")
+		items, ok := reply.([]any)
+		if err != nil || !ok {
+			t.Fatalf("expected the reply for task %q, got %v (%v)", want.ID, reply, err)
+		}
+		var res redis.Reply[int]
+		json.Unmarshal([]byte(items[1].(string)), &res)
+		if res.ID != want.ID || res.Result != want.Result || (res.Error != "") != (want.Result == 0) {
+			t.Errorf("expected reply %+v, with an error if malformed, got %+v", want, res)
+		}
+	}
+	// Cleanup
+	cancel()
+	if err := <-errs; err != nil {
+		t.Errorf("expected Consume to keep going past the malformed task, got %v", err)
+	}
+	s.Close()
+}
+
+func TestDoErrorReply(t *testing.T) {
+	// Setup
+	s := newFakeServer(t)
+	c := dial(t, s)
+	// Action
+	_, err := c.Do("GET", "x")
+	// Assert
+	if _, ok := err.(redis.ErrReply); !ok {
+		t.Errorf("expected ErrReply, got %v", err)
+	}
+	// Cleanup
+	c.Close()
+	s.Close()
+}
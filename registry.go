@@ -0,0 +1,242 @@
+package gorkpool
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// registryShards is how many shards the workers are spread over.
+const registryShards = 32
+
+// registry holds the workers of the pool by id, sharded by the hash of their id
+// so that adding workers and removing them by id only lock their shard. Writers
+// hold p.mutex, for reading at least, along with the lock of the shard, so
+// holding p.mutex for writing is enough to read the whole registry.
+type registry[Id comparable, Task any, Result any] struct {
+	shards [registryShards]registryShard[Id, Task, Result]
+	n      atomic.Int64
+}
+
+type registryShard[Id comparable, Task any, Result any] struct {
+	mutex   sync.Mutex
+	workers map[Id]*workerState[Id, Task, Result]
+	// leaving are the workers of the shard removed but not exited yet
+	leaving map[*workerState[Id, Task, Result]]struct{}
+}
+
+func (r *registry[Id, Task, Result]) shard(id Id) *registryShard[Id, Task, Result] {
+	return &r.shards[shardOf(id)%registryShards]
+}
+
+// shardOf hashes id, the common id types without allocating. Only the others
+// box id, in hashOf, so that it doesn't escape here.
+func shardOf[Id comparable](id Id) uint64 {
+	switch v := any(id).(type) {
+	case int:
+		return mix(uint64(v))
+	case int64:
+		return mix(uint64(v))
+	case int32:
+		return mix(uint64(v))
+	case uint:
+		return mix(uint64(v))
+	case uint64:
+		return mix(uint64(v))
+	case uint32:
+		return mix(uint64(v))
+	case string:
+		var h uint64 = 14695981039346656037
+		for i := 0; i < len(v); i++ {
+			h = (h ^ uint64(v[i])) * 1099511628211
+		}
+		return h
+	default:
+		return hashOf(id)
+	}
+}
+
+//go:noinline
+func hashOf[Id comparable](id Id) uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h, id)
+	return h.Sum64()
+}
+
+// mix spreads the bits of sequential ids over the shards.
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	return x
+}
+
+func (r *registry[Id, Task, Result]) len() int {
+	return int(r.n.Load())
+}
+
+func (r *registry[Id, Task, Result]) get(id Id) (*workerState[Id, Task, Result], bool) {
+	s := r.shard(id)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ws, ok := s.workers[id]
+	return ws, ok
+}
+
+// holds tells whether ws is the worker with id.
+func (r *registry[Id, Task, Result]) holds(id Id, ws *workerState[Id, Task, Result]) bool {
+	cur, ok := r.get(id)
+	return ok && cur == ws
+}
+
+// add puts ws in the registry and calls start, under the lock of its shard so
+// that ws can't be taken out before, failing with ErrIdConflict if its id is
+// taken and ErrMaxWorkers if it holds max workers already, max being
+// unlimited if not positive.
+func (r *registry[Id, Task, Result]) add(ws *workerState[Id, Task, Result], max int, start func()) error {
+	id := ws.worker.ID()
+	s := r.shard(id)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.workers[id]; ok {
+		return NewErrIdConflict(id)
+	}
+	for {
+		n := r.n.Load()
+		if max > 0 && n >= int64(max) {
+			return NewErrMaxWorkers(max)
+		}
+		if r.n.CompareAndSwap(n, n+1) {
+			break
+		}
+	}
+	if s.workers == nil {
+		s.workers = make(map[Id]*workerState[Id, Task, Result])
+	}
+	s.workers[id] = ws
+	start()
+	return nil
+}
+
+// take removes the worker with id, failing with ErrWorkerNotFound if there is
+// none and ErrMinWorkers if only min workers are left, min being ignored if
+// not positive.
+func (r *registry[Id, Task, Result]) take(id Id, min int) (*workerState[Id, Task, Result], error) {
+	s := r.shard(id)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ws, ok := s.workers[id]
+	if !ok {
+		return nil, NewErrWorkerNotFound(id)
+	}
+	for {
+		n := r.n.Load()
+		if min > 0 && n <= int64(min) {
+			return nil, NewErrMinWorkers(min)
+		}
+		if r.n.CompareAndSwap(n, n-1) {
+			break
+		}
+	}
+	delete(s.workers, id)
+	return ws, nil
+}
+
+// remove takes ws out, if it is still the worker with id, telling whether it
+// was.
+func (r *registry[Id, Task, Result]) remove(id Id, ws *workerState[Id, Task, Result]) bool {
+	s := r.shard(id)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if cur, ok := s.workers[id]; !ok || cur != ws {
+		return false
+	}
+	delete(s.workers, id)
+	r.n.Add(-1)
+	return true
+}
+
+// each calls fn with every worker until it returns false. The caller must hold
+// p.mutex for writing, fn being free to remove workers.
+func (r *registry[Id, Task, Result]) each(fn func(Id, *workerState[Id, Task, Result]) bool) {
+	for i := range r.shards {
+		for id, ws := range r.shards[i].workers {
+			if !fn(id, ws) {
+				return
+			}
+		}
+	}
+}
+
+// eachShared is each for callers holding p.mutex for reading only, if at
+// all, locking the shards one at a time. fn must not change the registry.
+func (r *registry[Id, Task, Result]) eachShared(fn func(Id, *workerState[Id, Task, Result]) bool) {
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mutex.Lock()
+		for id, ws := range s.workers {
+			if !fn(id, ws) {
+				s.mutex.Unlock()
+				return
+			}
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// leave keeps ws, just removed, among the leaving workers until it exits.
+func (r *registry[Id, Task, Result]) leave(ws *workerState[Id, Task, Result]) {
+	s := r.shard(ws.worker.ID())
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if ws.exited() {
+		return
+	}
+	if s.leaving == nil {
+		s.leaving = make(map[*workerState[Id, Task, Result]]struct{})
+	}
+	s.leaving[ws] = struct{}{}
+}
+
+// left drops ws, exited, from the leaving workers.
+func (r *registry[Id, Task, Result]) left(ws *workerState[Id, Task, Result]) {
+	s := r.shard(ws.worker.ID())
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.leaving, ws)
+}
+
+// eachLeaving calls fn with every leaving worker, locking the shards one at a
+// time.
+func (r *registry[Id, Task, Result]) eachLeaving(fn func(*workerState[Id, Task, Result])) {
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mutex.Lock()
+		for ws := range s.leaving {
+			fn(ws)
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// snapshot returns the workers, in no particular order.
+func (r *registry[Id, Task, Result]) snapshot() []*workerState[Id, Task, Result] {
+	workers := make([]*workerState[Id, Task, Result], 0, r.len())
+	r.eachShared(func(_ Id, ws *workerState[Id, Task, Result]) bool {
+		workers = append(workers, ws)
+		return true
+	})
+	return workers
+}
+
+// clear takes every worker out.
+func (r *registry[Id, Task, Result]) clear() {
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mutex.Lock()
+		s.workers, s.leaving = nil, nil
+		s.mutex.Unlock()
+	}
+	r.n.Store(0)
+}
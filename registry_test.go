@@ -0,0 +1,32 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/gorkpooltest"
+)
+
+func TestContainsAllocs(t *testing.T) {
+	// Setup
+	ints := gorkpool.NewFuncPool(context.Background(), 1, func(x int) int { return x })
+	strs := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id string, ic chan int, oc chan int) (gorkpool.GorkWorker[string, int, int], error) {
+		return gorkpooltest.NewRecorder[string](id, double), nil
+	})
+	strs.AddWorker("worker")
+	// Action
+	intAllocs := testing.AllocsPerRun(100, func() {
+		ints.Contains(100000)
+	})
+	strAllocs := testing.AllocsPerRun(100, func() {
+		strs.Contains("worker")
+	})
+	// Assert
+	if intAllocs != 0 || strAllocs != 0 {
+		t.Errorf("expected looking up int and string ids not to allocate, got %v and %v allocations", intAllocs, strAllocs)
+	}
+	// Cleanup
+	ints.Shutdown(context.Background())
+	strs.Shutdown(context.Background())
+}
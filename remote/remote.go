@@ -0,0 +1,98 @@
+// Package remote dispatches the tasks of a pool to worker processes on other
+// machines, following the Worker service of worker.proto.
+//
+// It doesn't depend on gRPC itself: RemoteWorker takes a Client, which the
+// client generated from worker.proto is adapted to in a few lines, and
+// Handler gives the function a generated server implements Handle with.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+// Request and Reply mirror the messages of worker.proto.
+type Request struct {
+	Task []byte
+}
+
+type Reply struct {
+	Result []byte
+	Error  string
+}
+
+// Client calls the Handle method of a remote Worker service. Its errors are
+// taken for the connection being lost; those of the task come in the Reply.
+type Client interface {
+	Handle(ctx context.Context, req *Request) (*Reply, error)
+}
+
+// RemoteWorker is a TaskHandler worker handing its tasks to a remote Worker
+// service, JSON encoded. When c fails, it returns an ErrConnLost so the pool
+// queues the task again and replaces the worker, which should dial again from
+// the factory.
+type RemoteWorker[Id comparable, Task any, Result any] struct {
+	id     Id
+	client Client
+}
+
+func NewRemoteWorker[Id comparable, Task any, Result any](id Id, c Client) *RemoteWorker[Id, Task, Result] {
+	return &RemoteWorker[Id, Task, Result]{
+		id:     id,
+		client: c,
+	}
+}
+
+func (w *RemoteWorker[Id, Task, Result]) ID() Id {
+	return w.id
+}
+
+func (w *RemoteWorker[Id, Task, Result]) Process() {}
+
+func (w *RemoteWorker[Id, Task, Result]) SignalRemoval() {}
+
+func (w *RemoteWorker[Id, Task, Result]) Handle(ctx context.Context, task Task) (Result, error) {
+	var result Result
+	data, err := json.Marshal(task)
+	if err != nil {
+		return result, gorkpool.NewErrPermanent(err)
+	}
+
+	reply, err := w.client.Handle(ctx, &Request{Task: data})
+	if err != nil {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		return result, gorkpool.NewErrConnLost(err)
+	}
+	if reply.Error != "" {
+		return result, errors.New(reply.Error)
+	}
+	if err := json.Unmarshal(reply.Result, &result); err != nil {
+		return result, gorkpool.NewErrPermanent(err)
+	}
+	return result, nil
+}
+
+// Handler turns fn into the Handle method of a Worker service, for the worker
+// processes. Failures of fn are sent in the Reply.
+func Handler[Task any, Result any](fn gorkpool.HandlerFunc[Task, Result]) func(ctx context.Context, req *Request) (*Reply, error) {
+	return func(ctx context.Context, req *Request) (*Reply, error) {
+		var task Task
+		if err := json.Unmarshal(req.Task, &task); err != nil {
+			return &Reply{Error: err.Error()}, nil
+		}
+		result, err := fn(ctx, task)
+		if err != nil {
+			return &Reply{Error: err.Error()}, nil
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return &Reply{Error: err.Error()}, nil
+		}
+		return &Reply{Result: data}, nil
+	}
+}
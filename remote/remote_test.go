@@ -0,0 +1,76 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+	"github.com/joaovictorsl/gorkpool/remote"
+)
+
+type clientFunc func(ctx context.Context, req *remote.Request) (*remote.Reply, error)
+
+func (fn clientFunc) Handle(ctx context.Context, req *remote.Request) (*remote.Reply, error) {
+	return fn(ctx, req)
+}
+
+func negate(ctx context.Context, x int) (int, error) {
+	if x == 0 {
+		return 0, errors.New("zero")
+	}
+	return -x, nil
+}
+
+func TestRemoteWorker(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	handle := remote.Handler[int, int](negate)
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return remote.NewRemoteWorker[int, int, int](id, clientFunc(handle)), nil
+	})
+	pool.AddWorker(0)
+	// Action
+	result, err := pool.SubmitWait(ctx, 2)
+	_, taskErr := pool.SubmitWait(ctx, 0)
+	// Assert
+	if err != nil || result != -2 {
+		t.Errorf("expected result %d, got %d and %v", -2, result, err)
+	}
+	if taskErr == nil || taskErr.Error() != "zero" {
+		t.Errorf("expected the remote error, got %v", taskErr)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRemoteWorkerConnLost(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	handle := remote.Handler[int, int](negate)
+	var dialed atomic.Int64
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, inputCh chan int, outputCh chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		first := dialed.Add(1) == 1
+		return remote.NewRemoteWorker[int, int, int](id, clientFunc(func(ctx context.Context, req *remote.Request) (*remote.Reply, error) {
+			if first {
+				return nil, errors.New("connection reset")
+			}
+			return handle(ctx, req)
+		})), nil
+	})
+	pool.AddWorker(0)
+	// Action
+	result, err := pool.SubmitWait(ctx, 3)
+	// Assert
+	if err != nil || result != -3 {
+		t.Errorf("expected the task to be requeued and give %d, got %d and %v", -3, result, err)
+	}
+	if n := dialed.Load(); n != 2 {
+		t.Errorf("expected the worker to be replaced once, got %d workers created", n)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,151 @@
+package gorkpool
+
+import "time"
+
+// RemovalPolicy decides which worker RemoveWorker takes out of the pool.
+// Whatever the policy, idle workers are removed before the ones in the middle
+// of a task, so shrinking the pool interrupts as little work as possible.
+type RemovalPolicy int
+
+const (
+	// RemoveAny removes whichever idle worker comes first.
+	RemoveAny RemovalPolicy = iota
+	// RemoveNewest removes the worker added last.
+	RemoveNewest
+	// RemoveOldest removes the worker added first.
+	RemoveOldest
+	// RemoveLeastBusy removes the worker with the fewest tasks in flight.
+	// Only TaskHandler workers report what they are doing, channel workers
+	// always look idle.
+	RemoveLeastBusy
+	// RemoveMostIdle removes the worker that has been idle for the longest,
+	// falling back to RemoveLeastBusy when every worker is busy.
+	RemoveMostIdle
+)
+
+func WithRemovalPolicy(policy RemovalPolicy) Option {
+	return func(c *config) {
+		c.removalPolicy = policy
+	}
+}
+
+// signalRemoval queues ws, unregistered already, to be signalled to stop by
+// the removal goroutine of the pool, so removing a worker never waits for it.
+// Workers are signalled in the order they were removed, the goroutine running
+// while any is queued. Their done channel tells when they are gone, and
+// shutting down waits for them as well.
+func (p *GorkPool[Id, Task, Result]) signalRemoval(ws *workerState[Id, Task, Result]) {
+	p.removalMutex.Lock()
+	p.removals = append(p.removals, ws)
+	start := !p.removing
+	p.removing = true
+	p.removalMutex.Unlock()
+
+	if start {
+		p.launch("removals", p.signalRemovals)
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) signalRemovals() {
+	for {
+		p.removalMutex.Lock()
+		if len(p.removals) == 0 {
+			p.removals, p.removing = nil, false
+			p.removalMutex.Unlock()
+			return
+		}
+		ws := p.removals[0]
+		p.removals[0] = nil
+		p.removals = p.removals[1:]
+		p.removalMutex.Unlock()
+
+		p.signal(ws)
+	}
+}
+
+// signal tells ws to stop without waiting for it, returning a channel closed
+// once it took the signal or exited. TaskHandler and Runner workers have their
+// context cancelled. Channel workers get SignalRemoval once, on a goroutine of
+// the pool, unless Process returned already. If it returns right as the signal
+// is sent, SignalRemoval may never return, its goroutine being left behind for
+// VerifyClean to report.
+func (p *GorkPool[Id, Task, Result]) signal(ws *workerState[Id, Task, Result]) <-chan struct{} {
+	ws.signalled.Do(func() {
+		if ws.cancel != nil {
+			ws.cancel()
+			ws.confirm()
+			return
+		}
+		select {
+		case <-ws.confirmed:
+			// It returned already
+			return
+		default:
+		}
+		p.launch("signal removal", func() {
+			ws.worker.SignalRemoval()
+			ws.confirm()
+		})
+	})
+	return ws.confirmed
+}
+
+// pickRemoval chooses the worker to remove according to the removal policy.
+// The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) pickRemoval() (Id, *workerState[Id, Task, Result]) {
+	var (
+		targetId Id
+		target   *workerState[Id, Task, Result]
+	)
+	p.workers.each(func(id Id, ws *workerState[Id, Task, Result]) bool {
+		if target == nil || p.preferRemoval(ws, target) {
+			targetId, target = id, ws
+		}
+		return p.cfg.removalPolicy != RemoveAny || target.busy > 0
+	})
+	return targetId, target
+}
+
+// preferRemoval tells whether ws should be removed before other.
+func (p *GorkPool[Id, Task, Result]) preferRemoval(ws, other *workerState[Id, Task, Result]) bool {
+	if idle := ws.busy == 0; idle != (other.busy == 0) {
+		return idle
+	}
+	switch p.cfg.removalPolicy {
+	case RemoveNewest:
+		return ws.seq > other.seq
+	case RemoveOldest:
+		return ws.seq < other.seq
+	case RemoveLeastBusy:
+		return ws.busy < other.busy
+	case RemoveMostIdle:
+		if ws.busy == 0 && other.busy == 0 {
+			return ws.idleSince.Before(other.idleSince)
+		}
+		return ws.busy < other.busy
+	default:
+		return false
+	}
+}
+
+// markBusy and markIdle keep track of what TaskHandler workers are doing.
+// The caller must hold p.mutex.
+func (ws *workerState[Id, Task, Result]) markBusy(weight int) {
+	now := ws.clock.Now()
+	if ws.busy == 0 {
+		ws.busySince = now
+	}
+	ws.busy++
+	ws.weight += weight
+	ws.lastActive = now
+}
+
+func (ws *workerState[Id, Task, Result]) markIdle(now time.Time, weight int) {
+	ws.busy--
+	ws.weight -= weight
+	ws.lastActive = now
+	if ws.busy == 0 {
+		ws.idleSince = now
+		ws.busyTime += now.Sub(ws.busySince)
+	}
+}
@@ -0,0 +1,154 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestRemovalPolicyAge(t *testing.T) {
+	policies := map[gorkpool.RemovalPolicy][]int{
+		gorkpool.RemoveNewest: {2, 1, 0},
+		gorkpool.RemoveOldest: {0, 1, 2},
+	}
+	for policy, want := range policies {
+		// Setup
+		ctx, cancel := context.WithCancel(context.Background())
+		pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+			return newTestWorker(id, ic, oc), nil
+		}, gorkpool.WithRemovalPolicy(policy))
+		for i := 0; i < 3; i++ {
+			pool.AddWorker(i)
+		}
+		for _, id := range want {
+			// Action
+			w := pool.RemoveWorker()
+			// Assert
+			if w == nil || w.ID() != id {
+				t.Errorf("expected policy %d to remove worker %d, got %v", policy, id, w)
+			}
+		}
+		// Cleanup
+		cancel()
+		pool.Wait()
+	}
+}
+
+func TestRemovalPolicyLeastBusy(t *testing.T) {
+	// Setup
+	busy, release := make(chan struct{}), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			close(busy)
+			<-release
+			return x, nil
+		}}, nil
+	}, gorkpool.WithRemovalPolicy(gorkpool.RemoveLeastBusy), gorkpool.WithOutputBufferSize(1))
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-busy
+	pool.AddWorker(1)
+	// Action
+	w := pool.RemoveWorker()
+	// Assert
+	if w == nil || w.ID() != 1 {
+		t.Errorf("expected idle worker 1 to be removed, got %v", w)
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
+
+func TestRemovalIdleFirst(t *testing.T) {
+	// Setup
+	busy, release := make(chan struct{}), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			close(busy)
+			<-release
+			return x, nil
+		}}, nil
+	}, gorkpool.WithRemovalPolicy(gorkpool.RemoveNewest), gorkpool.WithOutputBufferSize(1))
+	pool.AddWorker(1)
+	pool.AddTask(1)
+	<-busy
+	pool.AddWorker(0)
+	pool.AddWorker(2)
+	// Action
+	first := pool.RemoveWorker()
+	second := pool.RemoveWorker()
+	// Assert
+	if first == nil || first.ID() != 2 || second == nil || second.ID() != 0 {
+		t.Errorf("expected idle workers 2 then 0 to be removed before busy worker 1, got %v and %v", first, second)
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
+
+// oneShotWorker returns from Process after a single task, never taking the
+// removal signal afterwards.
+type oneShotWorker struct {
+	*testWorker
+}
+
+func (w oneShotWorker) Process() {
+	w.output <- -<-w.input
+}
+
+func TestRemoveReturnedWorker(t *testing.T) {
+	// Setup
+	stopping, release := make(chan int, 1), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return oneShotWorker{newTestWorker(id, ic, oc)}, nil
+	}, gorkpool.WithOutputBufferSize(1))
+	pool.OnWorkerStopped(func(id int) {
+		stopping <- id
+		<-release
+	})
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-pool.OutputCh()
+	// Returned, but not done yet
+	<-stopping
+	time.AfterFunc(10*time.Millisecond, func() {
+		close(release)
+	})
+	removeCtx, stop := context.WithTimeout(context.Background(), time.Second)
+	defer stop()
+	// Action
+	w, err := pool.RemoveWorkerByIdWait(removeCtx, 0)
+	// Assert
+	if err != nil || w == nil || w.ID() != 0 {
+		t.Errorf("expected worker 0 to be removed, got %v and %v", w, err)
+	}
+	cancel()
+	pool.Wait()
+	// SignalRemoval would block forever
+	if err := pool.VerifyClean(removeCtx); err != nil {
+		t.Errorf("expected the returned worker not to be signalled, got %v", err)
+	}
+}
+
+func TestRemoveWorkerShuttingDown(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	cancel()
+	pool.Wait()
+	// Action
+	_, err := pool.RemoveWorkerByIdWait(context.Background(), 0)
+	// Assert
+	if !errors.Is(err, gorkpool.CodePoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
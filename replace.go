@@ -0,0 +1,74 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+)
+
+// ReplaceWorker swaps the worker with id for a new one made by the factory,
+// only removing the old one once the new one started, so the pool never has
+// fewer workers. Tasks waiting in the queue of the old worker go back to the
+// pool queue.
+func (p *GorkPool[Id, Task, Result]) ReplaceWorker(id Id) error {
+	_, err := p.replaceWorker(id)
+	return err
+}
+
+// replaceWorker is ReplaceWorker returning the old worker.
+func (p *GorkPool[Id, Task, Result]) replaceWorker(id Id) (*workerState[Id, Task, Result], error) {
+	ws, err := p.create(id)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	old, ok := p.workers.get(id)
+	switch {
+	case !p.running():
+		err = NewErrPoolClosed()
+	case !ok:
+		err = NewErrWorkerNotFound(id)
+	case ws.worker.ID() != id:
+		err = NewErrIdConflict(ws.worker.ID())
+	}
+	if err != nil {
+		p.mutex.Unlock()
+		ws.discard()
+		return nil, err
+	}
+	p.unregister(id, old)
+	// It can't fail, old made room for it
+	_ = p.register(ws)
+	p.mutex.Unlock()
+
+	<-p.signal(old)
+	return old, nil
+}
+
+// RollingRestart replaces every worker of the pool with ReplaceWorker, one at
+// a time from the oldest, waiting for each old worker to be done before
+// moving on to the next. Long-lived pools pick up changes to the factory or
+// let go of leaked resources this way without downtime. Workers added or
+// removed meanwhile are left alone. If ctx is done while an old worker is
+// still stopping, it returns an ErrRemovalTimeout for it, leaving the rest of
+// the workers as they are.
+func (p *GorkPool[Id, Task, Result]) RollingRestart(ctx context.Context) error {
+	p.mutex.RLock()
+	workers := p.byAge()
+	p.mutex.RUnlock()
+
+	for _, ws := range workers {
+		old, err := p.replaceWorker(ws.worker.ID())
+		var notFound ErrWorkerNotFound
+		switch {
+		case errors.As(err, &notFound):
+			continue
+		case err != nil:
+			return err
+		}
+		if _, err := p.awaitRemoval(ctx, old); err != nil {
+			return err
+		}
+	}
+	return nil
+}
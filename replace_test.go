@@ -0,0 +1,91 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestReplaceWorker(t *testing.T) {
+	// Setup
+	var generation atomic.Int64
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		g := int(generation.Add(1))
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return g, ctx.Err()
+		}}, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	err := pool.ReplaceWorker(0)
+	missingErr := pool.ReplaceWorker(1)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected worker to be replaced, got %v", err)
+	}
+	if pool.Length() != 1 || !pool.Contains(0) {
+		t.Errorf("expected worker 0 to be the only worker, got %d workers", pool.Length())
+	}
+	f, _ := pool.Submit(1)
+	if got, _ := f.Wait(context.Background()); got != 2 {
+		t.Errorf("expected the new worker to handle the task, got generation %d", got)
+	}
+	if !errors.Is(missingErr, gorkpool.CodeWorkerNotFound) {
+		t.Errorf("expected ErrWorkerNotFound, got %v", missingErr)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRollingRestart(t *testing.T) {
+	// Setup
+	var (
+		mutex   sync.Mutex
+		created []int
+		stopped []int
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		created = append(created, id)
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return x, nil
+		}}, nil
+	})
+	for i := 0; i < 3; i++ {
+		pool.AddWorker(i)
+	}
+	pool.OnWorkerStopped(func(id int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		stopped = append(stopped, id)
+	})
+	// Action
+	err := pool.RollingRestart(context.Background())
+	// Assert
+	if err != nil {
+		t.Fatalf("expected workers to be restarted, got %v", err)
+	}
+	if pool.Length() != 3 {
+		t.Errorf("expected %d workers, got %d", 3, pool.Length())
+	}
+	mutex.Lock()
+	if want := []int{0, 1, 2, 0, 1, 2}; !reflect.DeepEqual(created, want) {
+		t.Errorf("expected workers to be created %v, got %v", want, created)
+	}
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(stopped, want) {
+		t.Errorf("expected old workers to stop one at a time %v, got %v", want, stopped)
+	}
+	mutex.Unlock()
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,54 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+)
+
+// HandBack queues task again for another worker. It is meant for channel
+// workers signalled to stop in the middle of a task, which would be lost
+// otherwise. TaskHandler workers don't need it: a task whose Handle returns
+// context.Canceled because the worker was removed is queued again by the
+// pool, without counting as an attempt.
+func (p *GorkPool[Id, Task, Result]) HandBack(task Task, opts ...TaskOption) error {
+	return p.pushCtx(context.Background(), p.newEnvelope(task, opts))
+}
+
+// interrupted tells whether err comes from ws being removed while handling a
+// task, rather than from the task itself. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) interrupted(ws *workerState[Id, Task, Result], err error) bool {
+	return err != nil && ws.ctx.Err() != nil && errors.Is(err, context.Canceled) && p.running()
+}
+
+// requeue queues env again after its worker was removed while handling it,
+// or its delivery was nacked.
+func (p *GorkPool[Id, Task, Result]) requeue(env *envelope[Task, Result]) {
+	env.attempt--
+	p.reroute(env)
+}
+
+// reroute queues env again for another worker, for one taken by a worker that
+// was removed in the meantime.
+func (p *GorkPool[Id, Task, Result]) reroute(env *envelope[Task, Result]) {
+	if env.owned {
+		p.launch("requeue", func() {
+			if !p.queue.slots.acquire(p.ctx.Done(), nil) {
+				p.mutex.Lock()
+				p.disown(env)
+				p.mutex.Unlock()
+				p.abandon(env)
+				return
+			}
+			p.queue.changed()
+			p.mutex.Lock()
+			p.returned(env)
+			p.mutex.Unlock()
+		})
+		return
+	}
+	p.launch("requeue", func() {
+		if !p.queue.push(env, p.ctx.Done(), nil) {
+			p.abandon(env)
+		}
+	})
+}
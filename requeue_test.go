@@ -0,0 +1,55 @@
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestRemoveWorkerRequeuesTask(t *testing.T) {
+	// Setup
+	started := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			if id == 0 {
+				started <- struct{}{}
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+			return -x, nil
+		}}, nil
+	})
+	pool.AddWorker(0)
+	f, _ := pool.Submit(1)
+	<-started
+	// Action
+	pool.RemoveWorkerById(0)
+	pool.AddWorker(1)
+	// Assert
+	if got, err := f.Wait(context.Background()); err != nil || got != -1 {
+		t.Errorf("expected task to be handled by another worker, got %d, %v", got, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestHandBack(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	// Action
+	err := pool.HandBack(1)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected task to be handed back, got %v", err)
+	}
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result to be %d, got %d", -1, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,46 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+)
+
+// Restart brings a stopped or failed pool back to life under ctx. The pool gets
+// fresh input and output channels, of the same capacity as the previous ones,
+// unless they are external, and the workers it had when it stopped are
+// recreated through the factory.
+// It must not be called concurrently with other methods of the pool, and
+// OutputCh must be called again to get the new output channel.
+func (p *GorkPool[Id, Task, Result]) Restart(ctx context.Context) error {
+	p.mutex.Lock()
+	if err := p.transitionLocked(StateRunning); err != nil {
+		p.mutex.Unlock()
+		return err
+	}
+	p.err = nil
+
+	ids := make([]Id, 0, p.workers.len())
+	p.workers.each(func(id Id, _ *workerState[Id, Task, Result]) bool {
+		ids = append(ids, id)
+		return true
+	})
+	p.workers.clear()
+	p.channelWorkers.Store(0)
+	p.handlerWorkers.Store(0)
+	p.readyCount.Store(0)
+
+	inputCh, outputCh := p.inputCh, p.outputCh
+	if !p.cfg.externalChannels {
+		inputCh, outputCh = make(chan Task, cap(inputCh)), make(chan Result, cap(outputCh))
+	}
+	p.start(ctx, inputCh, outputCh)
+	p.mutex.Unlock()
+
+	var errs []error
+	for _, id := range ids {
+		if err := p.AddWorker(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
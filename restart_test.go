@@ -0,0 +1,55 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestRestart(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	cancel()
+	pool.Wait()
+	ctx, cancelRestarted := context.WithCancel(context.Background())
+	// Action
+	err := pool.Restart(ctx)
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pool.State() != gorkpool.StateRunning {
+		t.Errorf("expected pool to be %v, got %v", gorkpool.StateRunning, pool.State())
+	}
+	if pool.Length() != 2 || !pool.Contains(0) || !pool.Contains(1) {
+		t.Errorf("expected workers 0 and 1 to be recreated, got %d workers", pool.Length())
+	}
+	pool.AddTask(1)
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result %d, got %d", -1, got)
+	}
+	// Cleanup
+	cancelRestarted()
+	pool.Wait()
+}
+
+func TestRestartRunning(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	err := pool.Restart(context.Background())
+	// Assert
+	var invalid gorkpool.ErrInvalidTransition
+	if !errors.As(err, &invalid) {
+		t.Errorf("expected ErrInvalidTransition, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
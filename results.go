@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package gorkpool
+
+import (
+	"context"
+	"iter"
+)
+
+// Results ranges over the results on OutputCh until the pool stops or ctx is
+// done.
+func (p *GorkPool[Id, Task, Result]) Results(ctx context.Context) iter.Seq[Result] {
+	outputCh, done := p.OutputCh(), p.Done()
+	return func(yield func(Result) bool) {
+		for {
+			select {
+			case result, ok := <-outputCh:
+				if !ok || !yield(result) {
+					return
+				}
+			case <-done:
+				// The output channel may be external and never closed
+				for {
+					select {
+					case result, ok := <-outputCh:
+						if !ok || !yield(result) {
+							return
+						}
+					default:
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+//go:build go1.23
+
+package gorkpool_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResults(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	// Action
+	sum, n := 0, 0
+	for result := range pool.Results(context.Background()) {
+		sum += result
+		if n++; n == 3 {
+			cancel()
+		}
+	}
+	// Assert
+	if sum != -6 {
+		t.Errorf("expected results to sum to %d, got %d", -6, sum)
+	}
+	// Cleanup
+	pool.Wait()
+}
+
+func TestResultsContextDone(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+	// Action
+	n := 0
+	for range pool.Results(ctx) {
+		n++
+	}
+	// Assert
+	if n != 0 {
+		t.Errorf("expected no results, got %d", n)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
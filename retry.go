@@ -0,0 +1,112 @@
+package gorkpool
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how tasks failed by TaskHandler workers are requeued.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	// MaxDelay caps the backoff, zero means no cap.
+	MaxDelay time.Duration
+	// Multiplier defaults to 2.
+	Multiplier float64
+	// Jitter is the fraction, in [0, 1], of each delay that is randomized.
+	Jitter float64
+}
+
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := r.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := float64(r.BaseDelay) * math.Pow(multiplier, float64(attempt-1))
+	if r.MaxDelay > 0 && delay > float64(r.MaxDelay) {
+		delay = float64(r.MaxDelay)
+	}
+	if r.Jitter > 0 {
+		delay -= delay * math.Min(r.Jitter, 1) * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// DeadLetter is a task that failed for good, either because it ran out of
+// attempts or because its worker reported an ErrPermanent.
+type DeadLetter[Task any] struct {
+	Task     Task
+	Err      error
+	Attempts int
+}
+
+func (p *GorkPool[Id, Task, Result]) SetRetryPolicy(policy RetryPolicy) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.retry = policy
+}
+
+// SetDeadLetterHandler registers fn to receive the tasks that failed for good.
+// It is called from the worker goroutine that saw the last failure.
+func (p *GorkPool[Id, Task, Result]) SetDeadLetterHandler(fn func(DeadLetter[Task])) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.deadLetter = fn
+}
+
+// fail requeues env after its backoff if it still has attempts left,
+// otherwise it is dead lettered.
+func (p *GorkPool[Id, Task, Result]) fail(env *envelope[Task, Result], err error) {
+	p.mutex.Lock()
+	policy := p.retry
+	canceled := env.canceled
+	p.mutex.Unlock()
+
+	if canceled {
+		return
+	}
+
+	var permanent ErrPermanent
+	if env.attempt >= policy.MaxAttempts || errors.As(err, &permanent) || env.expired(p.cfg.clock.Now()) {
+		p.drop(env, err)
+		return
+	}
+
+	p.after(policy.backoff(env.attempt), func() {
+		if p.isCanceled(env) {
+			return
+		}
+		if !p.queue.push(env, p.ctx.Done(), nil) {
+			p.abandon(env)
+		}
+	}, func() {
+		p.abandon(env)
+	})
+}
+
+// drop gives up on env, handing it to the dead letter handler if there is one.
+func (p *GorkPool[Id, Task, Result]) drop(env *envelope[Task, Result], err error) {
+	p.finish(env)
+
+	p.mutex.Lock()
+	deadLetter := p.deadLetter
+	p.mutex.Unlock()
+
+	p.logf("gorkpool: dropping task after %d attempt(s): %v", env.attempt, err)
+	p.recordErr(p.taskFailed(env, err))
+	var zero Result
+	p.settle(env, zero, err)
+
+	if deadLetter != nil {
+		deadLetter(DeadLetter[Task]{
+			Task:     env.task,
+			Err:      err,
+			Attempts: env.attempt,
+		})
+	}
+}
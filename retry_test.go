@@ -0,0 +1,113 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestRetry(t *testing.T) {
+	// Setup
+	var attempts atomic.Int32
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if attempts.Add(1) < 3 {
+			return 0, errors.New("flaky")
+		}
+		return -x, nil
+	})
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Jitter: 0.5})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result to be %d, got %d", -1, got)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected %d attempts, got %d", 3, attempts.Load())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRetryExhausted(t *testing.T) {
+	// Setup
+	var attempts atomic.Int32
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		attempts.Add(1)
+		return 0, errors.New("broken")
+	})
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	waitFor(t, func() bool { return attempts.Load() == 2 })
+	time.Sleep(10 * time.Millisecond)
+	if attempts.Load() != 2 {
+		t.Errorf("expected %d attempts, got %d", 2, attempts.Load())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestDeadLetter(t *testing.T) {
+	// Setup
+	failure := errors.New("broken")
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return 0, failure
+	})
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	deadLetters := make(chan gorkpool.DeadLetter[int], 1)
+	pool.SetDeadLetterHandler(func(dl gorkpool.DeadLetter[int]) {
+		deadLetters <- dl
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	dl := <-deadLetters
+	if dl.Task != 1 {
+		t.Errorf("expected dead letter task to be %d, got %d", 1, dl.Task)
+	}
+	if dl.Attempts != 3 {
+		t.Errorf("expected dead letter after %d attempts, got %d", 3, dl.Attempts)
+	}
+	if !errors.Is(dl.Err, failure) {
+		t.Errorf("expected dead letter error to be %v, got %v", failure, dl.Err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestDeadLetterPermanent(t *testing.T) {
+	// Setup
+	var attempts atomic.Int32
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		attempts.Add(1)
+		return 0, gorkpool.NewErrPermanent(errors.New("bad input"))
+	})
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond})
+	deadLetters := make(chan gorkpool.DeadLetter[int], 1)
+	pool.SetDeadLetterHandler(func(dl gorkpool.DeadLetter[int]) {
+		deadLetters <- dl
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	dl := <-deadLetters
+	if dl.Attempts != 1 || attempts.Load() != 1 {
+		t.Errorf("expected permanent failure not to be retried, got %d attempts", attempts.Load())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
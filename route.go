@@ -0,0 +1,37 @@
+package gorkpool
+
+type outputRoute[Result any] struct {
+	match func(Result) bool
+	ch    chan Result
+}
+
+// RouteOutput sends the results matching predicate to ch instead of OutputCh,
+// so they can be split into several channels without a demultiplexing
+// goroutine downstream. Routes are tried in the order they were added, the
+// first match winning, after the result interceptors ran. Like those, they
+// only see the results the pool sends itself, not those written by channel
+// workers or of Submit. ch belongs to the caller and is never closed by the
+// pool, so it must be read until the pool is done.
+func (p *GorkPool[Id, Task, Result]) RouteOutput(predicate func(Result) bool, ch chan Result) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	routes := make([]outputRoute[Result], 0, len(p.routes)+1)
+	routes = append(routes, p.routes...)
+	p.routes = append(routes, outputRoute[Result]{match: predicate, ch: ch})
+}
+
+// output sends result to the channel of the first route it matches, OutputCh
+// otherwise.
+func (p *GorkPool[Id, Task, Result]) output(result Result) {
+	p.mutex.RLock()
+	routes := p.routes
+	p.mutex.RUnlock()
+
+	for _, route := range routes {
+		if route.match(result) {
+			route.ch <- result
+			return
+		}
+	}
+	p.outputCh <- result
+}
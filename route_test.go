@@ -0,0 +1,37 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+func TestRouteOutput(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	evens := make(chan int, 4)
+	pool.RouteOutput(func(result int) bool {
+		return result%2 == 0
+	}, evens)
+	pool.AddWorker(0)
+	// Action
+	for i := 1; i <= 4; i++ {
+		pool.AddTask(i)
+	}
+	routed := []int{<-evens, <-evens}
+	rest := []int{<-pool.OutputCh(), <-pool.OutputCh()}
+	// Assert
+	sort.Ints(routed)
+	sort.Ints(rest)
+	if routed[0] != -4 || routed[1] != -2 {
+		t.Errorf("expected results -4 and -2 to be routed, got %v", routed)
+	}
+	if rest[0] != -3 || rest[1] != -1 {
+		t.Errorf("expected results -3 and -1 on OutputCh, got %v", rest)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
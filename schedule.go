@@ -0,0 +1,166 @@
+package gorkpool
+
+import (
+	"sync"
+	"time"
+)
+
+// Schedule is a handle to a task submission that will happen in the future.
+type Schedule struct {
+	mutex   *sync.Mutex
+	timer   Timer
+	stopped bool
+	release func()
+	// abandon is called when shutdown stops a pending schedule
+	abandon func()
+}
+
+// Stop cancels the scheduled submission. It returns false if it was already
+// stopped or there was no submission pending.
+func (s *Schedule) Stop() bool {
+	s.mutex.Lock()
+	if s.stopped {
+		s.mutex.Unlock()
+		return false
+	}
+	s.stopped = true
+	pending := s.timer.Stop()
+	s.mutex.Unlock()
+
+	s.release()
+	return pending
+}
+
+func (p *GorkPool[Id, Task, Result]) SubmitAfter(d time.Duration, task Task, opts ...TaskOption) *Schedule {
+	return p.after(d, func() {
+		p.enqueue(task, opts)
+	}, nil)
+}
+
+func (p *GorkPool[Id, Task, Result]) SubmitAt(t time.Time, task Task, opts ...TaskOption) *Schedule {
+	return p.SubmitAfter(t.Sub(p.cfg.clock.Now()), task, opts...)
+}
+
+func (p *GorkPool[Id, Task, Result]) SubmitEvery(interval time.Duration, taskFactory func() Task, opts ...TaskOption) *Schedule {
+	return p.recur(func(t time.Time) time.Time {
+		return t.Add(interval)
+	}, taskFactory, opts)
+}
+
+// SubmitCron submits a task built by taskFactory on every time matching the
+// standard five field cron expression (minute hour day-of-month month day-of-week).
+func (p *GorkPool[Id, Task, Result]) SubmitCron(expr string, taskFactory func() Task, opts ...TaskOption) (*Schedule, error) {
+	c, err := parseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return p.recur(c.next, taskFactory, opts), nil
+}
+
+func (p *GorkPool[Id, Task, Result]) recur(next func(time.Time) time.Time, taskFactory func() Task, opts []TaskOption) *Schedule {
+	s := p.newSchedule()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	at := next(p.cfg.clock.Now())
+	if at.IsZero() {
+		s.stopped = true
+		return s
+	}
+	s.timer = p.cfg.clock.AfterFunc(at.Sub(p.cfg.clock.Now()), func() {
+		s.mutex.Lock()
+		if s.stopped || p.ctx.Err() != nil {
+			s.mutex.Unlock()
+			return
+		}
+		task := taskFactory()
+		if at := next(p.cfg.clock.Now()); at.IsZero() {
+			s.stopped = true
+			defer s.release()
+		} else {
+			s.timer.Reset(at.Sub(p.cfg.clock.Now()))
+		}
+		s.mutex.Unlock()
+
+		p.enqueue(task, opts)
+	})
+	p.schedule(s)
+
+	return s
+}
+
+// after runs fn once d has elapsed, unless the pool shuts down first, in which
+// case abandon is called instead.
+func (p *GorkPool[Id, Task, Result]) after(d time.Duration, fn func(), abandon func()) *Schedule {
+	s := p.newSchedule()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.abandon = abandon
+	s.timer = p.cfg.clock.AfterFunc(d, func() {
+		if p.unschedule(s) {
+			fn()
+		}
+	})
+	p.schedule(s)
+
+	return s
+}
+
+func (p *GorkPool[Id, Task, Result]) newSchedule() *Schedule {
+	s := &Schedule{mutex: &sync.Mutex{}}
+	s.release = func() {
+		p.mutex.Lock()
+		delete(p.schedules, s)
+		p.mutex.Unlock()
+	}
+	return s
+}
+
+// schedule registers s so it is stopped on shutdown. The caller must hold s.mutex.
+func (p *GorkPool[Id, Task, Result]) schedule(s *Schedule) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.ctx.Err() != nil {
+		s.stopped = true
+		s.timer.Stop()
+		if s.abandon != nil {
+			s.abandon()
+		}
+		return
+	}
+	p.schedules[s] = struct{}{}
+}
+
+// unschedule reports whether s was still pending and marks it as fired.
+func (p *GorkPool[Id, Task, Result]) unschedule(s *Schedule) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.stopped = true
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.schedules, s)
+	return p.ctx.Err() == nil
+}
+
+func (p *GorkPool[Id, Task, Result]) stopSchedules() {
+	p.mutex.Lock()
+	schedules := p.schedules
+	p.schedules = make(map[*Schedule]struct{})
+	p.mutex.Unlock()
+
+	for s := range schedules {
+		s.mutex.Lock()
+		abandon := s.abandon
+		s.mutex.Unlock()
+
+		if s.Stop() && abandon != nil {
+			abandon()
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestSubmitAfter(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	// Action
+	start := time.Now()
+	pool.SubmitAfter(20*time.Millisecond, 1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result to be %d, got %d", -1, got)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected task to be submitted after %v, got %v", 20*time.Millisecond, elapsed)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitAtStop(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	s := pool.SubmitAt(time.Now().Add(20*time.Millisecond), 1)
+	// Action
+	stopped := s.Stop()
+	// Assert
+	if !stopped {
+		t.Error("expected pending schedule to be stopped")
+	}
+	if s.Stop() {
+		t.Error("expected second stop to return false")
+	}
+	select {
+	case got := <-pool.OutputCh():
+		t.Errorf("expected no result, got %d", got)
+	case <-time.After(40 * time.Millisecond):
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitAfterCancelledOnShutdown(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	s := pool.SubmitAfter(time.Hour, 1)
+	// Action
+	cancel()
+	pool.Wait()
+	// Assert
+	if s.Stop() {
+		t.Error("expected schedule to have been stopped by shutdown")
+	}
+}
+
+func TestSubmitEvery(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	n := 0
+	// Action
+	s := pool.SubmitEvery(5*time.Millisecond, func() int {
+		n++
+		return n
+	})
+	// Assert
+	for i := 1; i <= 3; i++ {
+		if got := <-pool.OutputCh(); got != -i {
+			t.Errorf("expected result to be %d, got %d", -i, got)
+		}
+	}
+	if !s.Stop() {
+		t.Error("expected recurring schedule to be pending")
+	}
+	// Cleanup
+	cancel()
+	for range pool.OutputCh() {
+	}
+}
+
+func TestSubmitCronInvalid(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	exprs := []string{"* * * *", "60 * * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"}
+	for _, expr := range exprs {
+		// Action
+		_, err := pool.SubmitCron(expr, func() int { return 0 })
+		// Assert
+		var cronErr gorkpool.ErrInvalidCronExpr
+		if !errors.As(err, &cronErr) {
+			t.Errorf("expected %q to be rejected with ErrInvalidCronExpr, got %v", expr, err)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitCronStop(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	s, err := pool.SubmitCron("*/15 9-17 * * 1-5", func() int { return 0 })
+	// Assert
+	if err != nil {
+		t.Fatalf("expected valid cron expression, got %v", err)
+	}
+	if !s.Stop() {
+		t.Error("expected cron schedule to be pending")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestSubmitCronDayStep(t *testing.T) {
+	// Setup
+	// A Monday
+	clock := gorkpool.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 30, 0, time.UTC))
+	pool := gorkpool.NewFuncPool(context.Background(), 1, func(x int) int { return x }, gorkpool.WithClock(clock), gorkpool.WithOutputBufferSize(1))
+	// Action
+	// Odd days that are Mondays, */2 restricting the day of the month like *
+	s, err := pool.SubmitCron("0 0 */2 * 1", func() int { return 1 })
+	if err != nil {
+		t.Fatalf("expected valid cron expression, got %v", err)
+	}
+	clock.Advance(14*24*time.Hour - time.Minute)
+	// Assert
+	select {
+	case <-pool.OutputCh():
+		t.Error("expected nothing before Monday the 15th")
+	case <-time.After(50 * time.Millisecond):
+	}
+	clock.Advance(time.Minute)
+	select {
+	case <-pool.OutputCh():
+	case <-time.After(time.Second):
+		t.Error("expected a submission on Monday the 15th")
+	}
+	// Cleanup
+	s.Stop()
+	pool.Shutdown(context.Background())
+}
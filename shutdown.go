@@ -0,0 +1,128 @@
+package gorkpool
+
+import (
+	"context"
+	"time"
+)
+
+// Shutdown stops the pool like cancelling its context does, and waits for the
+// queued tasks to be processed and the workers to exit. If ctx is done first
+// it returns an ErrShutdownTimeout listing the workers still running.
+func (p *GorkPool[Id, Task, Result]) Shutdown(ctx context.Context) error {
+	p.cancel(nil)
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		running := p.runningWorkers()
+		p.mutex.Lock()
+		p.report.TimedOut = running
+		p.mutex.Unlock()
+		return NewErrShutdownTimeout(running, ctx.Err())
+	}
+}
+
+// Report sums up a shutdown of the pool. Completed and Failed count the tasks
+// done while draining, Dropped those abandoned with ErrPoolClosed, of which
+// Persisted were saved to the TaskStore. Abandoned lists the tasks still
+// queued when Kill, Drain or WithDrainTimeout cut the drain short. TimedOut
+// lists the workers still running when a Shutdown or WithDrainTimeout gave up
+// waiting for them.
+type Report struct {
+	Completed int
+	Failed    int
+	Dropped   int
+	Persisted int
+	Abandoned []any
+	TimedOut  []any
+	Duration  time.Duration
+}
+
+// ShutdownReport is Shutdown returning the Report of the shutdown as well. If
+// ctx is done first, the Report so far only has the workers that timed out.
+func (p *GorkPool[Id, Task, Result]) ShutdownReport(ctx context.Context) (Report, error) {
+	if err := p.Shutdown(ctx); err != nil {
+		p.mutex.RLock()
+		defer p.mutex.RUnlock()
+		return Report{TimedOut: p.report.TimedOut}, err
+	}
+	report, _ := p.FinalReport()
+	return report, nil
+}
+
+// FinalReport returns the Report of the last shutdown, once it is done.
+func (p *GorkPool[Id, Task, Result]) FinalReport() (Report, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if !p.reported {
+		return Report{}, false
+	}
+	return p.report, true
+}
+
+func (p *GorkPool[Id, Task, Result]) runningWorkers() []any {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	ids := make([]any, 0)
+	p.workers.each(func(id Id, ws *workerState[Id, Task, Result]) bool {
+		if !ws.exited() {
+			ids = append(ids, id)
+		}
+		return true
+	})
+	// Removed workers are waited for as well
+	p.workers.eachLeaving(func(ws *workerState[Id, Task, Result]) {
+		if !ws.exited() {
+			ids = append(ids, ws.worker.ID())
+		}
+	})
+	return ids
+}
+
+// Done is closed once every worker has exited and the output channel is closed.
+func (p *GorkPool[Id, Task, Result]) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait blocks until Done is closed.
+func (p *GorkPool[Id, Task, Result]) Wait() {
+	<-p.done
+}
+
+// OnShutdownStart registers fn to be called once the pool starts shutting
+// down, before it drains, say to deregister from service discovery. It runs
+// on the pool's goroutine, holding up the shutdown.
+func (p *GorkPool[Id, Task, Result]) OnShutdownStart(fn func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.shutdownStart = appendHook(p.shutdownStart, fn)
+}
+
+// OnShutdownComplete registers fn to be called once the pool is drained and
+// its workers exited, with the cause of a StateFailed pool or nil. Done is
+// only closed after fn returns, so fn must not wait for it.
+func (p *GorkPool[Id, Task, Result]) OnShutdownComplete(fn func(err error)) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.shutdownComplete = appendHook(p.shutdownComplete, fn)
+}
+
+// OnWorkerStopped registers fn to be called with the id of every worker that
+// exits, whether it was removed or the pool stopped, after its Lifecycle Stop
+// if it has one. It runs on the worker's goroutine.
+func (p *GorkPool[Id, Task, Result]) OnWorkerStopped(fn func(id Id)) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.workerStopped = appendHook(p.workerStopped, fn)
+}
+
+func (p *GorkPool[Id, Task, Result]) stopped(id Id) {
+	p.mutex.RLock()
+	hooks := p.workerStopped
+	p.mutex.RUnlock()
+	for _, fn := range hooks {
+		fn(id)
+	}
+}
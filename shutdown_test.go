@@ -0,0 +1,174 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestShutdown(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	defer cancel()
+	pool.AddWorker(0)
+	for i := 0; i < 15; i++ {
+		pool.AddTask(i)
+	}
+	results := make(chan int, 20)
+	go func() {
+		for r := range pool.OutputCh() {
+			results <- r
+		}
+		close(results)
+	}()
+	// Action
+	err := pool.Shutdown(context.Background())
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	n := 0
+	for range results {
+		n++
+	}
+	if n != 15 {
+		t.Errorf("expected queued tasks to be drained, got %d of %d results", n, 15)
+	}
+	if pool.State() != gorkpool.StateStopped {
+		t.Errorf("expected pool to be %v, got %v", gorkpool.StateStopped, pool.State())
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		<-release
+		return -x, nil
+	})
+	defer cancel()
+	pool.AddWorker(7)
+	pool.AddTask(1)
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShutdown()
+	// Action
+	err := pool.Shutdown(ctx)
+	// Assert
+	var timeout gorkpool.ErrShutdownTimeout
+	if !errors.As(err, &timeout) {
+		t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+	}
+	if ids := timeout.Workers(); len(ids) != 1 || ids[0] != 7 {
+		t.Errorf("expected worker 7 to be reported, got %v", ids)
+	}
+	// Cleanup
+	close(release)
+	for range pool.OutputCh() {
+	}
+}
+
+func TestWait(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	// Assert
+	select {
+	case <-pool.Done():
+		t.Error("expected running pool not to be done")
+	default:
+	}
+	// Action
+	cancel()
+	pool.Wait()
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected buffered result %d, got %d", -1, got)
+	}
+	if _, ok := <-pool.OutputCh(); ok {
+		t.Error("expected output channel to be closed once the pool is done")
+	}
+}
+
+func TestShutdownHooks(t *testing.T) {
+	// Setup
+	var (
+		mutex  sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 0, func(ctx context.Context, x int) (int, error) {
+		return x, nil
+	})
+	pool.OnShutdownStart(func() {
+		record("start")
+	})
+	pool.OnWorkerStopped(func(id int) {
+		record(fmt.Sprintf("worker %d", id))
+	})
+	pool.OnShutdownComplete(func(err error) {
+		record(fmt.Sprintf("complete %v", err))
+	})
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	pool.RemoveWorkerByIdWait(context.Background(), 1)
+	// Action
+	err := pool.Shutdown(context.Background())
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []string{"worker 1", "start", "worker 0", "complete <nil>"}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("expected events %v, got %v", want, events)
+	}
+}
+
+func TestShutdownReport(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 0, func(ctx context.Context, x int) (int, error) {
+		<-release
+		if x%2 == 1 {
+			return 0, errors.New("odd")
+		}
+		return x, nil
+	}, gorkpool.WithQueueSize(10), gorkpool.WithOutputBufferSize(10))
+	pool.OnShutdownStart(func() {
+		close(release)
+	})
+	pool.AddWorker(0)
+	for i := 0; i < 10; i++ {
+		pool.AddTask(i)
+	}
+	if _, ok := pool.FinalReport(); ok {
+		t.Fatalf("expected no report before shutdown")
+	}
+	// Action
+	report, err := pool.ShutdownReport(context.Background())
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Completed+report.Failed != 10 || report.Failed != 5 {
+		t.Errorf("expected 10 tasks done during drain, 5 failed, got %+v", report)
+	}
+	if report.Dropped != 0 || len(report.TimedOut) != 0 {
+		t.Errorf("expected nothing dropped or timed out, got %+v", report)
+	}
+	if final, ok := pool.FinalReport(); !ok || !reflect.DeepEqual(final, report) {
+		t.Errorf("expected final report %+v, got %+v", report, final)
+	}
+}
@@ -0,0 +1,49 @@
+package gorkpool
+
+// AddSource makes the pool take tasks from ch, submitted with opts, until ch is
+// closed. A graceful shutdown keeps taking them until every source is closed,
+// so the pool only stops once all of them are drained, while Kill and Drain
+// stop reading them right away.
+func (p *GorkPool[Id, Task, Result]) AddSource(ch <-chan Task, opts ...TaskOption) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.running() {
+		return NewErrPoolClosed()
+	}
+
+	p.sources.Add(1)
+	p.launch("source", func() {
+		p.consume(ch, opts)
+	})
+	return nil
+}
+
+func (p *GorkPool[Id, Task, Result]) consume(ch <-chan Task, opts []TaskOption) {
+	defer p.sources.Done()
+	for {
+		select {
+		case task, ok := <-ch:
+			if !ok {
+				return
+			}
+			env := p.newEnvelope(task, opts)
+			if ok, err := p.offer(env, p.sourcesStop, nil); err != nil {
+				p.drop(env, err)
+			} else if !ok {
+				p.abandon(env)
+			}
+		case <-p.sourcesStop:
+			return
+		}
+	}
+}
+
+// sourcesDone is closed once every source is closed or stopped.
+func (p *GorkPool[Id, Task, Result]) sourcesDone() <-chan struct{} {
+	done := make(chan struct{})
+	p.launch("sources", func() {
+		p.sources.Wait()
+		close(done)
+	})
+	return done
+}
@@ -0,0 +1,40 @@
+package gorkpool_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddSource(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	a, b := make(chan int), make(chan int)
+	pool.AddSource(a)
+	pool.AddSource(b)
+	sum := make(chan int)
+	go func() {
+		total := 0
+		for x := range pool.OutputCh() {
+			total += x
+		}
+		sum <- total
+	}()
+	// Action
+	a <- 1
+	b <- 2
+	cancel()
+	a <- 3
+	close(a)
+	// Assert
+	select {
+	case <-pool.Done():
+		t.Error("expected pool to wait for every source to be closed")
+	case <-time.After(20 * time.Millisecond):
+	}
+	b <- 4
+	close(b)
+	if got := <-sum; got != -10 {
+		t.Errorf("expected results to sum to %d, got %d", -10, got)
+	}
+}
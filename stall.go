@@ -0,0 +1,130 @@
+package gorkpool
+
+import (
+	"sort"
+	"time"
+)
+
+// WithStallDetection calls fn once the pool has had tasks waiting or in
+// flight for after without any of them being dispatched or completed. It is
+// called again only after the pool makes progress and stalls anew. DumpState
+// tells what the workers are up to.
+func WithStallDetection(after time.Duration, fn func()) Option {
+	return func(c *config) {
+		c.stallAfter = after
+		c.onStall = fn
+	}
+}
+
+// StateDump is a snapshot of the pool for diagnostics. It marshals to JSON, to
+// attach to bug reports or serve from admin endpoints.
+type StateDump[Id comparable] struct {
+	State    State `json:"state"`
+	Paused   bool  `json:"paused"`
+	Queued   int   `json:"queued"`
+	InFlight int   `json:"in_flight"`
+	// LastProgress is when a task was last dispatched or completed
+	LastProgress time.Time        `json:"last_progress"`
+	Workers      []WorkerDump[Id] `json:"workers"`
+	Queue        QueueDump        `json:"queue"`
+	Stats        Stats            `json:"stats"`
+	Config       ConfigDump       `json:"config"`
+}
+
+// WorkerDump is what DumpState knows about a worker. The pool only sees what
+// TaskHandler workers are doing, so Busy and LastActive stay zero for the
+// other workers.
+type WorkerDump[Id comparable] struct {
+	ID         Id          `json:"id"`
+	Busy       int         `json:"busy"`
+	Queued     int         `json:"queued"`
+	LastActive time.Time   `json:"last_active"`
+	Exited     bool        `json:"exited"`
+	Paused     bool        `json:"paused"`
+	Ready      bool        `json:"ready"`
+	Capacity   int         `json:"capacity"`
+	Tags       []string    `json:"tags,omitempty"`
+	Stats      WorkerStats `json:"stats"`
+}
+
+// DumpState takes a snapshot of the pool, with its workers sorted by when they
+// were added.
+func (p *GorkPool[Id, Task, Result]) DumpState() StateDump[Id] {
+	queued := p.QueueLen()
+	queue := p.queue.dump()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	now := p.cfg.clock.Now()
+	dump := StateDump[Id]{
+		State:        p.state,
+		Paused:       p.resumed != nil,
+		Queued:       queued,
+		InFlight:     p.inFlight,
+		LastProgress: p.lastProgress,
+		Workers:      make([]WorkerDump[Id], 0, p.workers.len()),
+		Queue:        queue,
+		Stats: Stats{
+			QueueWait:  p.queueWait.snapshot(),
+			Processing: p.processing.snapshot(),
+		},
+		Config: p.cfg.dump(),
+	}
+	seqs := make(map[Id]uint64, p.workers.len())
+	p.workers.each(func(id Id, ws *workerState[Id, Task, Result]) bool {
+		seqs[id] = ws.seq
+		dump.Workers = append(dump.Workers, WorkerDump[Id]{
+			ID:         id,
+			Busy:       ws.busy,
+			Queued:     len(ws.queue) + len(ws.inputCh),
+			LastActive: ws.lastActive,
+			Exited:     ws.exited(),
+			Paused:     ws.paused(),
+			Ready:      ws.ready,
+			Capacity:   ws.capacity,
+			Tags:       ws.tagList(),
+			Stats:      ws.stats(now),
+		})
+		return true
+	})
+	sort.Slice(dump.Workers, func(i, j int) bool {
+		return seqs[dump.Workers[i].ID] < seqs[dump.Workers[j].ID]
+	})
+	return dump
+}
+
+func (p *GorkPool[Id, Task, Result]) progressed() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.lastProgress = p.cfg.clock.Now()
+}
+
+func (p *GorkPool[Id, Task, Result]) detectStalls() {
+	interval := p.cfg.stallAfter / 4
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := p.cfg.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	var reported time.Time
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case now := <-ticker.C():
+			// Tasks queued in a paused pool aren't expected to move
+			pending := (p.QueueLen() > 0 && !p.Paused()) || p.InFlight() > 0
+			p.mutex.Lock()
+			last := p.lastProgress
+			p.mutex.Unlock()
+
+			if !pending || now.Sub(last) < p.cfg.stallAfter || last.Equal(reported) {
+				continue
+			}
+			reported = last
+			p.logf("gorkpool: no progress since %v", last.Format(time.RFC3339Nano))
+			p.cfg.onStall()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package gorkpool_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithStallDetection(t *testing.T) {
+	// Setup
+	stalled := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			<-release
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithOutputBufferSize(2), gorkpool.WithStallDetection(10*time.Millisecond, func() {
+		stalled <- struct{}{}
+	}))
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	select {
+	case <-stalled:
+	case <-time.After(time.Second):
+		t.Fatal("expected stall to be detected")
+	}
+	dump := pool.DumpState()
+	if dump.InFlight != 1 || len(dump.Workers) != 1 || dump.Workers[0].Busy != 1 {
+		t.Errorf("expected dump to show the busy worker, got %+v", dump)
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
+
+func TestDumpStateJSON(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, double, gorkpool.WithQueueSize(10))
+	pool.Pause()
+	first, _ := pool.Submit(1, gorkpool.WithPriority(2))
+	second, _ := pool.Submit(2, gorkpool.WithTenant("acme"))
+	// Action
+	b, err := json.Marshal(pool.DumpState())
+	// Assert
+	if err != nil {
+		t.Fatalf("expected the dump to marshal, got %v", err)
+	}
+	var dump struct {
+		State   string `json:"state"`
+		Paused  bool   `json:"paused"`
+		Workers []struct {
+			ID       int `json:"id"`
+			Capacity int `json:"capacity"`
+		} `json:"workers"`
+		Queue struct {
+			Len        int            `json:"len"`
+			ByPriority map[int]int    `json:"by_priority"`
+			ByTenant   map[string]int `json:"by_tenant"`
+		} `json:"queue"`
+		Config struct {
+			QueueSize int `json:"queue_size"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(b, &dump); err != nil {
+		t.Fatalf("expected JSON, got %v", err)
+	}
+	if dump.State != "running" || !dump.Paused {
+		t.Errorf("expected a paused running pool, got state %q and paused %v", dump.State, dump.Paused)
+	}
+	if len(dump.Workers) != 1 || dump.Workers[0].Capacity != 1 {
+		t.Errorf("expected 1 worker of capacity 1, got %+v", dump.Workers)
+	}
+	if want := map[int]int{0: 1, 2: 1}; dump.Queue.Len != 2 || !reflect.DeepEqual(dump.Queue.ByPriority, want) {
+		t.Errorf("expected 2 queued tasks by priority %v, got %d by %v", want, dump.Queue.Len, dump.Queue.ByPriority)
+	}
+	if dump.Queue.ByTenant["acme"] != 1 {
+		t.Errorf("expected 1 queued task of tenant acme, got %v", dump.Queue.ByTenant)
+	}
+	if dump.Config.QueueSize != 10 {
+		t.Errorf("expected queue size %d in the config, got %d", 10, dump.Config.QueueSize)
+	}
+	// Cleanup
+	pool.Resume()
+	first.Wait(context.Background())
+	second.Wait(context.Background())
+	pool.Shutdown(context.Background())
+}
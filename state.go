@@ -0,0 +1,86 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+)
+
+type State int
+
+const (
+	// StateRunning pools accept workers and tasks.
+	StateRunning State = iota
+	// StateDraining pools finish their queued tasks and wait for their workers.
+	StateDraining
+	// StateStopped pools are done and have closed their output channel.
+	StateStopped
+	// StateFailed pools are done too, but their context was cancelled with a
+	// cause, available from Err.
+	StateFailed
+)
+
+var stateNames = [...]string{"running", "draining", "stopped", "failed"}
+
+func (s State) String() string {
+	if s < 0 || int(s) >= len(stateNames) {
+		return "unknown"
+	}
+	return stateNames[s]
+}
+
+// MarshalText makes the state marshal to its name, as in a StateDump.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+var stateTransitions = map[State][]State{
+	StateRunning:  {StateDraining},
+	StateDraining: {StateStopped, StateFailed},
+	StateStopped:  {StateRunning},
+	StateFailed:   {StateRunning},
+}
+
+func (p *GorkPool[Id, Task, Result]) State() State {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.state
+}
+
+// Err returns the cause of a StateFailed pool, nil otherwise.
+func (p *GorkPool[Id, Task, Result]) Err() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.err
+}
+
+func (p *GorkPool[Id, Task, Result]) transition(to State) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.transitionLocked(to)
+}
+
+// transitionLocked is transition for callers already holding p.mutex.
+func (p *GorkPool[Id, Task, Result]) transitionLocked(to State) error {
+	for _, allowed := range stateTransitions[p.state] {
+		if allowed == to {
+			p.state = to
+			return nil
+		}
+	}
+	return NewErrInvalidTransition(p.state, to)
+}
+
+// running reports whether the pool still accepts work. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) running() bool {
+	return p.state == StateRunning && p.ctx.Err() == nil
+}
+
+// finalState is where a drained pool ends: failed if its context was
+// cancelled with a cause of its own.
+func (p *GorkPool[Id, Task, Result]) finalState() (State, error) {
+	cause := context.Cause(p.ctx)
+	if cause == nil || errors.Is(cause, context.Canceled) || errors.Is(cause, context.DeadlineExceeded) {
+		return StateStopped, nil
+	}
+	return StateFailed, cause
+}
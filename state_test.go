@@ -0,0 +1,53 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestState(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	// Assert
+	if pool.State() != gorkpool.StateRunning {
+		t.Errorf("expected pool to be %v, got %v", gorkpool.StateRunning, pool.State())
+	}
+	// Action
+	cancel()
+	for range pool.OutputCh() {
+	}
+	// Assert
+	if pool.State() != gorkpool.StateStopped {
+		t.Errorf("expected pool to be %v, got %v", gorkpool.StateStopped, pool.State())
+	}
+	if err := pool.AddWorker(1); !errors.Is(err, gorkpool.NewErrPoolClosed()) {
+		t.Errorf("expected %v, got %v", gorkpool.NewErrPoolClosed(), err)
+	}
+	if w := pool.RemoveWorker(); w != nil {
+		t.Errorf("expected no worker to be removed from a stopped pool, got %d", w.ID())
+	}
+}
+
+func TestStateFailed(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cause := errors.New("upstream is gone")
+	pool := gorkpool.NewGorkPool(ctx, make(chan int), make(chan int), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return newTestWorker(id, ic, oc), nil
+	})
+	// Action
+	cancel(cause)
+	for range pool.OutputCh() {
+	}
+	// Assert
+	if pool.State() != gorkpool.StateFailed {
+		t.Errorf("expected pool to be %v, got %v", gorkpool.StateFailed, pool.State())
+	}
+	if !errors.Is(pool.Err(), cause) {
+		t.Errorf("expected pool error to be %v, got %v", cause, pool.Err())
+	}
+}
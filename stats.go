@@ -0,0 +1,75 @@
+package gorkpool
+
+import "time"
+
+// WorkerStats is what a worker has done since it was added. The pool only
+// sees the tasks of TaskHandler workers, so the counters stay zero and the
+// worker counts as idle for the other workers.
+type WorkerStats struct {
+	Processed  int           `json:"processed"`
+	Errors     int           `json:"errors"`
+	BusyTime   time.Duration `json:"busy_time"`
+	IdleTime   time.Duration `json:"idle_time"`
+	LastActive time.Time     `json:"last_active"`
+	// Trips is how many times the circuit breaker of the worker tripped
+	Trips int `json:"trips"`
+}
+
+// WorkerStats returns the stats of the worker with the given id, if it is
+// part of the pool.
+func (p *GorkPool[Id, Task, Result]) WorkerStats(id Id) (WorkerStats, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	ws, ok := p.workers.get(id)
+	if !ok {
+		return WorkerStats{}, false
+	}
+	return ws.stats(p.cfg.clock.Now()), true
+}
+
+// AllWorkerStats returns the stats of every worker of the pool.
+func (p *GorkPool[Id, Task, Result]) AllWorkerStats() map[Id]WorkerStats {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	now := p.cfg.clock.Now()
+	stats := make(map[Id]WorkerStats, p.workers.len())
+	p.workers.eachShared(func(id Id, ws *workerState[Id, Task, Result]) bool {
+		stats[id] = ws.stats(now)
+		return true
+	})
+	return stats
+}
+
+// The caller must hold p.mutex.
+func (ws *workerState[Id, Task, Result]) stats(now time.Time) WorkerStats {
+	busy := ws.busyTime
+	if ws.busy > 0 {
+		busy += now.Sub(ws.busySince)
+	}
+	return WorkerStats{
+		Processed:  ws.processed,
+		Errors:     ws.errors,
+		BusyTime:   busy,
+		IdleTime:   now.Sub(ws.added) - busy,
+		LastActive: ws.lastActive,
+		Trips:      ws.trips,
+	}
+}
+
+// counted records the outcome of a task handled by ws. The caller must hold
+// p.mutex.
+func (ws *workerState[Id, Task, Result]) counted(err error) {
+	ws.processed++
+	if err != nil {
+		ws.errors++
+	}
+}
+
+// The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) counted(err error) {
+	if err != nil {
+		p.failed++
+	} else {
+		p.completed++
+	}
+}
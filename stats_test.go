@@ -0,0 +1,79 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWorkerStats(t *testing.T) {
+	// Setup
+	failure := errors.New("failure")
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		if x < 0 {
+			return 0, failure
+		}
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	for _, x := range []int{1, 2, -3} {
+		f, err := pool.Submit(x)
+		if err != nil {
+			t.Fatalf("expected submit to succeed, got %v", err)
+		}
+		f.Wait(context.Background())
+	}
+	stats, ok := pool.WorkerStats(0)
+	// Assert
+	if !ok {
+		t.Fatal("expected stats of worker 0")
+	}
+	if stats.Processed != 3 || stats.Errors != 1 {
+		t.Errorf("expected 3 processed tasks and 1 error, got %d and %d", stats.Processed, stats.Errors)
+	}
+	if stats.BusyTime < 15*time.Millisecond {
+		t.Errorf("expected at least %v busy, got %v", 15*time.Millisecond, stats.BusyTime)
+	}
+	if stats.IdleTime < 0 || stats.LastActive.IsZero() {
+		t.Errorf("expected idle time and last active to be set, got %v and %v", stats.IdleTime, stats.LastActive)
+	}
+	if _, ok := pool.WorkerStats(1); ok {
+		t.Error("expected no stats for a missing worker")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestAllWorkerStats(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	for i := 0; i < 3; i++ {
+		pool.AddWorker(i)
+	}
+	// Action
+	for i := 0; i < 10; i++ {
+		f, _ := pool.Submit(i)
+		f.Wait(context.Background())
+	}
+	stats := pool.AllWorkerStats()
+	// Assert
+	if len(stats) != 3 {
+		t.Fatalf("expected stats of 3 workers, got %d", len(stats))
+	}
+	processed := 0
+	for _, s := range stats {
+		processed += s.Processed
+	}
+	if processed != 10 {
+		t.Errorf("expected 10 processed tasks, got %d", processed)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,89 @@
+package gorkpool
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type status[Id comparable] struct {
+	State        string             `json:"state"`
+	Queued       int                `json:"queued"`
+	InFlight     int                `json:"in_flight"`
+	LastProgress time.Time          `json:"last_progress"`
+	Workers      []workerStatus[Id] `json:"workers"`
+	QueueWait    latencyStatus      `json:"queue_wait"`
+	Processing   latencyStatus      `json:"processing"`
+}
+
+type workerStatus[Id comparable] struct {
+	ID         Id        `json:"id"`
+	Busy       int       `json:"busy"`
+	Queued     int       `json:"queued"`
+	Exited     bool      `json:"exited"`
+	Processed  int       `json:"processed"`
+	Errors     int       `json:"errors"`
+	BusyTime   string    `json:"busy_time"`
+	IdleTime   string    `json:"idle_time"`
+	LastActive time.Time `json:"last_active"`
+}
+
+type latencyStatus struct {
+	Count uint64 `json:"count"`
+	Mean  string `json:"mean"`
+	P50   string `json:"p50"`
+	P95   string `json:"p95"`
+	P99   string `json:"p99"`
+}
+
+func newLatencyStatus(h Histogram) latencyStatus {
+	return latencyStatus{
+		Count: h.Count,
+		Mean:  h.Mean().String(),
+		P50:   h.Quantile(0.5).String(),
+		P95:   h.Quantile(0.95).String(),
+		P99:   h.Quantile(0.99).String(),
+	}
+}
+
+// StatusHandler serves the state of the pool, its workers, queue depth and
+// stats as JSON. It answers 503 Service Unavailable unless the pool is
+// running, so it doubles as a health check.
+func (p *GorkPool[Id, Task, Result]) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dump := p.DumpState()
+		workerStats := p.AllWorkerStats()
+		stats := p.Stats()
+
+		s := status[Id]{
+			State:        dump.State.String(),
+			Queued:       dump.Queued,
+			InFlight:     dump.InFlight,
+			LastProgress: dump.LastProgress,
+			Workers:      make([]workerStatus[Id], 0, len(dump.Workers)),
+			QueueWait:    newLatencyStatus(stats.QueueWait),
+			Processing:   newLatencyStatus(stats.Processing),
+		}
+		for _, wd := range dump.Workers {
+			// It may have been removed since the dump
+			ws := workerStats[wd.ID]
+			s.Workers = append(s.Workers, workerStatus[Id]{
+				ID:         wd.ID,
+				Busy:       wd.Busy,
+				Queued:     wd.Queued,
+				Exited:     wd.Exited,
+				Processed:  ws.Processed,
+				Errors:     ws.Errors,
+				BusyTime:   ws.BusyTime.String(),
+				IdleTime:   ws.IdleTime.String(),
+				LastActive: wd.LastActive,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if dump.State != StateRunning {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(s)
+	})
+}
@@ -0,0 +1,64 @@
+package gorkpool_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testStatus struct {
+	State   string `json:"state"`
+	Workers []struct {
+		ID        int `json:"id"`
+		Processed int `json:"processed"`
+	} `json:"workers"`
+	Processing struct {
+		Count uint64 `json:"count"`
+	} `json:"processing"`
+}
+
+func TestStatusHandler(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	pool.AddWorker(0)
+	f, _ := pool.Submit(1)
+	f.Wait(context.Background())
+	// Action
+	rec := httptest.NewRecorder()
+	pool.StatusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	// Assert
+	var s testStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &s); err != nil {
+		t.Fatalf("expected JSON status, got %v", err)
+	}
+	if rec.Code != http.StatusOK || s.State != "running" {
+		t.Errorf("expected running pool to answer %d, got %d and state %q", http.StatusOK, rec.Code, s.State)
+	}
+	if len(s.Workers) != 1 || s.Workers[0].ID != 0 || s.Workers[0].Processed != 1 {
+		t.Errorf("expected worker 0 with 1 processed task, got %+v", s.Workers)
+	}
+	if s.Processing.Count != 1 {
+		t.Errorf("expected 1 processing sample, got %d", s.Processing.Count)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestStatusHandlerStopped(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	cancel()
+	pool.Wait()
+	// Action
+	rec := httptest.NewRecorder()
+	pool.StatusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	// Assert
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected stopped pool to answer %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
@@ -0,0 +1,173 @@
+package gorkpool
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TaskStore keeps the tasks a pool couldn't deliver when it stopped. Save
+// replaces whatever it held with tasks, which may be empty.
+type TaskStore[Task any] interface {
+	Save(tasks []Task) error
+	Load() ([]Task, error)
+}
+
+// FileStore is a TaskStore keeping the tasks as JSON in a file.
+type FileStore[Task any] struct {
+	mutex *sync.Mutex
+	path  string
+}
+
+func NewFileStore[Task any](path string) *FileStore[Task] {
+	return &FileStore[Task]{
+		mutex: &sync.Mutex{},
+		path:  path,
+	}
+}
+
+// Save writes tasks to a temporary file first, renaming it over the file, so
+// the file always holds a whole set of tasks.
+func (s *FileStore[Task]) Save(tasks []Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if tasks == nil {
+		tasks = []Task{}
+	}
+	b, err := json.Marshal(tasks)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// Load reads the tasks in the file, none if there is no file yet.
+func (s *FileStore[Task]) Load() ([]Task, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	if err := json.Unmarshal(b, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// WithTaskStore makes the pool replay the tasks in store whenever it starts,
+// alongside new submissions, and save to it the tasks it couldn't deliver
+// once it stops, like SetTaskStore. Tasks are only taken out of the store
+// when the pool saves what is left, so those of a pool that crashed are
+// replayed again: processing is at least once. store must be a TaskStore of
+// the task type of the pool, it is ignored otherwise.
+func WithTaskStore[Task any](store TaskStore[Task]) Option {
+	return func(c *config) {
+		c.taskStore = store
+	}
+}
+
+// SetTaskStore makes the pool save to store the tasks it couldn't deliver
+// once it stops, rather than dropping them. Tasks returned by Kill and Drain
+// are left to the caller.
+func (p *GorkPool[Id, Task, Result]) SetTaskStore(store TaskStore[Task]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.store = store
+}
+
+// resume replays the tasks of the store set with WithTaskStore, like a source.
+// It is called by start, which may hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) resume() {
+	store, ok := p.cfg.taskStore.(TaskStore[Task])
+	if !ok {
+		p.logf("gorkpool: ignoring task store of type %T", p.cfg.taskStore)
+		return
+	}
+	p.store = store
+
+	p.sources.Add(1)
+	p.launch("replay", func() {
+		p.replay(store)
+	})
+}
+
+func (p *GorkPool[Id, Task, Result]) replay(store TaskStore[Task]) {
+	defer p.sources.Done()
+	tasks, err := store.Load()
+	if err != nil {
+		// Saving over tasks it couldn't load would lose them
+		p.mutex.Lock()
+		if p.store == store {
+			p.store = nil
+		}
+		p.mutex.Unlock()
+		p.reportErr(NewErrTaskStore(err))
+		return
+	}
+	for i, task := range tasks {
+		env := p.newEnvelope(task, nil)
+		if ok, err := p.offer(env, p.sourcesStop, nil); err != nil {
+			p.drop(env, err)
+		} else if !ok {
+			// Keep the tasks not replayed yet in the store
+			p.mutex.Lock()
+			p.undelivered = append(p.undelivered, tasks[i:]...)
+			p.mutex.Unlock()
+			return
+		}
+	}
+}
+
+// keep sets env aside to be saved if the pool has a task store.
+func (p *GorkPool[Id, Task, Result]) keep(env *envelope[Task, Result]) {
+	if p.isAborted() || p.isCanceled(env) {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.store != nil {
+		p.undelivered = append(p.undelivered, env.task)
+	}
+}
+
+// persist saves the tasks set aside by keep.
+func (p *GorkPool[Id, Task, Result]) persist() {
+	p.mutex.Lock()
+	store, tasks := p.store, p.undelivered
+	p.undelivered = nil
+	p.mutex.Unlock()
+	if store == nil {
+		return
+	}
+	if err := store.Save(tasks); err != nil {
+		p.reportErr(NewErrTaskStore(err))
+		return
+	}
+	p.mutex.Lock()
+	p.report.Persisted = len(tasks)
+	p.mutex.Unlock()
+}
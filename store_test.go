@@ -0,0 +1,78 @@
+package gorkpool_test
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestFileStore(t *testing.T) {
+	// Setup
+	store := gorkpool.NewFileStore[int](filepath.Join(t.TempDir(), "tasks.json"))
+	// Action
+	empty, emptyErr := store.Load()
+	saveErr := store.Save([]int{1, 2, 3})
+	tasks, err := store.Load()
+	// Assert
+	if emptyErr != nil || len(empty) != 0 {
+		t.Errorf("expected no tasks before saving, got %v (%v)", empty, emptyErr)
+	}
+	if saveErr != nil || err != nil || len(tasks) != 3 || tasks[2] != 3 {
+		t.Errorf("expected saved tasks to load back, got %v (%v, %v)", tasks, saveErr, err)
+	}
+}
+
+func TestSetTaskStore(t *testing.T) {
+	// Setup
+	store := gorkpool.NewFileStore[int](filepath.Join(t.TempDir(), "tasks.json"))
+	ctx := context.Background()
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return -x, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithOutputBufferSize(10))
+	pool.SetTaskStore(store)
+	pool.AddWorker(0)
+	// No worker can take them
+	pool.AddTask(1, gorkpool.WithTags("gpu"))
+	pool.AddTask(2, gorkpool.WithTags("gpu"))
+	pool.AddTask(3)
+	waitFor(t, func() bool { return pool.QueueLen() == 2 })
+	// Action
+	err := pool.Shutdown(ctx)
+	tasks, loadErr := store.Load()
+	// Assert
+	if err != nil || loadErr != nil {
+		t.Fatalf("expected pool to shut down and tasks to load, got %v and %v", err, loadErr)
+	}
+	sort.Ints(tasks)
+	if len(tasks) != 2 || tasks[0] != 1 || tasks[1] != 2 {
+		t.Errorf("expected the undelivered tasks to be saved, got %v", tasks)
+	}
+}
+
+func TestWithTaskStore(t *testing.T) {
+	// Setup
+	store := gorkpool.NewFileStore[int](filepath.Join(t.TempDir(), "tasks.json"))
+	store.Save([]int{1, 2, 3})
+	ctx := context.Background()
+	// Action
+	pool := gorkpool.NewFuncPool(ctx, 1, func(x int) int { return -x }, gorkpool.WithTaskStore[int](store), gorkpool.WithOutputBufferSize(10))
+	results := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		results = append(results, <-pool.OutputCh())
+	}
+	err := pool.Shutdown(ctx)
+	left, loadErr := store.Load()
+	// Assert
+	sort.Ints(results)
+	if len(results) != 3 || results[0] != -3 || results[2] != -1 {
+		t.Errorf("expected the stored tasks to be replayed, got %v", results)
+	}
+	if err != nil || loadErr != nil || len(left) != 0 {
+		t.Errorf("expected the store to be emptied once replayed, got %v (%v, %v)", left, err, loadErr)
+	}
+}
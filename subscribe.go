@@ -0,0 +1,116 @@
+package gorkpool
+
+import "sync"
+
+type subscriber[Result any] struct {
+	ch   chan Result
+	quit chan struct{}
+	once *sync.Once
+}
+
+func (s *subscriber[Result]) unsubscribe() {
+	s.once.Do(func() {
+		close(s.quit)
+	})
+}
+
+// broadcast copies the results of a run of the pool to every subscriber.
+type broadcast[Result any] struct {
+	mutex       *sync.Mutex
+	subscribers map[*subscriber[Result]]struct{}
+	closed      bool
+}
+
+// SubscribeOutput gives the caller its own copy of every result from now on,
+// on a channel with bufferSize buffer which is closed when the pool stops.
+// A slow subscriber holds up the others. Once there are subscribers the pool
+// reads OutputCh itself, so it must no longer be read directly. The returned
+// function unsubscribes, after which the channel gets no more results.
+func (p *GorkPool[Id, Task, Result]) SubscribeOutput(bufferSize int) (<-chan Result, func()) {
+	s := &subscriber[Result]{
+		ch:   make(chan Result, bufferSize),
+		quit: make(chan struct{}),
+		once: &sync.Once{},
+	}
+
+	p.mutex.Lock()
+	if p.broadcast == nil {
+		p.broadcast = &broadcast[Result]{
+			mutex:       &sync.Mutex{},
+			subscribers: make(map[*subscriber[Result]]struct{}),
+		}
+		broadcast, outputCh, done := p.broadcast, p.outputCh, p.done
+		p.launch("subscriptions", func() {
+			p.broadcastOutput(broadcast, outputCh, done)
+		})
+	}
+	b := p.broadcast
+	p.mutex.Unlock()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		close(s.ch)
+		return s.ch, func() {}
+	}
+	b.subscribers[s] = struct{}{}
+
+	return s.ch, func() {
+		s.unsubscribe()
+		b.mutex.Lock()
+		delete(b.subscribers, s)
+		b.mutex.Unlock()
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) broadcastOutput(b *broadcast[Result], outputCh chan Result, done <-chan struct{}) {
+	defer b.close()
+	for {
+		select {
+		case result, ok := <-outputCh:
+			if !ok {
+				return
+			}
+			b.send(result)
+		case <-done:
+			// The output channel may be external and never closed
+			for {
+				select {
+				case result, ok := <-outputCh:
+					if !ok {
+						return
+					}
+					b.send(result)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *broadcast[Result]) send(result Result) {
+	b.mutex.Lock()
+	subscribers := make([]*subscriber[Result], 0, len(b.subscribers))
+	for s := range b.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	b.mutex.Unlock()
+
+	for _, s := range subscribers {
+		select {
+		case s.ch <- result:
+		case <-s.quit:
+		}
+	}
+}
+
+func (b *broadcast[Result]) close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.closed = true
+	for s := range b.subscribers {
+		close(s.ch)
+	}
+	b.subscribers = nil
+}
@@ -0,0 +1,36 @@
+package gorkpool_test
+
+import "testing"
+
+func TestSubscribeOutput(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	pool.AddWorker(0)
+	first, _ := pool.SubscribeOutput(3)
+	second, unsubscribe := pool.SubscribeOutput(3)
+	// Action
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	for _, ch := range []<-chan int{first, second} {
+		sum := 0
+		for i := 0; i < 3; i++ {
+			sum += <-ch
+		}
+		if sum != -6 {
+			t.Errorf("expected every subscriber to get all results, got sum %d", sum)
+		}
+	}
+	unsubscribe()
+	pool.AddTask(4)
+	if got := <-first; got != -4 {
+		t.Errorf("expected result %d, got %d", -4, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+	if _, ok := <-first; ok {
+		t.Error("expected subscription to be closed once the pool stops")
+	}
+}
@@ -0,0 +1,68 @@
+package gorkpool
+
+// Tagged is an optional interface for workers with capabilities. Tasks
+// submitted WithTags only go to workers having all of their tags.
+type Tagged interface {
+	Tags() []string
+}
+
+// WithTags makes the task go only to workers having all of tags. It needs per
+// worker queues, tasks failing to submit with ErrNoWorkerQueues otherwise.
+// Tasks no worker can take wait aside until a worker that can joins the pool.
+func WithTags(tags ...string) TaskOption {
+	return func(c *taskConfig) {
+		c.tags = append(c.tags, tags...)
+	}
+}
+
+func (ws *workerState[Id, Task, Result]) hasTags(tags []string) bool {
+	for _, tag := range tags {
+		if _, ok := ws.tags[tag]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validate tells whether env can be submitted to the pool at all.
+func (p *GorkPool[Id, Task, Result]) validate(env *envelope[Task, Result]) error {
+	if len(env.tags) > 0 && !p.perWorkerQueues() {
+		return NewErrNoWorkerQueues()
+	}
+	return nil
+}
+
+// unpark queues again the parked tasks ws can take. Parked tasks don't hold a
+// slot in the queue, so they wait for one like new tasks. The caller must hold
+// p.mutex for writing, or for reading along with p.parkMutex.
+func (p *GorkPool[Id, Task, Result]) unpark(ws *workerState[Id, Task, Result]) {
+	parked := p.parked[:0]
+	for _, env := range p.parked {
+		if !ws.takes(env) {
+			parked = append(parked, env)
+			continue
+		}
+		env := env
+		p.launch("requeue", func() {
+			if !p.queue.push(env, p.ctx.Done(), nil) {
+				p.abandon(env)
+			}
+		})
+	}
+	p.parked = parked
+}
+
+// unparkAll takes every parked task out, along with those held back for their
+// key, leaving them to the caller.
+func (p *GorkPool[Id, Task, Result]) unparkAll() []*envelope[Task, Result] {
+	p.mutex.Lock()
+	parked := p.parked
+	p.parked = nil
+	waiting := p.unholdKeys()
+	p.mutex.Unlock()
+
+	for range waiting {
+		p.queue.release()
+	}
+	return append(parked, waiting...)
+}
@@ -0,0 +1,81 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type testTagged struct {
+	testHandler
+	tags []string
+}
+
+func (w *testTagged) Tags() []string {
+	return w.tags
+}
+
+func setupTaggedPool() (*gorkpool.GorkPool[int, int, int], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tags := map[int][]string{0: {"cpu"}, 1: {"cpu", "gpu"}, 2: {"tpu"}}
+	return gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testTagged{testHandler: testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			return id, nil
+		}}, tags: tags[id]}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy)), cancel
+}
+
+func TestWithTags(t *testing.T) {
+	// Setup
+	pool, cancel := setupTaggedPool()
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	for i := 0; i < 5; i++ {
+		// Action
+		got, err := pool.SubmitWait(context.Background(), i, gorkpool.WithTags("gpu"))
+		// Assert
+		if err != nil || got != 1 {
+			t.Errorf("expected gpu task to go to worker 1, got %d, %v", got, err)
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithTagsParked(t *testing.T) {
+	// Setup
+	pool, cancel := setupTaggedPool()
+	pool.AddWorker(0)
+	parked, _ := pool.Submit(0, gorkpool.WithTags("tpu"))
+	// Action
+	got, err := pool.SubmitWait(context.Background(), 1)
+	// Assert
+	if err != nil || got != 0 {
+		t.Errorf("expected untagged task not to wait behind the parked one, got %d, %v", got, err)
+	}
+	pool.AddWorker(2)
+	if got, err := parked.Wait(context.Background()); err != nil || got != 2 {
+		t.Errorf("expected parked task to go to worker 2, got %d, %v", got, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithTagsSharedDispatch(t *testing.T) {
+	// Setup
+	pool, cancel := setupPool()
+	// Action
+	err := pool.AddTask(1, gorkpool.WithTags("gpu"))
+	// Assert
+	var noQueues gorkpool.ErrNoWorkerQueues
+	if !errors.As(err, &noQueues) {
+		t.Errorf("expected ErrNoWorkerQueues, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
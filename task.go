@@ -0,0 +1,72 @@
+package gorkpool
+
+import (
+	"context"
+	"time"
+)
+
+type taskConfig struct {
+	id       string
+	priority int
+	deadline time.Time
+	timeout  time.Duration
+	ttl      time.Duration
+	tags     []string
+
+	concurrencyKey string
+	tenant         string
+	weight         int
+	dedupKey       string
+	metadata       map[string]string
+	trace          context.Context
+	parent         context.Context
+	cacheKey       string
+}
+
+type TaskOption func(*taskConfig)
+
+// WithPriority makes the task jump ahead of queued tasks with a lower
+// priority. Tasks default to priority 0.
+func WithPriority(priority int) TaskOption {
+	return func(c *taskConfig) {
+		c.priority = priority
+	}
+}
+
+// WithTaskID identifies the task so it can be cancelled with CancelTask.
+func WithTaskID(id string) TaskOption {
+	return func(c *taskConfig) {
+		c.id = id
+	}
+}
+
+// WithDeadline makes the pool skip the task if it is still queued by t, and
+// cancels the context given to TaskHandler workers once t is reached.
+func WithDeadline(t time.Time) TaskOption {
+	return func(c *taskConfig) {
+		c.deadline = t
+	}
+}
+
+// WithTimeout is like WithDeadline, relative to when the task is submitted.
+func WithTimeout(d time.Duration) TaskOption {
+	return func(c *taskConfig) {
+		c.timeout = d
+	}
+}
+
+func newTaskConfig(opts []TaskOption) taskConfig {
+	if len(opts) == 0 {
+		return taskConfig{}
+	}
+	var c taskConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.timeout > 0 {
+		if deadline := time.Now().Add(c.timeout); c.deadline.IsZero() || deadline.Before(c.deadline) {
+			c.deadline = deadline
+		}
+	}
+	return c
+}
@@ -0,0 +1,66 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestTaskDeadlineSkipped(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		return -x, nil
+	})
+	deadLetters := make(chan gorkpool.DeadLetter[int], 1)
+	pool.SetDeadLetterHandler(func(dl gorkpool.DeadLetter[int]) {
+		deadLetters <- dl
+	})
+	// Action
+	pool.AddTask(1, gorkpool.WithDeadline(time.Now().Add(-time.Second)))
+	pool.AddWorker(0)
+	pool.AddTask(2)
+	// Assert
+	dl := <-deadLetters
+	if dl.Task != 1 || !errors.Is(dl.Err, context.DeadlineExceeded) {
+		t.Errorf("expected task 1 to be dead lettered with %v, got task %d with %v", context.DeadlineExceeded, dl.Task, dl.Err)
+	}
+	if dl.Attempts != 0 {
+		t.Errorf("expected expired task not to be attempted, got %d attempts", dl.Attempts)
+	}
+	if got := <-pool.OutputCh(); got != -2 {
+		t.Errorf("expected result to be %d, got %d", -2, got)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestTaskTimeout(t *testing.T) {
+	// Setup
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	deadLetters := make(chan gorkpool.DeadLetter[int], 1)
+	pool.SetDeadLetterHandler(func(dl gorkpool.DeadLetter[int]) {
+		deadLetters <- dl
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1, gorkpool.WithTimeout(10*time.Millisecond))
+	// Assert
+	select {
+	case dl := <-deadLetters:
+		if !errors.Is(dl.Err, context.DeadlineExceeded) {
+			t.Errorf("expected task to fail with %v, got %v", context.DeadlineExceeded, dl.Err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected task context to be cancelled by its timeout")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,55 @@
+package gorkpool
+
+import (
+	"context"
+	"sync"
+)
+
+// WithTaskContext ties the task to ctx, usually the one of the request
+// submitting it. Its values, like request ids or auth info, can be looked up
+// in the context of TaskHandler workers handling the task, as with
+// WithTraceContext. Once ctx is done the task is cancelled: a queued task is
+// dropped when it comes up and a running one gets its context cancelled, its
+// Future failing with ctx's error either way, and it isn't retried.
+func WithTaskContext(ctx context.Context) TaskOption {
+	return func(c *taskConfig) {
+		c.trace, c.parent = ctx, ctx
+	}
+}
+
+// orphaned cancels env if the context it was submitted with is done, telling
+// whether it did.
+func (p *GorkPool[Id, Task, Result]) orphaned(env *envelope[Task, Result]) bool {
+	if env.parent == nil || env.parent.Err() == nil {
+		return false
+	}
+	if env.future != nil {
+		var zero Result
+		env.future.resolve(zero, env.parent.Err())
+	}
+	p.cancelEnvelope(env)
+	return true
+}
+
+// withParent makes ctx, the context env is handled in, be cancelled along with
+// the context env was submitted with.
+func (p *GorkPool[Id, Task, Result]) withParent(ctx context.Context, cancel context.CancelFunc, parent context.Context) (context.Context, context.CancelFunc) {
+	if cancel == nil {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	var once sync.Once
+	stop := make(chan struct{})
+	p.launch("task context", func() {
+		select {
+		case <-parent.Done():
+			cancel()
+		case <-stop:
+		}
+	})
+	return ctx, func() {
+		once.Do(func() {
+			close(stop)
+		})
+		cancel()
+	}
+}
@@ -0,0 +1,97 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type requestKey struct{}
+
+func TestWithTaskContextValues(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (string, error) {
+		id, _ := ctx.Value(requestKey{}).(string)
+		return id, nil
+	})
+	reqCtx := context.WithValue(context.Background(), requestKey{}, "req-42")
+	// Action
+	got, err := pool.SubmitWait(context.Background(), 1, gorkpool.WithTaskContext(reqCtx))
+	// Assert
+	if err != nil || got != "req-42" {
+		t.Errorf("expected the request id %q in the handler, got %q and %v", "req-42", got, err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithTaskContextCancelsRunningTask(t *testing.T) {
+	// Setup
+	var calls atomic.Int64
+	started := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		calls.Add(1)
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	pool.SetRetryPolicy(gorkpool.RetryPolicy{MaxAttempts: 3})
+	reqCtx, reqCancel := context.WithCancel(context.Background())
+	future, _ := pool.Submit(1, gorkpool.WithTaskContext(reqCtx))
+	// Action
+	<-started
+	reqCancel()
+	_, err := future.Wait(context.Background())
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the task to be cancelled, got %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected the cancelled task not to be retried, got %d calls", n)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithTaskContextDropsQueuedTask(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var handled []int
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, x int) (int, error) {
+		handled = append(handled, x)
+		started <- struct{}{}
+		<-release
+		return x, nil
+	}, gorkpool.WithInputBufferSize(2))
+	first, _ := pool.Submit(1)
+	<-started
+	reqCtx, reqCancel := context.WithCancel(context.Background())
+	second, _ := pool.Submit(2, gorkpool.WithTaskContext(reqCtx))
+	// Action
+	reqCancel()
+	close(release)
+	_, firstErr := first.Wait(context.Background())
+	_, secondErr := second.Wait(context.Background())
+	// Assert
+	if firstErr != nil {
+		t.Errorf("expected the first task to succeed, got %v", firstErr)
+	}
+	if !errors.Is(secondErr, context.Canceled) {
+		t.Errorf("expected the queued task to be cancelled, got %v", secondErr)
+	}
+	if len(handled) != 1 {
+		t.Errorf("expected only the first task to be handled, got %v", handled)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
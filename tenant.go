@@ -0,0 +1,129 @@
+package gorkpool
+
+// WithTenant submits the task on behalf of tenant. Queued tasks of the same
+// priority are taken fairly across tenants, so one submitting a lot doesn't
+// starve the others. Tasks without a tenant share the "" tenant.
+func WithTenant(tenant string) TaskOption {
+	return func(c *taskConfig) {
+		c.tenant = tenant
+	}
+}
+
+// WithTenantWeight makes tenant get weight times the share of the queue a
+// tenant gets by default, when both have tasks waiting.
+func WithTenantWeight(tenant string, weight int) Option {
+	return func(c *config) {
+		if c.tenantWeights == nil {
+			c.tenantWeights = make(map[string]int)
+		}
+		c.tenantWeights[tenant] = weight
+	}
+}
+
+// WithTenantQuota caps at n the tasks of tenant waiting in the queue. Tasks
+// over the quota fail to submit with ErrQuotaExceeded, whatever the
+// backpressure policy, and those read from a source are dropped with it.
+func WithTenantQuota(tenant string, n int) Option {
+	return func(c *config) {
+		if c.tenantQuotas == nil {
+			c.tenantQuotas = make(map[string]int)
+		}
+		c.tenantQuotas[tenant] = n
+	}
+}
+
+type tenantState struct {
+	// finish is the virtual time at which the last task queued by the tenant
+	// would be done
+	finish   float64
+	queued   int
+	reserved int
+}
+
+// tenant returns the state of name, creating it if needed. The caller must
+// hold q.mutex.
+func (q *taskQueue[Task, Result]) tenant(name string) *tenantState {
+	t, ok := q.tenants[name]
+	if !ok {
+		t = &tenantState{}
+		q.tenants[name] = t
+	}
+	return t
+}
+
+// schedule stamps env with its virtual finish time, each task of a tenant
+// taking 1/weight of virtual time after the previous one. The queue serves
+// the earliest finish first, which shares it among tenants by weight. The
+// caller must hold q.mutex.
+func (q *taskQueue[Task, Result]) schedule(env *envelope[Task, Result]) {
+	t := q.tenant(env.tenant)
+	weight := q.weights[env.tenant]
+	if weight < 1 {
+		weight = 1
+	}
+
+	start := t.finish
+	if q.vtime > start {
+		// A tenant that was idle doesn't get to catch up
+		start = q.vtime
+	}
+	env.vtime = start + 1/float64(weight)
+	t.finish = env.vtime
+}
+
+// enqueued accounts for env joining the queue, taking over its place in the
+// quota if it had one. The caller must hold q.mutex.
+func (q *taskQueue[Task, Result]) enqueued(env *envelope[Task, Result]) {
+	t := q.tenant(env.tenant)
+	t.queued++
+	if env.reserved {
+		env.reserved = false
+		t.reserved--
+	}
+}
+
+// dequeued accounts for env leaving the queue, or giving back its place in
+// the quota when not queued. The caller must hold q.mutex.
+func (q *taskQueue[Task, Result]) dequeued(env *envelope[Task, Result], queued bool) {
+	t := q.tenant(env.tenant)
+	if queued {
+		t.queued--
+	} else {
+		t.reserved--
+	}
+	// The state of tasks without a tenant is kept, not to be allocated
+	// again for the next one whenever the queue runs empty
+	if env.tenant != "" && t.queued == 0 && t.reserved == 0 && t.finish <= q.vtime {
+		delete(q.tenants, env.tenant)
+	}
+}
+
+// reserve takes a place in the quota of its tenant for env, returning false
+// if there is none left. Queueing env takes the place over, otherwise it is
+// given back with unreserve.
+func (q *taskQueue[Task, Result]) reserve(env *envelope[Task, Result]) bool {
+	quota, ok := q.quotas[env.tenant]
+	if !ok {
+		return true
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	t := q.tenant(env.tenant)
+	if t.queued+t.reserved >= quota {
+		return false
+	}
+	t.reserved++
+	env.reserved = true
+	return true
+}
+
+func (q *taskQueue[Task, Result]) unreserve(env *envelope[Task, Result]) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if !env.reserved {
+		return
+	}
+	env.reserved = false
+	q.dequeued(env, false)
+}
@@ -0,0 +1,86 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func setupTenantPool(opts ...gorkpool.Option) (*gorkpool.GorkPool[int, string, string], chan struct{}, func() []string, context.CancelFunc) {
+	var (
+		mutex   sync.Mutex
+		handled []string
+	)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	opts = append(opts, gorkpool.WithQueueSize(10), gorkpool.WithOutputBufferSize(10))
+	pool := gorkpool.NewHandlerFuncPool(ctx, 1, func(ctx context.Context, task string) (string, error) {
+		if task == "block" {
+			started <- struct{}{}
+			<-release
+		}
+		mutex.Lock()
+		handled = append(handled, task)
+		mutex.Unlock()
+		return task, nil
+	}, opts...)
+	pool.AddTask("block")
+	<-started
+	return pool, release, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return handled
+	}, cancel
+}
+
+func TestWithTenant(t *testing.T) {
+	// Setup
+	pool, release, handled, cancel := setupTenantPool()
+	// Action
+	for _, task := range []string{"a1", "a2", "a3", "a4"} {
+		pool.AddTask(task, gorkpool.WithTenant("a"))
+	}
+	for _, task := range []string{"b1", "b2"} {
+		pool.AddTask(task, gorkpool.WithTenant("b"))
+	}
+	close(release)
+	for i := 0; i < 7; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	pos := make(map[string]int)
+	for i, task := range handled() {
+		pos[task] = i
+	}
+	if pos["b1"] > pos["a3"] || pos["b2"] > pos["a4"] {
+		t.Errorf("expected tenants to take turns, got %v", handled())
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestWithTenantQuota(t *testing.T) {
+	// Setup
+	pool, release, _, cancel := setupTenantPool(gorkpool.WithTenantQuota("a", 1))
+	// Action
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = pool.AddTask("a", gorkpool.WithTenant("a"))
+	}
+	// Assert
+	var quotaErr gorkpool.ErrQuotaExceeded
+	if !errors.As(err, &quotaErr) || quotaErr.Tenant() != "a" {
+		t.Errorf("expected tenant to be over its quota, got %v", err)
+	}
+	if err := pool.AddTask("b", gorkpool.WithTenant("b")); err != nil {
+		t.Errorf("expected other tenants to submit, got %v", err)
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
@@ -0,0 +1,33 @@
+package gorkpool
+
+import (
+	"context"
+	"fmt"
+	"runtime/trace"
+)
+
+// WithTraceRegions wraps the handling of every task by a TaskHandler worker,
+// and of every batch by a BatchHandler worker, in a runtime/trace region
+// logging the worker id and task type, so that go tool trace shows the tasks
+// each worker goroutine went through. While no trace is being recorded it
+// only costs a check per task, without allocating.
+func WithTraceRegions() Option {
+	return func(c *config) {
+		c.traceRegions = true
+	}
+}
+
+// tracing tells whether tasks get a trace region.
+func (p *GorkPool[Id, Task, Result]) tracing() bool {
+	return p.cfg.traceRegions && trace.IsEnabled()
+}
+
+// traceRegion starts the region of handling task, of the given kind, on the
+// current goroutine. Callers check tracing first, so that id and task aren't
+// boxed for nothing.
+func (p *GorkPool[Id, Task, Result]) traceRegion(ctx context.Context, kind string, id Id, task any) *trace.Region {
+	region := trace.StartRegion(ctx, "gorkpool."+kind)
+	trace.Log(ctx, "worker", fmt.Sprint(id))
+	trace.Log(ctx, kind, fmt.Sprintf("%T", task))
+	return region
+}
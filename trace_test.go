@@ -0,0 +1,47 @@
+package gorkpool_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/trace"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithTraceRegions(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewHandlerFuncPool(context.Background(), 1, double, gorkpool.WithTraceRegions())
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("tracing is already on: %v", err)
+	}
+	// Action
+	_, err := pool.SubmitWait(context.Background(), 1)
+	trace.Stop()
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("gorkpool.task")) {
+		t.Error("expected the trace to have a gorkpool.task region")
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestWithTraceRegionsAllocs(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewFuncPool(context.Background(), 1, func(x int) int { return x }, gorkpool.WithTraceRegions())
+	// Action
+	allocs := testing.AllocsPerRun(100, func() {
+		pool.AddTask(1)
+		<-pool.OutputCh()
+	})
+	// Assert
+	if allocs != 0 {
+		t.Errorf("expected tasks not to allocate without a trace being recorded, got %v allocations per task", allocs)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
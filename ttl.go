@@ -0,0 +1,16 @@
+package gorkpool
+
+import "time"
+
+// WithTTL makes the pool drop the task, failing it with ErrTaskStale, if it
+// is still waiting to be handled d after it was submitted. Unlike
+// WithTimeout, it doesn't limit how long the task takes once handled.
+func WithTTL(d time.Duration) TaskOption {
+	return func(c *taskConfig) {
+		c.ttl = d
+	}
+}
+
+func (env *envelope[Task, Result]) stale(now time.Time) bool {
+	return !env.expires.IsZero() && !now.Before(env.expires)
+}
@@ -0,0 +1,44 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithTTL(t *testing.T) {
+	// Setup
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		if x == 0 {
+			started <- struct{}{}
+			<-release
+		}
+		return x, nil
+	})
+	dead := make(chan gorkpool.DeadLetter[int], 1)
+	pool.SetDeadLetterHandler(func(dl gorkpool.DeadLetter[int]) {
+		dead <- dl
+	})
+	pool.AddWorker(0)
+	pool.AddTask(0)
+	<-started
+	// Action
+	pool.AddTask(1, gorkpool.WithTTL(10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	// Assert
+	if got := <-pool.OutputCh(); got != 0 {
+		t.Errorf("expected result to be %d, got %d", 0, got)
+	}
+	dl := <-dead
+	if dl.Task != 1 || !errors.As(dl.Err, new(gorkpool.ErrTaskStale)) {
+		t.Errorf("expected task %d to be dropped as stale, got task %d with %v", 1, dl.Task, dl.Err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
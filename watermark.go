@@ -0,0 +1,63 @@
+package gorkpool
+
+import (
+	"math"
+	"sync"
+)
+
+type watermark struct {
+	fraction float64
+	rising   bool
+	fn       func()
+	crossed  bool
+}
+
+type watermarks struct {
+	mutex *sync.Mutex
+	marks []*watermark
+}
+
+// OnQueueAbove calls fn whenever the queue goes from below fraction of its
+// capacity to at least that, like 0.8 for 80%. fn is called from whichever
+// goroutine filled the queue, so it must not block.
+func (p *GorkPool[Id, Task, Result]) OnQueueAbove(fraction float64, fn func()) {
+	p.watermarks.add(&watermark{fraction: fraction, rising: true, fn: fn})
+}
+
+// OnQueueBelow calls fn whenever the queue goes from above fraction of its
+// capacity to at most that. Like OnQueueAbove, fn must not block.
+func (p *GorkPool[Id, Task, Result]) OnQueueBelow(fraction float64, fn func()) {
+	// The queue starts empty, below any watermark
+	p.watermarks.add(&watermark{fraction: fraction, fn: fn, crossed: true})
+}
+
+func (w *watermarks) add(mark *watermark) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.marks = append(w.marks, mark)
+}
+
+// update fires the watermarks crossed now that used out of size slots of the
+// queue are taken.
+func (w *watermarks) update(used int, size int) {
+	var fire []func()
+	w.mutex.Lock()
+	for _, mark := range w.marks {
+		level := int(math.Ceil(mark.fraction * float64(size)))
+		var crossed bool
+		if mark.rising {
+			crossed = used >= level
+		} else {
+			crossed = used <= level
+		}
+		if crossed && !mark.crossed {
+			fire = append(fire, mark.fn)
+		}
+		mark.crossed = crossed
+	}
+	w.mutex.Unlock()
+
+	for _, fn := range fire {
+		fn()
+	}
+}
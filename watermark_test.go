@@ -0,0 +1,40 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestQueueWatermarks(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			<-release
+			return x, nil
+		}}, nil
+	}, gorkpool.WithQueueSize(4), gorkpool.WithOutputBufferSize(5))
+	var above, below int32
+	pool.OnQueueAbove(0.75, func() { atomic.AddInt32(&above, 1) })
+	pool.OnQueueBelow(0.25, func() { atomic.AddInt32(&below, 1) })
+	pool.AddWorker(0)
+	// Action
+	for i := 0; i < 5; i++ {
+		pool.AddTask(i)
+	}
+	// Assert
+	waitFor(t, func() bool { return atomic.LoadInt32(&above) == 1 })
+	if got := atomic.LoadInt32(&below); got != 0 {
+		t.Errorf("expected low watermark not to fire before the queue filled up, fired %d times", got)
+	}
+	close(release)
+	waitFor(t, func() bool { return atomic.LoadInt32(&below) == 1 })
+	if got := atomic.LoadInt32(&above); got != 1 {
+		t.Errorf("expected high watermark to fire once, fired %d times", got)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
@@ -0,0 +1,25 @@
+package gorkpool
+
+// WithWeight sets how heavy the task is compared to others, which default to
+// a weight of 1. Dispatching to the least busy worker weighs the tasks each
+// one has queued and in hand, and InFlightWeight sums the weights of the
+// tasks being handled.
+func WithWeight(weight int) TaskOption {
+	return func(c *taskConfig) {
+		c.weight = weight
+	}
+}
+
+func (env *envelope[Task, Result]) cost() int {
+	if env.weight < 1 {
+		return 1
+	}
+	return env.weight
+}
+
+// InFlightWeight is InFlight with each task counted by its weight.
+func (p *GorkPool[Id, Task, Result]) InFlightWeight() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.inFlightWeight
+}
@@ -0,0 +1,49 @@
+package gorkpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+func TestWithWeight(t *testing.T) {
+	// Setup
+	var mutex sync.Mutex
+	handledBy := make(map[int]int)
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id, fn: func(ctx context.Context, x int) (int, error) {
+			mutex.Lock()
+			handledBy[x] = id
+			mutex.Unlock()
+			<-release
+			return x, nil
+		}}, nil
+	}, gorkpool.WithDispatchMode(gorkpool.DispatchLeastBusy), gorkpool.WithWorkerQueueSize(4),
+		gorkpool.WithQueueSize(10), gorkpool.WithOutputBufferSize(10))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	pool.AddTask(0, gorkpool.WithWeight(5))
+	waitFor(t, func() bool { return pool.InFlightWeight() == 5 })
+	for i := 1; i <= 3; i++ {
+		pool.AddTask(i)
+	}
+	waitFor(t, func() bool { return pool.InFlight() == 2 && pool.QueueLen() == 2 })
+	close(release)
+	for i := 0; i < 4; i++ {
+		<-pool.OutputCh()
+	}
+	// Assert
+	for i := 1; i <= 3; i++ {
+		if handledBy[i] == handledBy[0] {
+			t.Errorf("expected task %d to go to the worker without the heavy task, got worker %d", i, handledBy[i])
+		}
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
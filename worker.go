@@ -0,0 +1,449 @@
+package gorkpool
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"runtime/trace"
+	"sync"
+	"time"
+)
+
+type workerState[Id comparable, Task any, Result any] struct {
+	worker  GorkWorker[Id, Task, Result]
+	handler TaskHandler[Task, Result]
+	batch   BatchHandler[Task, Result]
+	runner  Runner
+	tags    map[string]struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	// done is closed once the worker's goroutine returns
+	done chan struct{}
+	// labels are the profiler labels of the worker's goroutine, if any
+	labels context.Context
+	// queue and inputCh are the worker's own queue when dispatching to per
+	// worker queues, for TaskHandler and channel workers respectively
+	queue   chan *envelope[Task, Result]
+	inputCh chan Task
+	// direct takes the tasks of Broadcast, for TaskHandler workers
+	direct chan *envelope[Task, Result]
+	// pausing wakes TaskHandler workers up when paused
+	pausing chan struct{}
+	// capacity is the share of the tasks the worker gets, see Weighted
+	capacity int
+	// signalled makes sure the worker is signalled to stop once, confirmed
+	// being closed once it took the signal or exited
+	signalled   sync.Once
+	confirmed   chan struct{}
+	confirmOnce sync.Once
+
+	// Guarded by the pool mutex
+	seq       uint64
+	busy      int
+	pinned    int
+	idleSince time.Time
+	// weight sums the weights of the tasks queued for or handled by the worker
+	weight     int
+	lastActive time.Time
+	// busySince is when busy last went up from zero, busyTime adds up the
+	// time spent busy before it
+	busySince time.Time
+	busyTime  time.Duration
+	added     time.Time
+	processed int
+	errors    int
+	clock     Clock
+	// failures counts the consecutive failures for the circuit breaker
+	failures  int
+	openUntil time.Time
+	trips     int
+	// resumed is closed by ResumeWorker, nil unless paused
+	resumed chan struct{}
+	// ready workers count for WaitReady
+	ready bool
+}
+
+func (p *GorkPool[Id, Task, Result]) newWorkerState(w GorkWorker[Id, Task, Result]) *workerState[Id, Task, Result] {
+	ws := &workerState[Id, Task, Result]{
+		worker:    w,
+		done:      make(chan struct{}),
+		confirmed: make(chan struct{}),
+		added:     p.cfg.clock.Now(),
+		clock:     p.cfg.clock,
+	}
+	ws.capacity = capacityOf(w)
+	if h, ok := w.(TaskHandler[Task, Result]); ok {
+		ws.handler = h
+	} else if b, ok := w.(BatchHandler[Task, Result]); ok {
+		ws.handler, ws.batch = batchTaskHandler[Task, Result]{b}, b
+	} else if a, ok := w.(AckHandler[Task, Result]); ok {
+		ws.handler = ackHandler[Task, Result]{
+			AckHandler: a,
+			visibility: p.cfg.visibilityTimeout,
+			clock:      p.cfg.clock,
+			launch:     p.launch,
+			recovers:   p.recovers,
+			recovered: func(task any, v any) {
+				p.recovered(w.ID(), task, v)
+			},
+		}
+	} else if r, ok := w.(Runner); ok {
+		ws.runner = r
+	}
+	if ws.handler != nil || ws.runner != nil {
+		ws.ctx, ws.cancel = context.WithCancel(context.Background())
+	}
+	if ws.handler != nil {
+		ws.direct = make(chan *envelope[Task, Result])
+		ws.pausing = make(chan struct{}, 1)
+	}
+	if t, ok := w.(Tagged); ok {
+		ws.tags = make(map[string]struct{})
+		for _, tag := range t.Tags() {
+			ws.tags[tag] = struct{}{}
+		}
+	}
+	return ws
+}
+
+func (ws *workerState[Id, Task, Result]) exited() bool {
+	select {
+	case <-ws.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// confirm closes ws.confirmed, once.
+func (ws *workerState[Id, Task, Result]) confirm() {
+	ws.confirmOnce.Do(func() {
+		close(ws.confirmed)
+	})
+}
+
+// runWorker runs the processing loop of ws until it returns.
+func (p *GorkPool[Id, Task, Result]) runWorker(id Id, ws *workerState[Id, Task, Result]) {
+	defer p.wg.Done()
+	defer p.workers.left(ws)
+	defer close(ws.done)
+	defer p.stopped(id)
+	if p.cfg.profilerLabels != "" {
+		p.labelWorker(id, ws)
+	}
+	if p.perWorkerQueues() {
+		defer p.reclaim(id, ws)
+	}
+	if l, ok := ws.worker.(Lifecycle); ok {
+		defer func() {
+			if err := l.Stop(context.Background()); err != nil {
+				p.reportErr(NewErrWorker(id, err))
+			}
+		}()
+	}
+	defer func() {
+		// Checked once panicking, SetPanicReporter may come after the worker
+		if !p.recovers() {
+			return
+		}
+		if v := recover(); v != nil {
+			p.recovered(id, nil, v)
+			p.mutex.Lock()
+			p.unregister(id, ws)
+			p.mutex.Unlock()
+		}
+	}()
+
+	// Once it returns it can't take the removal signal anymore
+	defer ws.confirm()
+	switch {
+	case p.batching(ws):
+		p.serveBatch(ws)
+	case ws.handler != nil:
+		p.serveAll(ws)
+	case ws.runner != nil:
+		p.drive(id, ws)
+	default:
+		ws.worker.Process()
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) reportErr(err error) {
+	p.logf("gorkpool: %v", err)
+	p.recordErr(err)
+	if p.cfg.errorHandler != nil {
+		p.cfg.errorHandler(err)
+	}
+}
+
+// recovered reports the panic v of the worker with id while handling task.
+// It must be called from the deferred function recovering it, for the stack
+// trace to be the panic's.
+func (p *GorkPool[Id, Task, Result]) recovered(id Id, task any, v any) {
+	p.logf("gorkpool: recovered worker panic: %v", v)
+	if p.cfg.panicHandler != nil {
+		p.cfg.panicHandler(v)
+	}
+	if fn := p.panicReporter.Load(); fn != nil {
+		(*fn)(id, task, v, debug.Stack())
+	}
+}
+
+// drive runs Runner workers. One that returns on its own while the pool is
+// running, with or without an error, is no longer part of the pool.
+func (p *GorkPool[Id, Task, Result]) drive(id Id, ws *workerState[Id, Task, Result]) {
+	err := ws.runner.Run(ws.ctx)
+	stopped := ws.ctx.Err() != nil
+	ws.cancel()
+
+	if err != nil && !(stopped && errors.Is(err, context.Canceled)) {
+		p.reportErr(NewErrWorker(id, err))
+	}
+
+	p.mutex.Lock()
+	if p.state == StateRunning && p.workers.holds(id, ws) {
+		p.unregister(id, ws)
+	}
+	p.mutex.Unlock()
+}
+
+// serve is the processing loop of TaskHandler workers. Besides the tasks
+// dispatched to them, they also take whatever is left in the input channel.
+func (p *GorkPool[Id, Task, Result]) serve(ws *workerState[Id, Task, Result]) {
+	defer ws.cancel()
+
+	taskCh, inputCh := p.taskCh, p.inputCh
+	if ws.queue != nil {
+		taskCh = ws.queue
+	}
+	var stealable chan struct{}
+	if p.cfg.workStealing && ws.queue != nil {
+		stealable = p.stealable
+	}
+	for taskCh != nil || inputCh != nil {
+		if !p.coolDown(ws) || !p.workerUnpaused(ws) {
+			return
+		}
+		if stealable != nil && len(ws.queue) == 0 {
+			if env, ok := p.steal(ws); ok {
+				p.handle(ws, env)
+				continue
+			}
+		}
+
+		select {
+		case <-stealable:
+		case <-ws.pausing:
+		case <-ws.ctx.Done():
+			return
+		case env := <-ws.direct:
+			p.handle(ws, env)
+		case env, ok := <-taskCh:
+			if !ok {
+				if p.cfg.externalChannels {
+					return
+				}
+				taskCh = nil
+				continue
+			}
+			if ws.queue != nil {
+				p.taken(ws, env)
+			}
+			if ws.ctx.Err() != nil {
+				p.reroute(env)
+				return
+			}
+			p.handle(ws, env)
+		case task, ok := <-inputCh:
+			if !ok {
+				inputCh = nil
+				continue
+			}
+			env := p.alloc(task)
+			if ws.ctx.Err() != nil {
+				p.reroute(env)
+				return
+			}
+			p.handle(ws, env)
+		}
+	}
+}
+
+func (p *GorkPool[Id, Task, Result]) handle(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) {
+	for env != nil {
+		env = p.handleOne(ws, env)
+	}
+}
+
+// handleOne handles env, returning the task waiting for its concurrency key
+// that ws should handle next, if any.
+func (p *GorkPool[Id, Task, Result]) handleOne(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) *envelope[Task, Result] {
+	// Its place is given up in claim if so
+	p.orphaned(env)
+	ctx, cancel := p.taskContext(ws.ctx, env)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	p.mutex.Lock()
+	if ok, next, dropped := p.claim(ws, env); !ok {
+		p.mutex.Unlock()
+		if dropped != nil {
+			p.drop(env, dropped)
+		}
+		return next
+	}
+	env.cancel = cancel
+	startHooks, endHooks := p.startHooks, p.endHooks
+	handler := wrap(ws.handler, p.middleware)
+	p.mutex.Unlock()
+
+	var event TaskEvent[Id, Task, Result]
+	if len(startHooks) > 0 || len(endHooks) > 0 || p.recent != nil {
+		event = newTaskEvent(ws, env, p.cfg.clock.Now())
+		runHooks(startHooks, event)
+	}
+	started := p.cfg.clock.Now()
+	ws.labelTask(env.task)
+	var region *trace.Region
+	if p.tracing() {
+		region = p.traceRegion(ctx, "task", ws.worker.ID(), env.task)
+	}
+	result, err := p.call(p.taskValues(ctx, ws, env), ws.worker.ID(), handler, env.task)
+	if region != nil {
+		region.End()
+	}
+	ws.unlabelTask()
+	now := p.cfg.clock.Now()
+
+	p.mutex.Lock()
+	next, interrupted, tripped := p.complete(ws, env, err, now.Sub(started), now)
+	p.mutex.Unlock()
+
+	if len(endHooks) > 0 || p.recent != nil {
+		event.Result, event.Err, event.Duration = result, err, now.Sub(started)
+		runHooks(endHooks, event)
+		p.remember(event, now)
+	}
+	if tripped {
+		p.tripped(ws)
+	}
+	if broken(err) {
+		p.replace(ws.worker.ID(), ws, NewErrWorker(ws.worker.ID(), err))
+	}
+	p.conclude(env, result, err, interrupted)
+	return next
+}
+
+// claim takes env up for ws to handle, telling whether it is to be handled.
+// If not, next is the task of its key taking its place, if any, and an
+// expired or stale env is to be dropped with dropped once p.mutex is released.
+// The caller must hold it.
+func (p *GorkPool[Id, Task, Result]) claim(ws *workerState[Id, Task, Result], env *envelope[Task, Result]) (ok bool, next *envelope[Task, Result], dropped error) {
+	if env.canceled {
+		// It may have been handed the place of a task of its key
+		p.disown(env)
+		return false, p.releaseKey(ws, env), nil
+	}
+	// It may have expired or gone stale waiting in the queue of ws or of its key
+	if env.expired(p.cfg.clock.Now()) {
+		p.disown(env)
+		return false, p.releaseKey(ws, env), context.DeadlineExceeded
+	}
+	if env.stale(p.cfg.clock.Now()) {
+		p.disown(env)
+		return false, p.releaseKey(ws, env), NewErrTaskStale()
+	}
+	if !p.admitKey(env) {
+		return false, nil, nil
+	}
+	ws.markBusy(env.cost())
+	p.inFlight++
+	p.inFlightWeight += env.cost()
+	env.attempt++
+	env.handling = true
+	return true, nil, nil
+}
+
+// complete accounts for ws being done with env, telling whether env was
+// interrupted and the worker's circuit breaker tripped. next is the task of
+// its key taking its place, if any. The caller must hold p.mutex.
+func (p *GorkPool[Id, Task, Result]) complete(ws *workerState[Id, Task, Result], env *envelope[Task, Result], err error, took time.Duration, now time.Time) (next *envelope[Task, Result], interrupted bool, tripped bool) {
+	env.cancel, env.handling = nil, false
+	ws.markIdle(now, env.cost())
+	p.processing.observe(took)
+	p.inFlight--
+	p.inFlightWeight -= env.cost()
+	next = p.releaseKey(ws, env)
+	p.disown(env)
+	interrupted = p.interrupted(ws, err) || redeliver(err)
+	if !interrupted {
+		ws.counted(err)
+		p.counted(err)
+		tripped = ws.trip(err, now, p.cfg.breaker)
+	}
+	return next, interrupted, tripped
+}
+
+// conclude delivers the outcome of handling env.
+func (p *GorkPool[Id, Task, Result]) conclude(env *envelope[Task, Result], result Result, err error, interrupted bool) {
+	if env.direct {
+		p.settle(env, result, err)
+		return
+	}
+	if err != nil && p.orphaned(env) {
+		return
+	}
+	if interrupted {
+		p.requeue(env)
+		return
+	}
+	if err != nil {
+		p.fail(env, err)
+		return
+	}
+
+	p.finish(env)
+	p.settle(env, result, nil)
+	p.recycle(env)
+}
+
+// taskContext is the context env is handled in. Only envelopes CancelTask,
+// their Future or their submitter's context can cancel, or having a deadline,
+// need one of their own, the others run in ctx and cancel is nil.
+func (p *GorkPool[Id, Task, Result]) taskContext(ctx context.Context, env *envelope[Task, Result]) (context.Context, context.CancelFunc) {
+	var cancel context.CancelFunc
+	switch {
+	case !env.deadline.IsZero():
+		ctx, cancel = context.WithDeadline(ctx, env.deadline)
+	case env.id != "" || env.future != nil:
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	if env.parent != nil {
+		return p.withParent(ctx, cancel, env.parent)
+	}
+	return ctx, cancel
+}
+
+// broken tells whether the worker that failed a task with err can't take any
+// other and must be replaced.
+func broken(err error) bool {
+	if err == nil {
+		return false
+	}
+	var exited ErrProcessExited
+	return connLost(err) || errors.As(err, &exited)
+}
+
+// call runs h, turning a panic into an ErrPanic if the pool recovers them.
+func (p *GorkPool[Id, Task, Result]) call(ctx context.Context, id Id, h TaskHandler[Task, Result], task Task) (result Result, err error) {
+	if p.recovers() {
+		defer func() {
+			if v := recover(); v != nil {
+				p.recovered(id, task, v)
+				err = NewErrPanic(v)
+			}
+		}()
+	}
+	return h.Handle(ctx, task)
+}
@@ -0,0 +1,266 @@
+package gorkpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaovictorsl/gorkpool"
+)
+
+type testRunner struct {
+	id     int
+	input  chan int
+	output chan int
+	err    error
+}
+
+func (w *testRunner) ID() int {
+	return w.id
+}
+
+func (w *testRunner) Process() {}
+
+func (w *testRunner) SignalRemoval() {}
+
+func (w *testRunner) Run(ctx context.Context) error {
+	if w.err != nil {
+		return w.err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case x, ok := <-w.input:
+			if !ok {
+				return nil
+			}
+			w.output <- -x
+		}
+	}
+}
+
+func TestRunnerWorker(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testRunner{id: id, input: ic, output: oc}, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	pool.AddTask(1)
+	// Assert
+	if got := <-pool.OutputCh(); got != -1 {
+		t.Errorf("expected result %d, got %d", -1, got)
+	}
+	if w := pool.RemoveWorker(); w == nil {
+		t.Error("expected runner worker to be removed")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRunnerWorkerError(t *testing.T) {
+	// Setup
+	errs := make(chan error, 1)
+	failure := errors.New("connection lost")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testRunner{id: id, input: ic, output: oc, err: failure}, nil
+	}, gorkpool.WithErrorHandler(func(err error) { errs <- err }))
+	// Action
+	pool.AddWorker(0)
+	// Assert
+	err := <-errs
+	var workerErr gorkpool.ErrWorker
+	if !errors.As(err, &workerErr) || workerErr.ID() != 0 || !errors.Is(err, failure) {
+		t.Errorf("expected ErrWorker for worker 0 wrapping %v, got %v", failure, err)
+	}
+	waitFor(t, func() bool { return pool.Length() == 0 })
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+type testLifecycle struct {
+	testHandler
+	startErr error
+	started  chan int
+	stopped  chan int
+}
+
+func (w *testLifecycle) Start() error {
+	if w.startErr != nil {
+		return w.startErr
+	}
+	w.started <- w.id
+	return nil
+}
+
+func (w *testLifecycle) Stop(ctx context.Context) error {
+	w.stopped <- w.id
+	return nil
+}
+
+func TestLifecycleWorker(t *testing.T) {
+	// Setup
+	started, stopped := make(chan int, 2), make(chan int, 2)
+	failure := errors.New("can't connect")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		w := &testLifecycle{testHandler: testHandler{id: id}, started: started, stopped: stopped}
+		if id == 1 {
+			w.startErr = failure
+		}
+		return w, nil
+	})
+	// Action
+	okErr := pool.AddWorker(0)
+	failErr := pool.AddWorker(1)
+	// Assert
+	if okErr != nil {
+		t.Errorf("expected worker 0 to start, got %v", okErr)
+	}
+	if !errors.Is(failErr, failure) || pool.Contains(1) {
+		t.Errorf("expected worker 1 to fail with %v, got %v", failure, failErr)
+	}
+	if id := <-started; id != 0 {
+		t.Errorf("expected worker 0 to be started, got %d", id)
+	}
+	pool.RemoveWorkerById(0)
+	if id := <-stopped; id != 0 {
+		t.Errorf("expected worker 0 to be stopped, got %d", id)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRemoveWorkerWait(t *testing.T) {
+	// Setup
+	stopped := make(chan int, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testLifecycle{testHandler: testHandler{id: id}, started: make(chan int, 1), stopped: stopped}, nil
+	})
+	pool.AddWorker(0)
+	// Action
+	w, err := pool.RemoveWorkerByIdWait(context.Background(), 0)
+	// Assert
+	if err != nil || w == nil || w.ID() != 0 {
+		t.Fatalf("expected worker 0 to be removed, got %v, %v", w, err)
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Error("expected worker to be done by the time removal returns")
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}
+
+func TestRemoveWorkerWaitTimeout(t *testing.T) {
+	// Setup
+	busy, release := make(chan struct{}), make(chan struct{})
+	pool, cancel := setupHandlerPool(func(ctx context.Context, x int) (int, error) {
+		close(busy)
+		<-release
+		return x, nil
+	})
+	pool.AddWorker(0)
+	pool.AddTask(1)
+	<-busy
+	ctx, cancelRemoval := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelRemoval()
+	// Action
+	w, err := pool.RemoveWorkerWait(ctx)
+	// Assert
+	var timeoutErr gorkpool.ErrRemovalTimeout
+	if w == nil || !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ErrRemovalTimeout, got %v, %v", w, err)
+	}
+	// Cleanup
+	close(release)
+	cancel()
+	pool.Wait()
+}
+
+func TestRemoveWorkerErrors(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id}, nil
+	}, gorkpool.WithMinWorkers(1))
+	// Action
+	_, emptyErr := pool.RemoveWorkerWait(context.Background())
+	pool.AddWorker(0)
+	_, missingErr := pool.RemoveWorkerByIdWait(context.Background(), 1)
+	_, minErr := pool.RemoveWorkerByIdWait(context.Background(), 0)
+	cancel()
+	pool.Wait()
+	_, closedErr := pool.RemoveWorkerWait(context.Background())
+	// Assert
+	if !errors.Is(emptyErr, gorkpool.CodeWorkerNotFound) {
+		t.Errorf("expected ErrWorkerNotFound without workers, got %v", emptyErr)
+	}
+	var notFound gorkpool.ErrWorkerNotFound
+	if !errors.As(missingErr, &notFound) || notFound.ID() != 1 {
+		t.Errorf("expected ErrWorkerNotFound for worker 1, got %v", missingErr)
+	}
+	var minWorkers gorkpool.ErrMinWorkers
+	if !errors.As(minErr, &minWorkers) || minWorkers.Min() != 1 {
+		t.Errorf("expected ErrMinWorkers, got %v", minErr)
+	}
+	if !errors.Is(closedErr, gorkpool.ErrPoolClosed{}) {
+		t.Errorf("expected ErrPoolClosed, got %v", closedErr)
+	}
+}
+
+func TestTryRemoveWorker(t *testing.T) {
+	// Setup
+	pool := gorkpool.NewGorkPoolWithOptions(context.Background(), func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return &testHandler{id: id}, nil
+	}, gorkpool.WithMinWorkers(1))
+	pool.AddWorker(0)
+	pool.AddWorker(1)
+	// Action
+	missing := pool.RemoveWorkerById(2)
+	_, missingErr := pool.TryRemoveWorkerById(2)
+	w, err := pool.TryRemoveWorkerById(1)
+	_, minErr := pool.TryRemoveWorker()
+	// Assert
+	var notFound gorkpool.ErrWorkerNotFound
+	if missing != nil || !errors.As(missingErr, &notFound) || notFound.ID() != 2 {
+		t.Errorf("expected ErrWorkerNotFound for worker 2, got %v and %v", missing, missingErr)
+	}
+	if err != nil || w == nil || w.ID() != 1 {
+		t.Errorf("expected worker 1 to be removed, got %v and %v", w, err)
+	}
+	if !errors.Is(minErr, gorkpool.CodeMinWorkers) {
+		t.Errorf("expected ErrMinWorkers, got %v", minErr)
+	}
+	// Cleanup
+	pool.Shutdown(context.Background())
+}
+
+func TestAddWorkerFactoryFailed(t *testing.T) {
+	// Setup
+	failure := errors.New("failure")
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := gorkpool.NewGorkPoolWithOptions(ctx, func(id int, ic chan int, oc chan int) (gorkpool.GorkWorker[int, int, int], error) {
+		return nil, failure
+	})
+	// Action
+	err := pool.AddWorker(0)
+	// Assert
+	var factoryErr gorkpool.ErrFactoryFailed
+	if !errors.As(err, &factoryErr) || factoryErr.ID() != 0 || !errors.Is(err, failure) {
+		t.Errorf("expected ErrFactoryFailed wrapping the failure, got %v", err)
+	}
+	// Cleanup
+	cancel()
+	pool.Wait()
+}